@@ -1,10 +1,17 @@
 package cmd
 
 import (
+	"context"
 	"log"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/Amierza/nawasena-backend/dto"
 	"github.com/Amierza/nawasena-backend/migrations"
+	"github.com/Amierza/nawasena-backend/repository"
+	"github.com/Amierza/nawasena-backend/service"
+	"github.com/Amierza/nawasena-backend/storage"
 	"gorm.io/gorm"
 )
 
@@ -12,6 +19,11 @@ func Command(db *gorm.DB) {
 	migrate := false
 	seed := false
 	rollback := false
+	backfillDerivatives := false
+	backfillSlugs := false
+	gcUploads := false
+	dryRun := false
+	grace := ""
 
 	for _, arg := range os.Args[1:] {
 		if arg == "--migrate" {
@@ -25,6 +37,27 @@ func Command(db *gorm.DB) {
 		if arg == "--rollback" {
 			rollback = true
 		}
+
+		if arg == "--backfill-derivatives" {
+			backfillDerivatives = true
+		}
+
+		if arg == "--backfill-slugs" {
+			backfillSlugs = true
+		}
+
+		if arg == "--gc-uploads" {
+			gcUploads = true
+		}
+
+		if arg == "--dry-run" {
+			dryRun = true
+		}
+
+		// --grace=48h, default dari UPLOAD_GC_GRACE_HOURS
+		if value, ok := strings.CutPrefix(arg, "--grace="); ok {
+			grace = value
+		}
 	}
 
 	if migrate {
@@ -50,4 +83,58 @@ func Command(db *gorm.DB) {
 
 		log.Println("rollback complete successfully")
 	}
+
+	if backfillDerivatives {
+		fileService := service.NewFileService(storage.NewStorage(), nil, repository.NewMediaRepository(db))
+
+		generated, err := fileService.GenerateMissingDerivatives(context.Background())
+		if err != nil {
+			log.Fatalf("error backfill derivatives: %v", err)
+		}
+
+		log.Printf("backfill derivatives complete successfully, %d file(s) processed", generated)
+	}
+
+	if backfillSlugs {
+		slugService := service.NewSlugService(repository.NewSlugRepository(db))
+
+		generated, err := slugService.BackfillMissing(context.Background())
+		if err != nil {
+			log.Fatalf("error backfill slugs: %v", err)
+		}
+
+		log.Printf("backfill slugs complete successfully, %d slug(s) generated", generated)
+	}
+
+	if gcUploads {
+		storage := storage.NewStorage()
+		mediaRepo := repository.NewMediaRepository(db)
+		fileService := service.NewFileService(storage, nil, mediaRepo)
+		auditLogService := service.NewAuditLogService(repository.NewAuditLogRepository(db))
+		gcService := service.NewUploadGCService(storage, fileService, repository.NewUploadReferenceRepository(db), mediaRepo, auditLogService)
+
+		gracePeriod := gcService.GracePeriod()
+		if grace != "" {
+			parsed, err := time.ParseDuration(grace)
+			if err != nil {
+				log.Fatalf("error gc uploads: invalid --grace %q: %v", grace, err)
+			}
+			gracePeriod = parsed
+		}
+
+		res, err := gcService.Collect(context.Background(), dto.UploadGCRequest{DryRun: dryRun, GracePeriod: gracePeriod})
+		if err != nil {
+			log.Fatalf("error gc uploads: %v", err)
+		}
+
+		for _, key := range res.Orphans {
+			log.Printf("orphan: %s", key)
+		}
+		if dryRun {
+			log.Printf("gc uploads dry run: scanned %d, referenced %d, library %d, recent %d, %d orphan(s) would be deleted", res.Scanned, res.Referenced, res.Library, res.Recent, len(res.Orphans))
+			return
+		}
+
+		log.Printf("gc uploads complete successfully: scanned %d, deleted %d, freed %d bytes", res.Scanned, res.Deleted, res.FreedBytes)
+	}
 }
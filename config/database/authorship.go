@@ -0,0 +1,85 @@
+package database
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RegisterAuthorshipCallbacks mengisi created_by_id / updated_by_id pada entity
+// yang meng-embed entity.Authorship berdasarkan "admin_id" di context request.
+func RegisterAuthorshipCallbacks(db *gorm.DB) error {
+	if err := db.Callback().Create().Before("gorm:save_before_associations").Register("authorship:created_by", setCreatedBy); err != nil {
+		return err
+	}
+
+	return db.Callback().Update().Before("gorm:save_before_associations").Register("authorship:updated_by", setUpdatedBy)
+}
+
+func setCreatedBy(db *gorm.DB) {
+	if !hasAuthorship(db) {
+		return
+	}
+
+	omitAuthorAssociations(db)
+
+	adminID, ok := adminIDFromContext(db)
+	if !ok {
+		return
+	}
+
+	db.Statement.SetColumn("CreatedByID", &adminID, true)
+	db.Statement.SetColumn("UpdatedByID", &adminID, true)
+}
+
+func setUpdatedBy(db *gorm.DB) {
+	if !hasAuthorship(db) {
+		return
+	}
+
+	omitAuthorAssociations(db)
+
+	// UpdateColumn / UpdateColumns sengaja tidak menyentuh kolom lain (mis. increment views)
+	if db.Statement.SkipHooks {
+		return
+	}
+
+	adminID, ok := adminIDFromContext(db)
+	if !ok {
+		return
+	}
+
+	// repository yang memakai Select(...) hanya menulis kolom yang dipilih
+	if len(db.Statement.Selects) > 0 {
+		db.Statement.Selects = append(db.Statement.Selects, "UpdatedByID")
+	}
+
+	db.Statement.SetColumn("UpdatedByID", &adminID, true)
+}
+
+func hasAuthorship(db *gorm.DB) bool {
+	return db.Error == nil && db.Statement.Schema != nil && db.Statement.Schema.LookUpField("UpdatedByID") != nil
+}
+
+// relasi admin hasil preload jangan ikut di-upsert, selain menimpa kolom *_by_id
+// gorm juga akan mencoba insert ulang admin tersebut
+func omitAuthorAssociations(db *gorm.DB) {
+	db.Statement.Omits = append(db.Statement.Omits, "CreatedBy", "UpdatedBy")
+}
+
+func adminIDFromContext(db *gorm.DB) (uuid.UUID, bool) {
+	if db.Statement.Context == nil {
+		return uuid.Nil, false
+	}
+
+	adminID, ok := db.Statement.Context.Value("admin_id").(string)
+	if !ok || adminID == "" {
+		return uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(adminID)
+	if err != nil {
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
@@ -35,6 +35,10 @@ func SetUpPostgreSQLConnection() *gorm.DB {
 		panic(fmt.Errorf("failed to connect postgres: %v", err))
 	}
 
+	if err := RegisterAuthorshipCallbacks(db); err != nil {
+		panic(fmt.Errorf("failed to register authorship callbacks: %v", err))
+	}
+
 	log.Println("postgres connection established")
 	return db
 }
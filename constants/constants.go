@@ -3,6 +3,113 @@ package constants
 const (
 	ENUM_ROLE_SUPER_ADMIN = "super admin"
 	ENUM_ROLE_ADMIN       = "admin"
+	ENUM_ROLE_EDITOR      = "editor"
+
+	ENUM_PERMISSION_ADMINS_MANAGE                 = "admins:manage"
+	ENUM_PERMISSION_AUDIT_LOGS_READ               = "audit_logs:read"
+	ENUM_PERMISSION_UPLOADS_WRITE                 = "uploads:write"
+	ENUM_PERMISSION_MEDIA_DELETE                  = "media:delete"
+	ENUM_PERMISSION_MEMBERS_WRITE                 = "members:write"
+	ENUM_PERMISSION_MEMBERS_DELETE                = "members:delete"
+	ENUM_PERMISSION_POSITIONS_WRITE               = "positions:write"
+	ENUM_PERMISSION_POSITIONS_DELETE              = "positions:delete"
+	ENUM_PERMISSION_ACHIEVEMENT_CATEGORIES_WRITE  = "achievement_categories:write"
+	ENUM_PERMISSION_ACHIEVEMENT_CATEGORIES_DELETE = "achievement_categories:delete"
+	ENUM_PERMISSION_ACHIEVEMENTS_WRITE            = "achievements:write"
+	ENUM_PERMISSION_ACHIEVEMENTS_DELETE           = "achievements:delete"
+	ENUM_PERMISSION_SHIPS_WRITE                   = "ships:write"
+	ENUM_PERMISSION_SHIPS_DELETE                  = "ships:delete"
+	ENUM_PERMISSION_COMPETITIONS_WRITE            = "competitions:write"
+	ENUM_PERMISSION_COMPETITIONS_DELETE           = "competitions:delete"
+	ENUM_PERMISSION_NEWS_CATEGORIES_WRITE         = "news_categories:write"
+	ENUM_PERMISSION_NEWS_CATEGORIES_DELETE        = "news_categories:delete"
+	ENUM_PERMISSION_NEWS_WRITE                    = "news:write"
+	ENUM_PERMISSION_NEWS_DELETE                   = "news:delete"
+	ENUM_PERMISSION_PARTNERS_WRITE                = "partners:write"
+	ENUM_PERMISSION_PARTNERS_DELETE               = "partners:delete"
+	ENUM_PERMISSION_FLYERS_WRITE                  = "flyers:write"
+	ENUM_PERMISSION_FLYERS_DELETE                 = "flyers:delete"
+
+	ENUM_AUDIT_ACTION_CREATE          = "create"
+	ENUM_AUDIT_ACTION_UPDATE          = "update"
+	ENUM_AUDIT_ACTION_DELETE          = "delete"
+	ENUM_AUDIT_ACTION_ASSIGN_ROLE     = "assign_role"
+	ENUM_AUDIT_ACTION_CHANGE_PASSWORD = "change_password"
+	ENUM_AUDIT_ACTION_REVOKE_SESSIONS = "revoke_sessions"
+	ENUM_AUDIT_ACTION_ENABLE_2FA      = "enable_2fa"
+	ENUM_AUDIT_ACTION_RESET_2FA       = "reset_2fa"
+	ENUM_AUDIT_ACTION_REVOKE          = "revoke"
+	ENUM_AUDIT_ACTION_SIGN_URL        = "sign_url"
+	ENUM_AUDIT_ACTION_PUBLISH         = "publish"
+	ENUM_AUDIT_ACTION_RESTORE         = "restore"
+	ENUM_AUDIT_ACTION_GC              = "gc"
+
+	ENUM_AUDIT_ENTITY_ADMIN                = "admin"
+	ENUM_AUDIT_ENTITY_ROLE                 = "role"
+	ENUM_AUDIT_ENTITY_LOGIN_THROTTLE       = "login_throttle"
+	ENUM_AUDIT_ENTITY_API_KEY              = "api_key"
+	ENUM_AUDIT_ENTITY_POSITION             = "position"
+	ENUM_AUDIT_ENTITY_MEMBER               = "member"
+	ENUM_AUDIT_ENTITY_ACHIEVEMENT_CATEGORY = "achievement_category"
+	ENUM_AUDIT_ENTITY_ACHIEVEMENT          = "achievement"
+	ENUM_AUDIT_ENTITY_SHIP                 = "ship"
+	ENUM_AUDIT_ENTITY_COMPETITION          = "competition"
+	ENUM_AUDIT_ENTITY_NEWS_CATEGORY        = "news_category"
+	ENUM_AUDIT_ENTITY_NEWS                 = "news"
+	ENUM_AUDIT_ENTITY_PARTNER              = "partner"
+	ENUM_AUDIT_ENTITY_FLYER                = "flyer"
+	ENUM_AUDIT_ENTITY_MEDIA                = "media"
+
+	ENUM_TOKEN_ACCESS  = "access"
+	ENUM_TOKEN_REFRESH = "refresh"
+	ENUM_TOKEN_2FA     = "2fa_challenge"
+
+	ENUM_PASSWORD_RESET_TOKEN_TTL_MINUTES = 30
+	ENUM_2FA_RECOVERY_CODE_COUNT          = 10
+
+	ENUM_LOGIN_MAX_ATTEMPTS_PER_EMAIL = 5
+	ENUM_LOGIN_MAX_ATTEMPTS_PER_IP    = 20
+	ENUM_LOGIN_LOCKOUT_BASE_SECONDS   = 30
+	ENUM_LOGIN_LOCKOUT_MAX_SECONDS    = 3600
+
+	ENUM_API_KEY_PREFIX                     = "nws_"
+	ENUM_API_KEY_LAST_USED_INTERVAL_SECONDS = 60
+
+	ENUM_UPLOAD_MAX_FILE_SIZE_MB    = 5
+	ENUM_UPLOAD_MAX_REQUEST_SIZE_MB = 20
+	ENUM_UPLOAD_MAX_FILES           = 10
+	ENUM_UPLOAD_MAX_IMAGE_PIXELS    = 40_000_000
+	ENUM_UPLOAD_IMAGE_SIZES         = "thumbnail:320,medium:800,large:1280"
+	ENUM_UPLOAD_KEEP_METADATA       = "Artist,Copyright"
+	ENUM_UPLOAD_GC_GRACE_HOURS      = 24
+	ENUM_UPLOAD_CHUNK_SIZE_MB       = 5
+	ENUM_UPLOAD_MAX_RESUMABLE_MB    = 500
+	ENUM_UPLOAD_SESSION_TTL_HOURS   = 24
+
+	ENUM_VISIBILITY_PUBLIC  = "public"
+	ENUM_VISIBILITY_PRIVATE = "private"
+
+	ENUM_SIGNED_URL_TTL_MINUTES     = 15
+	ENUM_SIGNED_URL_MAX_TTL_MINUTES = 1440
+
+	ENUM_PUBLICATION_DRAFT     = "draft"
+	ENUM_PUBLICATION_SCHEDULED = "scheduled"
+	ENUM_PUBLICATION_PUBLISHED = "published"
+	ENUM_PUBLICATION_ARCHIVED  = "archived"
+
+	ENUM_NEWS_PUBLISH_INTERVAL_SECONDS = 60
+	ENUM_NEWS_SUMMARY_MAX_LENGTH       = 300
+	ENUM_NEWS_DIFF_MAX_CELLS           = 1_000_000 // batas tabel LCS diff revisi (~8 MB)
+	ENUM_NEWS_MAX_TAGS                 = 10
+	ENUM_NEWS_TAG_MAX_LENGTH           = 50
+	ENUM_NEWS_RELATED_LIMIT            = 4
+	ENUM_NEWS_RELATED_MAX_LIMIT        = 20
+
+	ENUM_FILE_ERROR_UNSUPPORTED_TYPE = "unsupported_type"
+	ENUM_FILE_ERROR_TYPE_MISMATCH    = "type_mismatch"
+	ENUM_FILE_ERROR_TOO_LARGE        = "too_large"
+	ENUM_FILE_ERROR_INVALID_IMAGE    = "invalid_image"
+	ENUM_FILE_ERROR_SAVE_FAILED      = "save_failed"
 
 	ENUM_RUN_PRODUCTION = "production"
 	ENUM_RUN_TESTING    = "testing"
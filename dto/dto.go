@@ -2,9 +2,13 @@ package dto
 
 import (
 	"errors"
+	"io"
+	"mime/multipart"
+	"time"
 
 	"github.com/Amierza/nawasena-backend/entity"
 	"github.com/Amierza/nawasena-backend/response"
+	"github.com/Amierza/nawasena-backend/storage"
 )
 
 const (
@@ -18,6 +22,7 @@ const (
 	MESSAGE_FAILED_TOKEN_NOT_VALID   = "failed token not valid"
 	MESSAGE_FAILED_GET_CUSTOM_CLAIMS = "failed get custom claims"
 	MESSAGE_FAILED_GET_ROLE_USER     = "failed get role user"
+	MESSAGE_FAILED_SESSION_REVOKED   = "failed session revoked"
 
 	// Middleware
 	MESSAGE_FAILED_TOKEN_DENIED_ACCESS = "failed token denied access"
@@ -28,10 +33,37 @@ const (
 	MESSAGE_FAILED_FILES_IS_EMPTY       = "failed files is empty"
 	MESSAGE_FAILED_UPLOAD_FILES         = "failed upload files"
 	MESSAGE_FAILED_UPLOAD_FILE          = "failed upload file"
+	MESSAGE_FAILED_GET_FILE             = "failed get file"
+
+	// Upload Session
+	MESSAGE_FAILED_CREATE_UPLOAD_SESSION = "failed create upload session"
+	MESSAGE_FAILED_GET_UPLOAD_SESSION    = "failed get upload session"
+	MESSAGE_FAILED_APPEND_UPLOAD_CHUNK   = "failed append upload chunk"
+	MESSAGE_FAILED_COMPLETE_UPLOAD       = "failed complete upload"
+	MESSAGE_FAILED_ABORT_UPLOAD          = "failed abort upload"
+
+	// Media
+	MESSAGE_FAILED_GET_LIST_MEDIA   = "failed get all media"
+	MESSAGE_FAILED_GET_DETAIL_MEDIA = "failed get detail media"
+	MESSAGE_FAILED_UPDATE_MEDIA     = "failed update media"
+	MESSAGE_FAILED_DELETE_MEDIA     = "failed delete media"
+	MESSAGE_FAILED_SIGN_MEDIA_URL   = "failed create signed media url"
+	MESSAGE_FAILED_DOWNLOAD_MEDIA   = "failed download media"
 
 	// Authentication
-	MESSAGE_FAILED_LOGIN_USER    = "failed login user"
-	MESSAGE_FAILED_REFRESH_TOKEN = "failed refresh token"
+	MESSAGE_FAILED_LOGIN_USER       = "failed login user"
+	MESSAGE_FAILED_REFRESH_TOKEN    = "failed refresh token"
+	MESSAGE_FAILED_LOGOUT           = "failed logout"
+	MESSAGE_FAILED_LOGOUT_ALL       = "failed logout all session"
+	MESSAGE_FAILED_CHANGE_PASSWORD  = "failed change password"
+	MESSAGE_FAILED_FORGOT_PASSWORD  = "failed forgot password"
+	MESSAGE_FAILED_RESET_PASSWORD   = "failed reset password"
+	MESSAGE_FAILED_LOGIN_2FA        = "failed login two factor"
+	MESSAGE_FAILED_ENROLL_2FA       = "failed enroll two factor"
+	MESSAGE_FAILED_CONFIRM_2FA      = "failed confirm two factor"
+	MESSAGE_FAILED_RESET_2FA        = "failed reset two factor"
+	MESSAGE_FAILED_GET_LIST_LOCKOUT = "failed get all login lockout"
+	MESSAGE_FAILED_CLEAR_LOCKOUT    = "failed clear login lockout"
 
 	// Admin
 	MESSAGE_FAILED_CREATE_ADMIN     = "failed create admin"
@@ -39,6 +71,26 @@ const (
 	MESSAGE_FAILED_GET_DETAIL_ADMIN = "failed get detail admin"
 	MESSAGE_FAILED_UPDATE_ADMIN     = "failed update admin"
 	MESSAGE_FAILED_DELETE_ADMIN     = "failed delete admin"
+	MESSAGE_FAILED_GET_PROFILE      = "failed get profile"
+	MESSAGE_FAILED_UPDATE_PROFILE   = "failed update profile"
+
+	// Role
+	MESSAGE_FAILED_CREATE_ROLE         = "failed create role"
+	MESSAGE_FAILED_GET_LIST_ROLE       = "failed get all role"
+	MESSAGE_FAILED_GET_DETAIL_ROLE     = "failed get detail role"
+	MESSAGE_FAILED_UPDATE_ROLE         = "failed update role"
+	MESSAGE_FAILED_DELETE_ROLE         = "failed delete role"
+	MESSAGE_FAILED_GET_LIST_PERMISSION = "failed get all permission"
+	MESSAGE_FAILED_ASSIGN_ROLE         = "failed assign role"
+
+	// Audit Log
+	MESSAGE_FAILED_GET_LIST_AUDIT_LOG = "failed get all audit log"
+
+	// API Key
+	MESSAGE_FAILED_CREATE_API_KEY    = "failed create api key"
+	MESSAGE_FAILED_GET_LIST_API_KEY  = "failed get all api key"
+	MESSAGE_FAILED_REVOKE_API_KEY    = "failed revoke api key"
+	MESSAGE_FAILED_API_KEY_NOT_VALID = "api key not valid"
 
 	// Position
 	MESSAGE_FAILED_CREATE_POSITION     = "failed create position"
@@ -95,6 +147,20 @@ const (
 	MESSAGE_FAILED_GET_DETAIL_NEWS = "failed get detail news"
 	MESSAGE_FAILED_UPDATE_NEWS     = "failed update news"
 	MESSAGE_FAILED_DELETE_NEWS     = "failed delete news"
+	MESSAGE_FAILED_GET_NEWS_TAGS   = "failed get all news tags"
+	MESSAGE_FAILED_GET_RELATED     = "failed get related news"
+
+	// News Revision
+	MESSAGE_FAILED_GET_LIST_NEWS_REVISION   = "failed get all news revision"
+	MESSAGE_FAILED_GET_DETAIL_NEWS_REVISION = "failed get detail news revision"
+	MESSAGE_FAILED_DIFF_NEWS_REVISION       = "failed diff news revision"
+	MESSAGE_FAILED_RESTORE_NEWS_REVISION    = "failed restore news revision"
+
+	// Gallery (news, achievement, ship, competition)
+	MESSAGE_FAILED_ADD_IMAGE      = "failed add image"
+	MESSAGE_FAILED_UPDATE_IMAGE   = "failed update image"
+	MESSAGE_FAILED_REMOVE_IMAGE   = "failed remove image"
+	MESSAGE_FAILED_REORDER_IMAGES = "failed reorder images"
 
 	// Partner
 	MESSAGE_FAILED_CREATE_PARTNER     = "failed create partner"
@@ -115,9 +181,34 @@ const (
 	MESSAGE_SUCCESS_UPLOAD_FILES = "success upload files"
 	MESSAGE_SUCCESS_UPLOAD_FILE  = "success upload file"
 
+	// Upload Session
+	MESSAGE_SUCCESS_CREATE_UPLOAD_SESSION = "success create upload session"
+	MESSAGE_SUCCESS_GET_UPLOAD_SESSION    = "success get upload session"
+	MESSAGE_SUCCESS_APPEND_UPLOAD_CHUNK   = "success append upload chunk"
+	MESSAGE_SUCCESS_COMPLETE_UPLOAD       = "success complete upload"
+	MESSAGE_SUCCESS_ABORT_UPLOAD          = "success abort upload"
+
+	// Media
+	MESSAGE_SUCCESS_GET_LIST_MEDIA   = "success get all media"
+	MESSAGE_SUCCESS_GET_DETAIL_MEDIA = "success get detail media"
+	MESSAGE_SUCCESS_UPDATE_MEDIA     = "success update media"
+	MESSAGE_SUCCESS_DELETE_MEDIA     = "success delete media"
+	MESSAGE_SUCCESS_SIGN_MEDIA_URL   = "success create signed media url"
+
 	// Authentication
-	MESSAGE_SUCCESS_LOGIN_USER    = "success login user"
-	MESSAGE_SUCCESS_REFRESH_TOKEN = "success refresh token"
+	MESSAGE_SUCCESS_LOGIN_USER       = "success login user"
+	MESSAGE_SUCCESS_REFRESH_TOKEN    = "success refresh token"
+	MESSAGE_SUCCESS_LOGOUT           = "success logout"
+	MESSAGE_SUCCESS_LOGOUT_ALL       = "success logout all session"
+	MESSAGE_SUCCESS_CHANGE_PASSWORD  = "success change password"
+	MESSAGE_SUCCESS_FORGOT_PASSWORD  = "if the email is registered, a reset link has been sent"
+	MESSAGE_SUCCESS_RESET_PASSWORD   = "success reset password"
+	MESSAGE_SUCCESS_LOGIN_2FA        = "success login two factor"
+	MESSAGE_SUCCESS_ENROLL_2FA       = "success enroll two factor"
+	MESSAGE_SUCCESS_CONFIRM_2FA      = "success confirm two factor"
+	MESSAGE_SUCCESS_RESET_2FA        = "success reset two factor"
+	MESSAGE_SUCCESS_GET_LIST_LOCKOUT = "success get all login lockout"
+	MESSAGE_SUCCESS_CLEAR_LOCKOUT    = "success clear login lockout"
 
 	// Admin
 	MESSAGE_SUCCESS_CREATE_ADMIN     = "success create admin"
@@ -125,6 +216,25 @@ const (
 	MESSAGE_SUCCESS_GET_DETAIL_ADMIN = "success get detail admin"
 	MESSAGE_SUCCESS_UPDATE_ADMIN     = "success update admin"
 	MESSAGE_SUCCESS_DELETE_ADMIN     = "success delete admin"
+	MESSAGE_SUCCESS_GET_PROFILE      = "success get profile"
+	MESSAGE_SUCCESS_UPDATE_PROFILE   = "success update profile"
+
+	// Role
+	MESSAGE_SUCCESS_CREATE_ROLE         = "success create role"
+	MESSAGE_SUCCESS_GET_LIST_ROLE       = "success get all role"
+	MESSAGE_SUCCESS_GET_DETAIL_ROLE     = "success get detail role"
+	MESSAGE_SUCCESS_UPDATE_ROLE         = "success update role"
+	MESSAGE_SUCCESS_DELETE_ROLE         = "success delete role"
+	MESSAGE_SUCCESS_GET_LIST_PERMISSION = "success get all permission"
+	MESSAGE_SUCCESS_ASSIGN_ROLE         = "success assign role"
+
+	// Audit Log
+	MESSAGE_SUCCESS_GET_LIST_AUDIT_LOG = "success get all audit log"
+
+	// API Key
+	MESSAGE_SUCCESS_CREATE_API_KEY   = "success create api key"
+	MESSAGE_SUCCESS_GET_LIST_API_KEY = "success get all api key"
+	MESSAGE_SUCCESS_REVOKE_API_KEY   = "success revoke api key"
 
 	// Position
 	MESSAGE_SUCCESS_CREATE_POSITION     = "success create position"
@@ -181,6 +291,20 @@ const (
 	MESSAGE_SUCCESS_GET_DETAIL_NEWS = "success get detail news"
 	MESSAGE_SUCCESS_UPDATE_NEWS     = "success update news"
 	MESSAGE_SUCCESS_DELETE_NEWS     = "success delete news"
+	MESSAGE_SUCCESS_GET_NEWS_TAGS   = "success get all news tags"
+	MESSAGE_SUCCESS_GET_RELATED     = "success get related news"
+
+	// News Revision
+	MESSAGE_SUCCESS_GET_LIST_NEWS_REVISION   = "success get all news revision"
+	MESSAGE_SUCCESS_GET_DETAIL_NEWS_REVISION = "success get detail news revision"
+	MESSAGE_SUCCESS_DIFF_NEWS_REVISION       = "success diff news revision"
+	MESSAGE_SUCCESS_RESTORE_NEWS_REVISION    = "success restore news revision"
+
+	// Gallery (news, achievement, ship, competition)
+	MESSAGE_SUCCESS_ADD_IMAGE      = "success add image"
+	MESSAGE_SUCCESS_UPDATE_IMAGE   = "success update image"
+	MESSAGE_SUCCESS_REMOVE_IMAGE   = "success remove image"
+	MESSAGE_SUCCESS_REORDER_IMAGES = "success reorder images"
 
 	// Partner
 	MESSAGE_SUCCESS_CREATE_PARTNER     = "success create partner"
@@ -202,11 +326,25 @@ var (
 	ErrGenerateAccessToken       = errors.New("failed to generate access token")
 	ErrGenerateRefreshToken      = errors.New("failed to generate refresh token")
 	ErrUnexpectedSigningMethod   = errors.New("unexpected signing method")
+	ErrUnknownSigningKey         = errors.New("unknown signing key")
 	ErrDecryptToken              = errors.New("failed to decrypt token")
 	ErrTokenInvalid              = errors.New("token invalid")
 	ErrValidateToken             = errors.New("failed to validate token")
 	ErrGetAdminIDFromToken       = errors.New("failed get admin id from token")
 	ErrGetAdminRoleNameFromToken = errors.New("failed get admin role name from token")
+	ErrGetSessionIDFromToken     = errors.New("failed get session id from token")
+	ErrGenerateChallengeToken    = errors.New("failed to generate two factor challenge token")
+	ErrWrongTokenType            = errors.New("wrong token type")
+
+	// Session
+	ErrCreateRefreshToken   = errors.New("failed create refresh token")
+	ErrGetRefreshToken      = errors.New("failed get refresh token")
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+	ErrRefreshTokenReused   = errors.New("refresh token already used, session revoked")
+	ErrRevokeRefreshToken   = errors.New("failed revoke refresh token")
+	ErrRevokeSession        = errors.New("failed revoke session")
+	ErrGetSession           = errors.New("failed get session")
+	ErrSessionRevoked       = errors.New("session has been revoked")
 
 	// Parse
 	ErrParseUUID                 = errors.New("failed parse to uuid format")
@@ -215,50 +353,136 @@ var (
 	ErrParseTimeFromTimeToString = errors.New("failed parse time format from time.Time to string")
 
 	// Middleware
-	ErrDeniedAccess = errors.New("denied access")
+	ErrDeniedAccess     = errors.New("denied access")
+	ErrPermissionDenied = errors.New("missing permission")
+	ErrGetPermissions   = errors.New("failed get admin permissions")
 
 	// Input Validation
-	ErrEmptyEmail          = errors.New("email is required")
-	ErrEmptyPassword       = errors.New("password is required")
-	ErrEmptyName           = errors.New("name is required")
-	ErrNameTooShort        = errors.New("name must be at least 3 characters")
-	ErrEmptyDesc           = errors.New("description is required")
-	ErrDescTooShort        = errors.New("description must be at least 5 characters")
-	ErrEmptyImage          = errors.New("failed image is required")
-	ErrFormatImage         = errors.New("format image must be has prefix assets/")
-	ErrEmptyPhoneNumber    = errors.New("failed phone number is required")
-	ErrEmptyMajor          = errors.New("failed major is required")
-	ErrEmptyGeneration     = errors.New("failed generation is required")
-	ErrTypeGeneration      = errors.New("failed generation is must be int")
-	ErrEmptyYear           = errors.New("failed year is required")
-	ErrEmptyDescription    = errors.New("failed description is required")
-	ErrDescriptionTooShort = errors.New("description must be at least 5 characters")
-	ErrEmptyImages         = errors.New("failed images is required")
-	ErrEmptyDate           = errors.New("failed date is required")
-	ErrEmptyLocation       = errors.New("failed location is required")
-	ErrLocationTooShort    = errors.New("location must be at least 5 characters")
-	ErrEmptyStatus         = errors.New("failed status is required")
-	ErrEmptyNewsCategory   = errors.New("failed news category is required")
-	ErrEmptyTeam           = errors.New("failed team is required")
-	ErrEmptyTags           = errors.New("failed tags is required")
+	ErrEmptyEmail           = errors.New("email is required")
+	ErrEmptyPassword        = errors.New("password is required")
+	ErrEmptyName            = errors.New("name is required")
+	ErrNameTooShort         = errors.New("name must be at least 3 characters")
+	ErrEmptyDesc            = errors.New("description is required")
+	ErrDescTooShort         = errors.New("description must be at least 5 characters")
+	ErrEmptyImage           = errors.New("failed image is required")
+	ErrFormatImage          = errors.New("format image must be has prefix assets/")
+	ErrFormatAvatar         = errors.New("avatar must be an uploaded file or an http(s) url")
+	ErrEmptyPhoneNumber     = errors.New("failed phone number is required")
+	ErrEmptyMajor           = errors.New("failed major is required")
+	ErrEmptyGeneration      = errors.New("failed generation is required")
+	ErrTypeGeneration       = errors.New("failed generation is must be int")
+	ErrEmptyYear            = errors.New("failed year is required")
+	ErrEmptyDescription     = errors.New("failed description is required")
+	ErrDescriptionTooShort  = errors.New("description must be at least 5 characters")
+	ErrEmptyImages          = errors.New("failed images is required")
+	ErrImageNotFound        = errors.New("image not found")
+	ErrLastImage            = errors.New("cannot remove the last image")
+	ErrInvalidImagePosition = errors.New("invalid image position")
+	ErrInvalidImageOrder    = errors.New("image order must list every image exactly once")
+	ErrUpdateImage          = errors.New("failed update image")
+	ErrDeleteImage          = errors.New("failed delete image")
+	ErrEmptyDate            = errors.New("failed date is required")
+	ErrEmptyLocation        = errors.New("failed location is required")
+	ErrLocationTooShort     = errors.New("location must be at least 5 characters")
+	ErrEmptyStatus          = errors.New("failed status is required")
+	ErrEmptyNewsCategory    = errors.New("failed news category is required")
+	ErrEmptyTeam            = errors.New("failed team is required")
+	ErrEmptyTags            = errors.New("failed tags is required")
 
 	// Phone Number
 	ErrFormatPhoneNumber = errors.New("failed format phone number")
 
 	// File
 	ErrNoFilesUploaded    = errors.New("failed no files uploaded")
-	ErrInvalidFileType    = errors.New("only jpg/jpeg/png allowed")
 	ErrSaveFile           = errors.New("failed save file")
 	ErrCreateFolderAssets = errors.New("failed create folder assets")
 	ErrDeleteOldImage     = errors.New("failed to delete old image")
+	ErrFileNotFound       = errors.New("file not found")
+	ErrTooManyFiles       = errors.New("too many files in one request")
+	ErrUploadTooLarge     = errors.New("upload request too large")
+	ErrAllFilesRejected   = errors.New("all files rejected")
+	ErrGetFile            = errors.New("failed get file")
+
+	// Upload Session
+	ErrEmptyFilename           = errors.New("failed filename is required")
+	ErrUnsupportedFileType     = errors.New("unsupported file type")
+	ErrInvalidUploadSize       = errors.New("upload size must be greater than 0")
+	ErrInvalidChecksum         = errors.New("checksum must be a sha-256 hex digest")
+	ErrCreateUploadSession     = errors.New("failed create upload session")
+	ErrGetUploadSessionByID    = errors.New("failed get upload session by id")
+	ErrUploadSessionNotFound   = errors.New("upload session not found")
+	ErrUploadSessionExpired    = errors.New("upload session expired")
+	ErrInvalidUploadOffset     = errors.New("invalid upload offset")
+	ErrUploadOffsetMismatch    = errors.New("upload offset does not match")
+	ErrChunkTooLarge           = errors.New("upload chunk too large")
+	ErrChunkExceedsUploadSize  = errors.New("upload chunk exceeds declared size")
+	ErrChunkChecksumMismatch   = errors.New("upload chunk checksum mismatch")
+	ErrWriteUploadChunk        = errors.New("failed write upload chunk")
+	ErrUploadIncomplete        = errors.New("upload is not complete yet")
+	ErrUploadChecksumMismatch  = errors.New("uploaded file checksum mismatch")
+	ErrDeleteUploadSessionByID = errors.New("failed delete upload session by id")
+
+	// Media
+	ErrCreateMedia               = errors.New("failed create media")
+	ErrGetMediaByID              = errors.New("failed get media by id")
+	ErrGetMediaByName            = errors.New("failed get media by name")
+	ErrMediaNotFound             = errors.New("media not found")
+	ErrGetAllMediaWithPagination = errors.New("failed get all media with pagination")
+	ErrUpdateMedia               = errors.New("failed update media")
+	ErrDeleteMediaByID           = errors.New("failed delete media by id")
+	ErrMediaInUse                = errors.New("media is still used and cannot be deleted")
+	ErrInvalidVisibility         = errors.New("visibility must be public or private")
+	ErrPrivateStorageUnavailable = errors.New("private storage is not configured")
+	ErrPrivateMedia              = errors.New("private media cannot be used as a public image")
+	ErrInvalidSignedURLTTL       = errors.New("invalid signed url expiry")
+	ErrSignMediaURL              = errors.New("failed sign media url")
+	ErrInvalidSignature          = errors.New("invalid download signature")
+	ErrSignedURLExpired          = errors.New("download link expired")
+	ErrAltTooLong                = errors.New("alt text must be at most 255 characters")
+
+	// Slug
+	ErrGenerateSlug = errors.New("failed generate slug")
+	ErrResolveSlug  = errors.New("failed resolve slug")
 
 	// Auth
 	ErrInvalidEmail      = errors.New("email is required and must be in a valid format (ex: admin@example.com)")
 	ErrInvalidPassword   = errors.New("password is required and must be at least 8 characters long")
 	ErrIncorrectPassword = errors.New("incorrect password")
+	ErrSamePassword      = errors.New("new password must be different from current password")
+	ErrUpdatePassword    = errors.New("failed update password")
+
+	// Login Throttle
+	ErrLoginLocked           = errors.New("too many failed login attempts")
+	ErrGetLoginThrottle      = errors.New("failed get login throttle")
+	ErrClearLoginThrottle    = errors.New("failed clear login throttle")
+	ErrLoginThrottleNotFound = errors.New("login throttle not found")
+
+	// Password Reset
+	ErrGeneratePasswordResetToken = errors.New("failed generate password reset token")
+	ErrCreatePasswordResetToken   = errors.New("failed create password reset token")
+	ErrGetPasswordResetToken      = errors.New("failed get password reset token")
+	ErrUsePasswordResetToken      = errors.New("failed use password reset token")
+	ErrPasswordResetTokenInvalid  = errors.New("password reset token is invalid or expired")
+
+	// Two Factor
+	ErrEmptyTwoFactorCode      = errors.New("two factor code is required")
+	ErrInvalidTwoFactorCode    = errors.New("invalid two factor code")
+	ErrTwoFactorNotEnabled     = errors.New("two factor is not enabled")
+	ErrTwoFactorAlreadyEnabled = errors.New("two factor is already enabled")
+	ErrTwoFactorNotEnrolled    = errors.New("two factor enrollment not started")
+	ErrGenerateTwoFactorSecret = errors.New("failed generate two factor secret")
+	ErrEncryptTwoFactorSecret  = errors.New("failed encrypt two factor secret")
+	ErrDecryptTwoFactorSecret  = errors.New("failed decrypt two factor secret")
+	ErrUpdateTwoFactor         = errors.New("failed update two factor")
+	ErrGenerateRecoveryCode    = errors.New("failed generate recovery code")
+	ErrCreateRecoveryCode      = errors.New("failed create recovery code")
+	ErrGetRecoveryCode         = errors.New("failed get recovery code")
+	ErrUseRecoveryCode         = errors.New("failed use recovery code")
+	ErrDeleteRecoveryCodes     = errors.New("failed delete recovery codes")
 
 	// Admin
 	ErrGetAdminByEmail           = errors.New("failed get admin by email")
+	ErrGetAdminByID              = errors.New("failed get admin by id")
 	ErrAdminNotFound             = errors.New("admin not found")
 	ErrEmailAlreadyExists        = errors.New("failed email already exists")
 	ErrHashPassword              = errors.New("failed hash password")
@@ -269,6 +493,45 @@ var (
 	ErrAdminAlreadyExists        = errors.New("failed admin already exists")
 	ErrUpdateAdmin               = errors.New("failed update admin")
 	ErrDeleteAdminByID           = errors.New("failed delete admin by id")
+	ErrDeleteOwnAccount          = errors.New("cannot delete your own account")
+	ErrDeleteLastSuperAdmin      = errors.New("cannot delete the last super admin")
+
+	// Role
+	ErrGetRoleByID          = errors.New("failed get role by id")
+	ErrGetRoleByName        = errors.New("failed get role by name")
+	ErrRoleNotFound         = errors.New("role not found")
+	ErrRoleAlreadyExists    = errors.New("failed role already exists")
+	ErrRoleNameTooLong      = errors.New("role name must be at most 20 characters")
+	ErrCreateRole           = errors.New("failed create role")
+	ErrGetAllRole           = errors.New("failed get all role")
+	ErrUpdateRole           = errors.New("failed update role")
+	ErrDeleteRole           = errors.New("failed delete role")
+	ErrRoleInUse            = errors.New("role is still assigned to admins")
+	ErrSystemRole           = errors.New("system role cannot be changed")
+	ErrInvalidPermission    = errors.New("invalid permission")
+	ErrGetAllPermission     = errors.New("failed get all permission")
+	ErrAssignRole           = errors.New("failed assign role")
+	ErrChangeOwnRole        = errors.New("cannot change your own role")
+	ErrDemoteLastSuperAdmin = errors.New("cannot demote the last super admin")
+
+	// Audit Log
+	ErrGetAllAuditLog   = errors.New("failed get all audit log")
+	ErrInvalidDateRange = errors.New("invalid date range, use YYYY-MM-DD or RFC3339")
+
+	// API Key
+	ErrEmptyAPIKeyScopes   = errors.New("api key must have at least one scope")
+	ErrInvalidAPIKeyScope  = errors.New("invalid api key scope")
+	ErrInvalidAPIKeyExpiry = errors.New("api key expiry must be in the future")
+	ErrGenerateAPIKey      = errors.New("failed generate api key")
+	ErrCreateAPIKey        = errors.New("failed create api key")
+	ErrGetAllAPIKey        = errors.New("failed get all api key")
+	ErrGetAPIKey           = errors.New("failed get api key")
+	ErrAPIKeyNotFound      = errors.New("api key not found")
+	ErrRevokeAPIKey        = errors.New("failed revoke api key")
+	ErrAPIKeyInvalid       = errors.New("api key invalid")
+	ErrAPIKeyExpired       = errors.New("api key expired")
+	ErrAPIKeyRevoked       = errors.New("api key revoked")
+	ErrAPIKeyNotAllowed    = errors.New("this endpoint requires an interactive session, api keys are not accepted")
 
 	// Position
 	ErrGetPositionByName            = errors.New("failed get position by name")
@@ -373,6 +636,25 @@ var (
 	ErrUpdateNews               = errors.New("failed update news")
 	ErrDeleteNewsByID           = errors.New("failed delete news by id")
 	ErrDeleteNewsImageByNewsID  = errors.New("failed delete news image by news id")
+	ErrInvalidPublicationStatus = errors.New("failed invalid publication status")
+	ErrEmptyPublishAt           = errors.New("failed publish_at is required for scheduled news")
+	ErrInvalidPublishAt         = errors.New("failed publish_at must be in the future")
+	ErrPublishScheduledNews     = errors.New("failed publish scheduled news")
+	ErrSummaryTooLong           = errors.New("summary must be at most 300 characters")
+	ErrInvalidBodyImage         = errors.New("failed body image must be an uploaded image")
+	ErrInvalidTag               = errors.New("failed tag must contain letters or digits and be at most 50 characters")
+	ErrTooManyTags              = errors.New("failed news can have at most 10 tags")
+	ErrSaveNewsTags             = errors.New("failed save news tags")
+	ErrGetNewsTags              = errors.New("failed get news tags")
+	ErrGetRelatedNews           = errors.New("failed get related news")
+
+	// News Revision
+	ErrGetNewsRevisions      = errors.New("failed get news revisions")
+	ErrGetNewsRevision       = errors.New("failed get news revision")
+	ErrNewsRevisionNotFound  = errors.New("news revision not found")
+	ErrInvalidRevisionNumber = errors.New("failed invalid revision number")
+	ErrCreateNewsRevision    = errors.New("failed create news revision")
+	ErrDeleteNewsRevisions   = errors.New("failed delete news revisions")
 
 	// Partner
 	ErrGetPartnerByID              = errors.New("failed get partner by id")
@@ -399,21 +681,249 @@ var (
 	ErrDeleteFlyerByID           = errors.New("failed delete flyer by id")
 )
 
+// Gallery, dipakai bersama oleh news, achievement, ship dan competition
+type (
+	AddImageRequest struct {
+		ParentID string `json:"-"`
+		// Image berisi id media atau nama file hasil upload
+		Image   string `json:"image" example:"6f1c2b1e-3d4a-4f5b-9c8d-7e6f5a4b3c2d"`
+		Caption string `json:"caption,omitempty" example:"Tim saat uji coba kapal"`
+		Alt     string `json:"alt,omitempty" example:"Kapal di kolam uji"`
+		IsCover bool   `json:"is_cover,omitempty" example:"false"`
+		// Position kosong berarti ditambahkan di akhir
+		Position *int `json:"position,omitempty" example:"0"`
+	}
+	UpdateImageRequest struct {
+		ParentID string  `json:"-"`
+		ImageID  string  `json:"-"`
+		Caption  *string `json:"caption,omitempty"`
+		Alt      *string `json:"alt,omitempty"`
+		IsCover  *bool   `json:"is_cover,omitempty"`
+	}
+	ReorderImagesRequest struct {
+		ParentID string   `json:"-"`
+		ImageIDs []string `json:"image_ids"`
+	}
+)
+
+// Slug, dipakai bersama oleh news, achievement, ship dan competition
+type (
+	SlugTarget struct {
+		ID   string
+		Name string
+	}
+	// SlugMovedError dikembalikan GetDetail kalau diakses lewat slug lama, handler membalas redirect ke Slug
+	SlugMovedError struct {
+		Slug string
+	}
+)
+
+func (se SlugMovedError) Error() string {
+	return "slug has moved to " + se.Slug
+}
+
+// File
+type (
+	UploadedFileResponse struct {
+		Filename    string                `json:"filename" example:"foto-kapal.png"`
+		Name        string                `json:"name" example:"0b166e1b-c103-4013-8d76-ed4d3d06df43.png"`
+		URL         string                `json:"url" example:"/uploads/0b166e1b-c103-4013-8d76-ed4d3d06df43.png"`
+		ContentType string                `json:"content_type" example:"image/png"`
+		Size        int64                 `json:"size" example:"204800"`
+		Hash        string                `json:"hash" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+		Duplicate   bool                  `json:"duplicate" example:"false"`
+		MediaID     string                `json:"media_id" example:"6f1c2b1e-3d4a-4f5b-9c8d-7e6f5a4b3c2d"`
+		Visibility  string                `json:"visibility" example:"public"`
+		Sources     *ImageSourcesResponse `json:"sources,omitempty"`
+	}
+	ImageVariantResponse struct {
+		Name  string `json:"name" example:"thumbnail"`
+		Width int    `json:"width" example:"320"`
+		URL   string `json:"url" example:"/uploads/0b166e1b-c103-4013-8d76-ed4d3d06df43_thumbnail.png"`
+		WebP  string `json:"webp,omitempty" example:"/uploads/0b166e1b-c103-4013-8d76-ed4d3d06df43_thumbnail.webp"`
+	}
+	ImageSourcesResponse struct {
+		Original   string                 `json:"original" example:"/uploads/0b166e1b-c103-4013-8d76-ed4d3d06df43.png"`
+		Srcset     string                 `json:"srcset" example:"/uploads/0b166e1b-c103-4013-8d76-ed4d3d06df43_thumbnail.png 320w, /uploads/0b166e1b-c103-4013-8d76-ed4d3d06df43_medium.png 800w"`
+		WebPSrcset string                 `json:"webp_srcset,omitempty" example:"/uploads/0b166e1b-c103-4013-8d76-ed4d3d06df43_thumbnail.webp 320w, /uploads/0b166e1b-c103-4013-8d76-ed4d3d06df43_medium.webp 800w"`
+		Variants   []ImageVariantResponse `json:"variants"`
+	}
+	FileError struct {
+		Index    int    `json:"index" example:"0"`
+		Filename string `json:"filename" example:"bukan-gambar.png"`
+		Code     string `json:"code" example:"type_mismatch"`
+		Message  string `json:"message" example:"file content is application/pdf, expected image/png"`
+	}
+	UploadFilesResponse struct {
+		Files  []UploadedFileResponse `json:"files"`
+		Errors []FileError            `json:"errors,omitempty"`
+	}
+)
+
+func (fe FileError) Error() string {
+	return fe.Filename + ": " + fe.Message
+}
+
+// Upload Session
+type (
+	CreateUploadSessionRequest struct {
+		Filename string `json:"filename" example:"final-kontes-kapal.mp4"`
+		Size     int64  `json:"size" example:"734003200"`
+		Checksum string `json:"checksum" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+		// Visibility kosong berarti public
+		Visibility string `json:"visibility,omitempty" example:"public"`
+	}
+	AppendUploadChunkRequest struct {
+		ID       string
+		Offset   int64
+		Checksum string
+		Body     io.Reader
+	}
+	UploadSessionResponse struct {
+		ID         string    `json:"id"`
+		Filename   string    `json:"filename"`
+		Size       int64     `json:"size"`
+		Offset     int64     `json:"offset"`
+		ChunkSize  int64     `json:"chunk_size"`
+		Checksum   string    `json:"checksum"`
+		Visibility string    `json:"visibility"`
+		ExpiresAt  time.Time `json:"expires_at"`
+	}
+)
+
+// Upload GC
+type (
+	UploadGCRequest struct {
+		DryRun      bool
+		GracePeriod time.Duration
+	}
+	UploadGCResponse struct {
+		DryRun     bool     `json:"dry_run"`
+		Scanned    int      `json:"scanned"`
+		Referenced int      `json:"referenced"`
+		Library    int      `json:"library"`
+		Recent     int      `json:"recent"`
+		Orphans    []string `json:"orphans"`
+		Deleted    int      `json:"deleted"`
+		FreedBytes int64    `json:"freed_bytes"`
+	}
+)
+
+// Media
+type (
+	MediaResponse struct {
+		ID           string                `json:"id"`
+		Name         string                `json:"name"`
+		OriginalName string                `json:"original_name"`
+		URL          string                `json:"url"`
+		ContentType  string                `json:"content_type"`
+		Size         int64                 `json:"size"`
+		Hash         string                `json:"hash"`
+		Width        int                   `json:"width"`
+		Height       int                   `json:"height"`
+		Alt          string                `json:"alt"`
+		Caption      string                `json:"caption"`
+		Visibility   string                `json:"visibility"`
+		DetachedAt   *time.Time            `json:"detached_at,omitempty"`
+		Sources      *ImageSourcesResponse `json:"sources,omitempty"`
+		UploadedBy   *AuthorResponse       `json:"uploaded_by,omitempty"`
+		UpdatedBy    *AuthorResponse       `json:"updated_by,omitempty"`
+		CreatedAt    time.Time             `json:"created_at"`
+	}
+	MediaPaginationRequest struct {
+		response.PaginationRequest
+		Hash       string `form:"hash"`
+		Visibility string `form:"visibility"`
+	}
+	UpdateMediaRequest struct {
+		ID      string  `json:"-"`
+		Alt     *string `json:"alt,omitempty"`
+		Caption *string `json:"caption,omitempty"`
+	}
+	MediaPaginationResponse struct {
+		response.PaginationResponse
+		Data []MediaResponse `json:"data"`
+	}
+	MediaPaginationRepositoryResponse struct {
+		response.PaginationResponse
+		Medias []entity.Media
+	}
+	SignMediaURLRequest struct {
+		ID string `json:"-"`
+		// ExpiresIn dalam detik, kosong berarti default 15 menit
+		ExpiresIn int `json:"expires_in,omitempty" example:"900"`
+	}
+	SignedMediaURLResponse struct {
+		URL       string    `json:"url" example:"/files/0b166e1b-c103-4013-8d76-ed4d3d06df43.pdf?expires=1767225600&signature=3q2-7w..."`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	DownloadMediaRequest struct {
+		Name      string
+		Expires   string
+		Signature string
+	}
+	MediaDownloadResponse struct {
+		Filename string
+		Body     io.ReadCloser
+		Info     storage.ObjectInfo
+	}
+)
+
 // Authentiation for Admin
 type (
 	LoginRequest struct {
 		Email    string `json:"email" example:"user@example.com"`
 		Password string `json:"password" example:"secret123"`
+		IP       string `json:"-"`
 	}
 	LoginResponse struct {
-		AccessToken  string `json:"access_token" example:"<access_token_here>"`
-		RefreshToken string `json:"refresh_token" example:"<refresh_token_here>"`
+		AccessToken       string `json:"access_token,omitempty" example:"<access_token_here>"`
+		RefreshToken      string `json:"refresh_token,omitempty" example:"<refresh_token_here>"`
+		TwoFactorRequired bool   `json:"two_factor_required,omitempty" example:"false"`
+		ChallengeToken    string `json:"challenge_token,omitempty" example:"<challenge_token_here>"`
+	}
+	LoginTwoFactorRequest struct {
+		ChallengeToken string `json:"challenge_token" example:"<challenge_token_here>"`
+		Code           string `json:"code" example:"123456"`
+		IP             string `json:"-"`
+	}
+	TwoFactorEnrollResponse struct {
+		Secret     string `json:"secret" example:"JBSWY3DPEHPK3PXP"`
+		OtpauthURI string `json:"otpauth_uri" example:"otpauth://totp/Nawasena:admin@example.com?secret=JBSWY3DPEHPK3PXP"`
+	}
+	ConfirmTwoFactorRequest struct {
+		AdminID string `json:"-"`
+		Code    string `json:"code" example:"123456"`
+	}
+	TwoFactorRecoveryCodesResponse struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	}
+	LoginThrottleResponse struct {
+		ID           string `json:"id"`
+		Key          string `json:"key"`
+		FailedCount  int    `json:"failed_count"`
+		LastFailedAt string `json:"last_failed_at"`
+		LockedUntil  string `json:"locked_until"`
+		Locked       bool   `json:"locked"`
 	}
 	RefreshTokenRequest struct {
 		RefreshToken string `json:"refresh_token" example:"<refresh_token_here>"`
 	}
+	ChangePasswordRequest struct {
+		AdminID         string `json:"-"`
+		CurrentPassword string `json:"current_password" example:"secret123"`
+		NewPassword     string `json:"new_password" example:"newsecret123"`
+	}
+	ForgotPasswordRequest struct {
+		Email string `json:"email" example:"user@example.com"`
+	}
+	ResetPasswordRequest struct {
+		Token       string `json:"token" example:"<reset_token_here>"`
+		NewPassword string `json:"new_password" example:"newsecret123"`
+	}
 	RefreshTokenResponse struct {
-		AccessToken string `json:"access_token" example:"<new_access_token_here>"`
+		AccessToken  string `json:"access_token" example:"<new_access_token_here>"`
+		RefreshToken string `json:"refresh_token" example:"<new_refresh_token_here>"`
 	}
 )
 
@@ -426,6 +936,30 @@ type (
 		Password    string      `json:"password"`
 		Role        entity.Role `json:"role"`
 		PhoneNumber string      `json:"phone_number"`
+		Avatar      string      `json:"avatar,omitempty"`
+	}
+	MeResponse struct {
+		ID               string      `json:"id"`
+		Name             string      `json:"name"`
+		Email            string      `json:"email"`
+		PhoneNumber      string      `json:"phone_number"`
+		Avatar           string      `json:"avatar"`
+		Role             entity.Role `json:"role"`
+		Permissions      []string    `json:"permissions"`
+		TwoFactorEnabled bool        `json:"two_factor_enabled"`
+		LastLoginAt      string      `json:"last_login_at,omitempty"`
+		LastLoginIP      string      `json:"last_login_ip,omitempty"`
+	}
+	UpdateMeRequest struct {
+		ID          string                `json:"-" form:"-"`
+		Name        string                `json:"name,omitempty" form:"name"`
+		PhoneNumber string                `json:"phone_number,omitempty" form:"phone_number"`
+		Avatar      string                `json:"avatar,omitempty" form:"avatar"`
+		AvatarFile  *multipart.FileHeader `json:"-" form:"avatar_file"`
+	}
+	AuthorResponse struct {
+		ID   string `json:"id"`
+		Name string `json:"name,omitempty"`
 	}
 	CreateAdminRequest struct {
 		Name        string `json:"name"`
@@ -450,12 +984,121 @@ type (
 	}
 )
 
+// Role
+type (
+	PermissionResponse struct {
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+	RoleResponse struct {
+		ID          string   `json:"id"`
+		Name        string   `json:"name"`
+		Description string   `json:"description"`
+		IsSystem    bool     `json:"is_system"`
+		Permissions []string `json:"permissions"`
+	}
+	CreateRoleRequest struct {
+		Name        string   `json:"name" example:"editor"`
+		Description string   `json:"description"`
+		Permissions []string `json:"permissions" example:"news:write,flyers:write"`
+	}
+	UpdateRoleRequest struct {
+		ID          string   `json:"-"`
+		Description string   `json:"description,omitempty"`
+		Permissions []string `json:"permissions,omitempty"`
+	}
+	AssignRoleRequest struct {
+		AdminID string `json:"-"`
+		ActorID string `json:"-"`
+		Role    string `json:"role" example:"editor"`
+	}
+)
+
+// Audit Log
+type (
+	AuditLogResponse struct {
+		ID         string         `json:"id"`
+		ActorID    string         `json:"actor_id"`
+		ActorName  string         `json:"actor_name"`
+		Action     string         `json:"action"`
+		EntityType string         `json:"entity_type"`
+		EntityID   string         `json:"entity_id"`
+		Before     map[string]any `json:"before"`
+		After      map[string]any `json:"after"`
+		IPAddress  string         `json:"ip_address"`
+		UserAgent  string         `json:"user_agent"`
+		CreatedAt  string         `json:"created_at"`
+	}
+	AuditLogPaginationRequest struct {
+		response.PaginationRequest
+		ActorID    string `form:"actor_id"`
+		Action     string `form:"action"`
+		EntityType string `form:"entity_type"`
+		EntityID   string `form:"entity_id"`
+		From       string `form:"from"`
+		To         string `form:"to"`
+	}
+	AuditLogFilter struct {
+		ActorID    string
+		Action     string
+		EntityType string
+		EntityID   string
+		From       *time.Time
+		To         *time.Time
+	}
+	AuditLogPaginationResponse struct {
+		response.PaginationResponse
+		Data []AuditLogResponse `json:"data"`
+	}
+	AuditLogPaginationRepositoryResponse struct {
+		response.PaginationResponse
+		AuditLogs []entity.AuditLog
+	}
+)
+
+// API Key
+type (
+	APIKeyResponse struct {
+		ID         string   `json:"id"`
+		Name       string   `json:"name"`
+		Prefix     string   `json:"prefix"`
+		Scopes     []string `json:"scopes"`
+		ExpiresAt  string   `json:"expires_at,omitempty"`
+		LastUsedAt string   `json:"last_used_at,omitempty"`
+		LastUsedIP string   `json:"last_used_ip,omitempty"`
+		RevokedAt  string   `json:"revoked_at,omitempty"`
+		CreatedAt  string   `json:"created_at"`
+	}
+	CreateAPIKeyResponse struct {
+		APIKeyResponse
+		Key string `json:"key"`
+	}
+	CreateAPIKeyRequest struct {
+		AdminID   string     `json:"-"`
+		Name      string     `json:"name" example:"frontend build"`
+		Scopes    []string   `json:"scopes" example:"news:write,uploads:write"`
+		ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	}
+	RevokeAPIKeyRequest struct {
+		ID      string `json:"-"`
+		ActorID string `json:"-"`
+	}
+	APIKeyPrincipal struct {
+		KeyID   string
+		AdminID string
+		Scopes  []string
+	}
+)
+
 // Position
 type (
 	PositionResponse struct {
-		ID     string `json:"id"`
-		Name   string `json:"name"`
-		IsTech bool   `json:"is_tech"`
+		ID        string          `json:"id"`
+		Name      string          `json:"name"`
+		IsTech    bool            `json:"is_tech"`
+		CreatedBy *AuthorResponse `json:"created_by,omitempty"`
+		UpdatedBy *AuthorResponse `json:"updated_by,omitempty"`
 	}
 	CreatePositionRequest struct {
 		Name   string `json:"name"`
@@ -485,6 +1128,8 @@ type (
 		Major      string           `json:"major"`
 		Generation *int             `json:"generation"`
 		Position   PositionResponse `json:"position"`
+		CreatedBy  *AuthorResponse  `json:"created_by,omitempty"`
+		UpdatedBy  *AuthorResponse  `json:"updated_by,omitempty"`
 	}
 	CreateMemberRequest struct {
 		Name       string `json:"name"`
@@ -514,8 +1159,10 @@ type (
 // AchievementCategory
 type (
 	AchievementCategoryResponse struct {
-		ID   string `json:"id"`
-		Name string `json:"name"`
+		ID        string          `json:"id"`
+		Name      string          `json:"name"`
+		CreatedBy *AuthorResponse `json:"created_by,omitempty"`
+		UpdatedBy *AuthorResponse `json:"updated_by,omitempty"`
 	}
 	CreateAchievementCategoryRequest struct {
 		Name string `json:"name"`
@@ -529,12 +1176,18 @@ type (
 // Achievement
 type (
 	AchievementImageResponse struct {
-		ID   string `json:"id"`
-		Name string `json:"name"`
+		ID       string                `json:"id"`
+		Name     string                `json:"name"`
+		Position int                   `json:"position"`
+		Caption  string                `json:"caption"`
+		Alt      string                `json:"alt"`
+		IsCover  bool                  `json:"is_cover"`
+		Sources  *ImageSourcesResponse `json:"sources,omitempty"`
 	}
 	AchievementResponse struct {
 		ID          string                      `json:"id"`
 		Name        string                      `json:"name"`
+		Slug        string                      `json:"slug"`
 		Year        int                         `json:"year"`
 		Description string                      `json:"description"`
 		Location    string                      `json:"location"`
@@ -546,7 +1199,10 @@ type (
 		Featured    bool                        `json:"featured"`
 		Tags        []string                    `json:"tags"`
 		Images      []AchievementImageResponse  `json:"images"`
+		Cover       *AchievementImageResponse   `json:"cover,omitempty"`
 		Category    AchievementCategoryResponse `json:"category"`
+		CreatedBy   *AuthorResponse             `json:"created_by,omitempty"`
+		UpdatedBy   *AuthorResponse             `json:"updated_by,omitempty"`
 	}
 	CreateAchievementRequest struct {
 		Name        string   `json:"name" binding:"required"`
@@ -592,14 +1248,23 @@ type (
 // Ship
 type (
 	ShipImageResponse struct {
-		ID   string `json:"id"`
-		Name string `json:"name"`
+		ID       string                `json:"id"`
+		Name     string                `json:"name"`
+		Position int                   `json:"position"`
+		Caption  string                `json:"caption"`
+		Alt      string                `json:"alt"`
+		IsCover  bool                  `json:"is_cover"`
+		Sources  *ImageSourcesResponse `json:"sources,omitempty"`
 	}
 	ShipResponse struct {
 		ID          string              `json:"id"`
 		Name        string              `json:"name"`
+		Slug        string              `json:"slug"`
 		Description string              `json:"description"`
 		Images      []ShipImageResponse `json:"images"`
+		Cover       *ShipImageResponse  `json:"cover,omitempty"`
+		CreatedBy   *AuthorResponse     `json:"created_by,omitempty"`
+		UpdatedBy   *AuthorResponse     `json:"updated_by,omitempty"`
 	}
 	CreateShipRequest struct {
 		Name        string   `json:"name"`
@@ -625,15 +1290,24 @@ type (
 // Competition
 type (
 	CompetitionImageResponse struct {
-		ID   string `json:"id"`
-		Name string `json:"name"`
+		ID       string                `json:"id"`
+		Name     string                `json:"name"`
+		Position int                   `json:"position"`
+		Caption  string                `json:"caption"`
+		Alt      string                `json:"alt"`
+		IsCover  bool                  `json:"is_cover"`
+		Sources  *ImageSourcesResponse `json:"sources,omitempty"`
 	}
 	CompetitionResponse struct {
 		ID          string                     `json:"id"`
 		Name        string                     `json:"name"`
+		Slug        string                     `json:"slug"`
 		Date        string                     `json:"date"`
 		Description string                     `json:"description"`
 		Images      []CompetitionImageResponse `json:"images"`
+		Cover       *CompetitionImageResponse  `json:"cover,omitempty"`
+		CreatedBy   *AuthorResponse            `json:"created_by,omitempty"`
+		UpdatedBy   *AuthorResponse            `json:"updated_by,omitempty"`
 	}
 	CreateCompetitionRequest struct {
 		Name        string   `json:"name"`
@@ -661,8 +1335,10 @@ type (
 // NewsCategory
 type (
 	NewsCategoryResponse struct {
-		ID   string `json:"id"`
-		Name string `json:"name"`
+		ID        string          `json:"id"`
+		Name      string          `json:"name"`
+		CreatedBy *AuthorResponse `json:"created_by,omitempty"`
+		UpdatedBy *AuthorResponse `json:"updated_by,omitempty"`
 	}
 	CreateNewsCategoryRequest struct {
 		Name string `json:"name"`
@@ -676,42 +1352,135 @@ type (
 // News
 type (
 	NewsImageResponse struct {
-		ID   string `json:"id"`
-		Name string `json:"name"`
+		ID       string                `json:"id"`
+		Name     string                `json:"name"`
+		Position int                   `json:"position"`
+		Caption  string                `json:"caption"`
+		Alt      string                `json:"alt"`
+		IsCover  bool                  `json:"is_cover"`
+		Sources  *ImageSourcesResponse `json:"sources,omitempty"`
 	}
 	NewsResponse struct {
-		ID          string               `json:"id"`
-		Name        string               `json:"name"`
-		Description string               `json:"description"`
-		PublishedAt string               `json:"published_at"`
-		Location    string               `json:"location"`
-		URL         string               `json:"url"`
-		Status      string               `json:"status"` // Completed, Ongoing, Upcoming
-		Views       int                  `json:"views"`
-		Featured    bool                 `json:"featured"`
-		Category    NewsCategoryResponse `json:"category"`
-		Images      []NewsImageResponse  `json:"images"`
+		ID                string                `json:"id"`
+		Name              string                `json:"name"`
+		Slug              string                `json:"slug"`
+		Description       string                `json:"description"`
+		Summary           string                `json:"summary"`
+		Excerpt           string                `json:"excerpt"`      // summary, atau diambil dari body kalau summary kosong
+		Body              string                `json:"body"`         // Markdown
+		BodyHTML          string                `json:"body_html"`    // sudah disanitasi
+		ReadingTime       int                   `json:"reading_time"` // menit
+		TableOfContents   []NewsHeadingResponse `json:"table_of_contents"`
+		PublishedAt       string                `json:"published_at"`
+		Location          string                `json:"location"`
+		URL               string                `json:"url"`
+		Status            string                `json:"status"` // Completed, Ongoing, Upcoming
+		Views             int                   `json:"views"`
+		Featured          bool                  `json:"featured"`
+		PublicationStatus string                `json:"publication_status"` // draft, scheduled, published, archived
+		PublishAt         string                `json:"publish_at,omitempty"`
+		Category          NewsCategoryResponse  `json:"category"`
+		Tags              []NewsTagResponse     `json:"tags"`
+		Images            []NewsImageResponse   `json:"images"`
+		Cover             *NewsImageResponse    `json:"cover,omitempty"`
+		CreatedBy         *AuthorResponse       `json:"created_by,omitempty"`
+		UpdatedBy         *AuthorResponse       `json:"updated_by,omitempty"`
 	}
 	CreateNewsRequest struct {
-		Name        string   `json:"name"`
-		Description string   `json:"description"`
-		Location    string   `json:"location"`
-		URL         string   `json:"url"`
-		Status      string   `json:"status"` // Completed, Ongoing, Upcoming
-		Featured    bool     `json:"featured"`
-		CategoryID  string   `json:"category_id"`
-		Images      []string `json:"images"`
+		Name              string     `json:"name"`
+		Description       string     `json:"description"`
+		Summary           string     `json:"summary"`
+		Body              string     `json:"body"` // Markdown, gambar bisa ditulis ![alt](id media / nama file upload)
+		Location          string     `json:"location"`
+		URL               string     `json:"url"`
+		Status            string     `json:"status"` // Completed, Ongoing, Upcoming
+		Featured          bool       `json:"featured"`
+		CategoryID        string     `json:"category_id"`
+		Tags              []string   `json:"tags"`
+		Images            []string   `json:"images"`
+		PublicationStatus string     `json:"publication_status"` // draft, scheduled, published, archived
+		PublishAt         *time.Time `json:"publish_at"`
 	}
 	UpdateNewsRequest struct {
-		ID          string   `json:"-"`
-		Name        string   `json:"name,omitempty"`
-		Description string   `json:"description,omitempty"`
-		Location    string   `json:"location,omitempty"`
-		URL         string   `json:"url,omitempty"`
-		Status      string   `json:"status,omitempty"` // Completed, Ongoing, Upcoming
-		Featured    bool     `json:"featured,omitempty"`
-		CategoryID  string   `json:"category_id,omitempty"`
-		Images      []string `json:"images,omitempty"`
+		ID                string     `json:"-"`
+		Name              string     `json:"name,omitempty"`
+		Description       string     `json:"description,omitempty"`
+		Summary           string     `json:"summary,omitempty"`
+		Body              string     `json:"body,omitempty"`
+		Location          string     `json:"location,omitempty"`
+		URL               string     `json:"url,omitempty"`
+		Status            string     `json:"status,omitempty"` // Completed, Ongoing, Upcoming
+		Featured          bool       `json:"featured,omitempty"`
+		CategoryID        string     `json:"category_id,omitempty"`
+		Tags              []string   `json:"tags,omitempty"` // [] menghapus semua tag
+		Images            []string   `json:"images,omitempty"`
+		PublicationStatus string     `json:"publication_status,omitempty"` // draft, scheduled, published, archived
+		PublishAt         *time.Time `json:"publish_at,omitempty"`
+	}
+	NewsTagResponse struct {
+		Name  string `json:"name"`
+		Slug  string `json:"slug"`
+		Count int64  `json:"count,omitempty"` // jumlah berita published, hanya diisi di list tag
+	}
+	NewsHeadingResponse struct {
+		Level int    `json:"level"`
+		Text  string `json:"text"`
+		ID    string `json:"id"`
+	}
+	NewsRevisionImageResponse struct {
+		Name     string                `json:"name"`
+		Position int                   `json:"position"`
+		Caption  string                `json:"caption"`
+		Alt      string                `json:"alt"`
+		IsCover  bool                  `json:"is_cover"`
+		Sources  *ImageSourcesResponse `json:"sources,omitempty"`
+	}
+	NewsRevisionResponse struct {
+		ID          string                      `json:"id"`
+		Number      int                         `json:"number"`
+		Name        string                      `json:"name"`
+		Description string                      `json:"description"`
+		Summary     string                      `json:"summary"`
+		Body        string                      `json:"body"`
+		Location    string                      `json:"location"`
+		URL         string                      `json:"url"`
+		Status      string                      `json:"status"`
+		Featured    bool                        `json:"featured"`
+		CategoryID  string                      `json:"category_id"`
+		Images      []NewsRevisionImageResponse `json:"images"`
+		CreatedAt   string                      `json:"created_at"`
+		CreatedBy   *AuthorResponse             `json:"created_by,omitempty"`
+	}
+	// NewsRevisionDiffRequest: to kosong berarti dibandingkan dengan isi berita saat ini
+	NewsRevisionDiffRequest struct {
+		ID   string `form:"-"`
+		From int    `form:"from"`
+		To   int    `form:"to"`
+	}
+	// NewsFieldChange berisi nilai sebelum & sesudah, Lines hanya diisi untuk description
+	NewsFieldChange struct {
+		Field  string     `json:"field"`
+		Before any        `json:"before"`
+		After  any        `json:"after"`
+		Lines  []DiffLine `json:"lines,omitempty"`
+		// TooLarge berarti teks terlalu panjang untuk dibandingkan per baris, Lines kosong
+		TooLarge bool `json:"too_large,omitempty"`
+	}
+	DiffLine struct {
+		Op   string `json:"op"` // equal, insert, delete
+		Text string `json:"text"`
+	}
+	NewsRevisionDiffResponse struct {
+		From    int               `json:"from"`
+		To      int               `json:"to"` // 0 = isi berita saat ini
+		Changes []NewsFieldChange `json:"changes"`
+	}
+	// NewsPaginationRequest dipakai list publik & admin, PublishedOnly diisi handler bukan dari query
+	NewsPaginationRequest struct {
+		response.PaginationRequest
+		PublicationStatus string `form:"publication_status"`
+		Tag               string `form:"tag"`
+		PublishedOnly     bool   `form:"-"`
 	}
 	NewsPaginationResponse struct {
 		response.PaginationResponse
@@ -726,9 +1495,11 @@ type (
 // Partner
 type (
 	PartnerResponse struct {
-		ID    string `json:"id"`
-		Name  string `json:"name"`
-		Image string `json:"image"`
+		ID        string          `json:"id"`
+		Name      string          `json:"name"`
+		Image     string          `json:"image"`
+		CreatedBy *AuthorResponse `json:"created_by,omitempty"`
+		UpdatedBy *AuthorResponse `json:"updated_by,omitempty"`
 	}
 	CreatePartnerRequest struct {
 		Name  string `json:"name"`
@@ -752,9 +1523,11 @@ type (
 // Flyer
 type (
 	FlyerResponse struct {
-		ID    string `json:"id"`
-		Name  string `json:"name"`
-		Image string `json:"image"`
+		ID        string          `json:"id"`
+		Name      string          `json:"name"`
+		Image     string          `json:"image"`
+		CreatedBy *AuthorResponse `json:"created_by,omitempty"`
+		UpdatedBy *AuthorResponse `json:"updated_by,omitempty"`
 	}
 	CreateFlyerRequest struct {
 		Name  string `json:"name"`
@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+)
+
+// AccessRole adalah data role yang dirujuk oleh Admin.Role lewat kolom name
+type AccessRole struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	Name        string    `gorm:"type:varchar(20);unique;not null" json:"name"`
+	Description string    `json:"description"`
+	IsSystem    bool      `gorm:"default:false" json:"is_system"`
+
+	Permissions []Permission `gorm:"many2many:role_permissions;constraint:OnDelete:CASCADE" json:"permissions"`
+
+	TimeStamp
+}
@@ -10,5 +10,6 @@ type AchievementCategory struct {
 
 	Achievement []Achievement `gorm:"foreignKey:AchievementCategoryID;constraint:OnDelete:CASCADE" json:"-"`
 
+	Authorship
 	TimeStamp
 }
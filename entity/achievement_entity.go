@@ -8,6 +8,7 @@ import (
 type Achievement struct {
 	ID          uuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
 	Name        string         `gorm:"type:varchar(150);not null" json:"name"`
+	Slug        string         `gorm:"type:varchar(120);default:null;uniqueIndex" json:"slug"`
 	Year        int            `gorm:"not null" json:"year"`
 	Description string         `json:"description"`
 	Location    string         `json:"location"`
@@ -24,5 +25,6 @@ type Achievement struct {
 	AchievementCategoryID *uuid.UUID          `gorm:"type:uuid" json:"achievement_category_id,omitempty"`
 	AchievementCategory   AchievementCategory `gorm:"foreignKey:AchievementCategoryID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"achievement_category,omitempty"`
 
+	Authorship
 	TimeStamp
 }
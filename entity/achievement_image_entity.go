@@ -9,5 +9,6 @@ type AchievementImage struct {
 	AchievementID *uuid.UUID  `gorm:"type:uuid" json:"achievement_id,omitempty"`
 	Achievement   Achievement `gorm:"foreignKey:AchievementID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"achievement,omitempty"`
 
+	Gallery
 	TimeStamp
 }
@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"time"
+
 	"github.com/Amierza/nawasena-backend/helper"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -13,6 +15,14 @@ type Admin struct {
 	Password    string    `gorm:"not null" json:"password"`
 	Role        Role      `gorm:"type:varchar(20);not null" json:"role"`
 	PhoneNumber string    `gorm:"type:varchar(20)" json:"phone_number"`
+	Avatar      string    `json:"avatar"`
+
+	LastLoginAt *time.Time `json:"last_login_at"`
+	LastLoginIP string     `gorm:"type:varchar(45)" json:"last_login_ip"`
+
+	TwoFactorEnabled  bool   `gorm:"default:false" json:"two_factor_enabled"`
+	TwoFactorSecret   string `json:"-"`
+	TwoFactorLastStep int64  `gorm:"default:0" json:"-"`
 
 	TimeStamp
 }
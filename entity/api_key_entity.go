@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type APIKey struct {
+	ID         uuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
+	Name       string         `gorm:"type:varchar(100);not null" json:"name"`
+	Prefix     string         `gorm:"type:varchar(20);uniqueIndex;not null" json:"prefix"`
+	KeyHash    string         `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	Scopes     pq.StringArray `gorm:"type:text[]" json:"scopes"`
+	ExpiresAt  *time.Time     `json:"expires_at"`
+	LastUsedAt *time.Time     `json:"last_used_at"`
+	LastUsedIP string         `gorm:"type:varchar(45)" json:"last_used_ip"`
+	RevokedAt  *time.Time     `json:"revoked_at"`
+
+	AdminID *uuid.UUID `gorm:"type:uuid;index" json:"admin_id,omitempty"`
+	Admin   Admin      `gorm:"foreignKey:AdminID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"admin,omitempty"`
+
+	TimeStamp
+}
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditLog bersifat append-only, jadi tidak pakai TimeStamp (tidak ada update / soft delete)
+type AuditLog struct {
+	ID         uuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
+	Action     string         `gorm:"type:varchar(30);not null;index" json:"action"`
+	EntityType string         `gorm:"type:varchar(50);not null;index:idx_audit_logs_entity" json:"entity_type"`
+	EntityID   string         `gorm:"type:varchar(64);index:idx_audit_logs_entity" json:"entity_id"`
+	Before     map[string]any `gorm:"type:jsonb;serializer:json" json:"before"`
+	After      map[string]any `gorm:"type:jsonb;serializer:json" json:"after"`
+	IPAddress  string         `gorm:"type:varchar(64)" json:"ip_address"`
+	UserAgent  string         `json:"user_agent"`
+	CreatedAt  time.Time      `gorm:"index" json:"created_at"`
+
+	ActorID *uuid.UUID `gorm:"type:uuid;index" json:"actor_id,omitempty"`
+	Actor   Admin      `gorm:"foreignKey:ActorID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"actor,omitempty"`
+}
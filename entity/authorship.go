@@ -0,0 +1,10 @@
+package entity
+
+import "github.com/google/uuid"
+
+type Authorship struct {
+	CreatedByID *uuid.UUID `gorm:"type:uuid;index" json:"created_by_id,omitempty"`
+	CreatedBy   *Admin     `gorm:"foreignKey:CreatedByID;references:ID;constraint:OnDelete:SET NULL;" json:"created_by,omitempty"`
+	UpdatedByID *uuid.UUID `gorm:"type:uuid;index" json:"updated_by_id,omitempty"`
+	UpdatedBy   *Admin     `gorm:"foreignKey:UpdatedByID;references:ID;constraint:OnDelete:SET NULL;" json:"updated_by,omitempty"`
+}
@@ -9,10 +9,12 @@ import (
 type Competition struct {
 	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
 	Name        string    `gorm:"type:varchar(150);not null" json:"name"`
+	Slug        string    `gorm:"type:varchar(120);default:null;uniqueIndex" json:"slug"`
 	Date        time.Time `json:"date"`
 	Description string    `json:"description"`
 
 	Images []CompetitionImage `gorm:"foreignKey:CompetitionID;constraint:OnDelete:CASCADE" json:"-"`
 
+	Authorship
 	TimeStamp
 }
@@ -11,5 +11,6 @@ type CompetitionImage struct {
 	CompetitionID *uuid.UUID  `gorm:"type:uuid" json:"competition_id,omitempty"`
 	Competition   Competition `gorm:"foreignKey:CompetitionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"competition,omitempty"`
 
+	Gallery
 	TimeStamp
 }
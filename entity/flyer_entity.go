@@ -9,5 +9,6 @@ type Flyer struct {
 	Name  string    `gorm:"type:varchar(150);unique;not null" json:"name"`
 	Image string    `json:"image"`
 
+	Authorship
 	TimeStamp
 }
@@ -0,0 +1,10 @@
+package entity
+
+// Gallery dipakai bersama oleh gambar news, achievement, ship dan competition.
+// Position dimulai dari 0 dan satu galeri selalu punya tepat satu cover
+type Gallery struct {
+	Position int    `gorm:"not null;default:0" json:"position"`
+	Caption  string `gorm:"type:text" json:"caption"`
+	Alt      string `gorm:"type:varchar(255)" json:"alt"`
+	IsCover  bool   `gorm:"default:false" json:"is_cover"`
+}
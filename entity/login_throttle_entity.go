@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LoginThrottle struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	Key          string     `gorm:"type:varchar(255);uniqueIndex;not null" json:"key"`
+	FailedCount  int        `gorm:"default:0" json:"failed_count"`
+	LastFailedAt *time.Time `json:"last_failed_at"`
+	LockedUntil  *time.Time `json:"locked_until"`
+
+	TimeStamp
+}
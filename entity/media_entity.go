@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Media mencatat setiap file hasil upload, CreatedBy adalah admin yang meng-upload
+type Media struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	Name         string    `gorm:"type:varchar(150);uniqueIndex;not null" json:"name"`
+	OriginalName string    `gorm:"type:varchar(255)" json:"original_name"`
+	ContentType  string    `gorm:"type:varchar(100)" json:"content_type"`
+	Size         int64     `json:"size"`
+	Hash         string    `gorm:"type:varchar(64);index" json:"hash"`
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	Alt          string    `gorm:"type:varchar(255)" json:"alt"`
+	Caption      string    `gorm:"type:text" json:"caption"`
+	// Visibility private berarti file ada di storage private dan hanya bisa diunduh lewat signed url
+	Visibility string `gorm:"type:varchar(10);not null;default:'public';index" json:"visibility"`
+	// AttachedAt diisi GC saat file pertama kali terlihat dipakai entity, DetachedAt saat file yang
+	// pernah dipakai tidak dirujuk lagi. Media yang belum pernah dipasang tetap disimpan di library
+	AttachedAt *time.Time `json:"attached_at"`
+	DetachedAt *time.Time `gorm:"index" json:"detached_at"`
+
+	Authorship
+	TimeStamp
+}
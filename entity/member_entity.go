@@ -14,5 +14,6 @@ type Member struct {
 	PositionID *uuid.UUID `gorm:"type:uuid" json:"position_id,omitempty"`
 	Position   Position   `gorm:"foreignKey:PositionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"position,omitempty"`
 
+	Authorship
 	TimeStamp
 }
@@ -10,5 +10,6 @@ type NewsCategory struct {
 
 	News []News `gorm:"foreignKey:NewsCategoryID;constraint:OnDelete:CASCADE" json:"-"`
 
+	Authorship
 	TimeStamp
 }
@@ -4,23 +4,47 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type News struct {
 	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
 	Name        string    `gorm:"type:varchar(150);not null" json:"name"`
+	Slug        string    `gorm:"type:varchar(120);default:null;uniqueIndex" json:"slug"`
 	Description string    `json:"description"`
-	PublishedAt time.Time `gorm:"type:timestamp;not null" json:"date"`
-	Location    string    `json:"location"`
-	URL         string    `json:"url"`
-	Status      string    `gorm:"type:varchar(50)" json:"status"`
-	Views       int       `gorm:"default:0" json:"views"`
-	Featured    bool      `gorm:"default:false" json:"featured"`
+	// Body ditulis dalam Markdown. BodyHTML, Excerpt, ReadingTime, TableOfContents & BodyImages adalah hasil
+	// render yang disimpan saat body diubah, data lama yang Body-nya kosong dirender dari Description
+	Summary         string         `gorm:"type:varchar(300)" json:"summary"`
+	Body            string         `gorm:"type:text" json:"body"`
+	BodyHTML        string         `gorm:"type:text" json:"body_html"`
+	Excerpt         string         `gorm:"type:varchar(300)" json:"excerpt"`
+	ReadingTime     int            `gorm:"default:0" json:"reading_time"`
+	TableOfContents []NewsHeading  `gorm:"type:jsonb;serializer:json" json:"table_of_contents"`
+	BodyImages      pq.StringArray `gorm:"type:text[]" json:"body_images"`
+	PublishedAt     time.Time      `gorm:"type:timestamp;not null" json:"date"`
+	Location        string         `json:"location"`
+	URL             string         `json:"url"`
+	Status          string         `gorm:"type:varchar(50)" json:"status"`
+	Views           int            `gorm:"default:0" json:"views"`
+	Featured        bool           `gorm:"default:false" json:"featured"`
+	// PublicationStatus terpisah dari Status (status kegiatan), hanya published yang tampil di publik.
+	// Default published supaya data lama tetap tampil setelah migrate
+	PublicationStatus string     `gorm:"type:varchar(20);not null;default:'published';index" json:"publication_status"`
+	PublishAt         *time.Time `gorm:"type:timestamp;index" json:"publish_at"`
 
 	Images []NewsImage `gorm:"foreignKey:NewsID;constraint:OnDelete:CASCADE" json:"-"`
+	Tags   []NewsTag   `gorm:"many2many:news_tag_relations;constraint:OnDelete:CASCADE" json:"-"`
 
 	NewsCategoryID *uuid.UUID   `gorm:"type:uuid" json:"news_category_id,omitempty"`
 	NewsCategory   NewsCategory `gorm:"foreignKey:NewsCategoryID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"news_category,omitempty"`
 
+	Authorship
 	TimeStamp
 }
+
+// NewsHeading adalah satu entri daftar isi, ID sama dengan atribut id heading di BodyHTML
+type NewsHeading struct {
+	Level int    `json:"level"`
+	Text  string `json:"text"`
+	ID    string `json:"id"`
+}
@@ -9,5 +9,6 @@ type NewsImage struct {
 	NewsID *uuid.UUID `gorm:"type:uuid" json:"news_id,omitempty"`
 	News   News       `gorm:"foreignKey:NewsID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"news_image,omitempty"`
 
+	Gallery
 	TimeStamp
 }
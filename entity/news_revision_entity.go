@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// NewsRevision adalah salinan isi berita sebelum di-update. Append-only seperti AuditLog,
+// status publikasi sengaja tidak ikut supaya restore tidak menayangkan / menurunkan berita
+type NewsRevision struct {
+	ID          uuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
+	NewsID      uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex:idx_news_revisions_number" json:"news_id"`
+	Number      int            `gorm:"not null;uniqueIndex:idx_news_revisions_number" json:"number"`
+	Name        string         `gorm:"type:varchar(150);not null" json:"name"`
+	Description string         `json:"description"`
+	Summary     string         `gorm:"type:varchar(300)" json:"summary"`
+	Body        string         `gorm:"type:text" json:"body"`
+	BodyImages  pq.StringArray `gorm:"type:text[]" json:"body_images"`
+	Location    string         `json:"location"`
+	URL         string         `json:"url"`
+	Status      string         `gorm:"type:varchar(50)" json:"status"`
+	Featured    bool           `json:"featured"`
+	CategoryID  *uuid.UUID     `gorm:"type:uuid" json:"category_id,omitempty"`
+	CreatedAt   time.Time      `gorm:"index" json:"created_at"`
+
+	Images []NewsRevisionImage `gorm:"foreignKey:NewsRevisionID;constraint:OnDelete:CASCADE" json:"images"`
+
+	News News `gorm:"foreignKey:NewsID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+
+	Authorship
+}
+
+// NewsRevisionImage ikut didaftarkan di uploadReferences supaya file revisi lama tidak dihapus GC
+type NewsRevisionImage struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	NewsRevisionID uuid.UUID `gorm:"type:uuid;not null;index" json:"news_revision_id"`
+	Name           string    `gorm:"type:varchar(150);not null" json:"name"`
+
+	Gallery
+}
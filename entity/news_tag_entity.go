@@ -0,0 +1,15 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+)
+
+// NewsTag adalah tag bebas berita. Slug dipakai untuk filter ?tag= dan supaya "Kapal Selam" dan
+// "kapal-selam" tidak jadi dua tag berbeda
+type NewsTag struct {
+	ID   uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	Name string    `gorm:"type:varchar(50);not null" json:"name"`
+	Slug string    `gorm:"type:varchar(120);not null;uniqueIndex" json:"slug"`
+
+	TimeStamp
+}
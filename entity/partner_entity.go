@@ -9,5 +9,6 @@ type Partner struct {
 	Name  string    `gorm:"type:varchar(150);not null" json:"name"`
 	Image string    `json:"image"`
 
+	Authorship
 	TimeStamp
 }
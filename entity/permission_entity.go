@@ -0,0 +1,13 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+)
+
+type Permission struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	Name        string    `gorm:"type:varchar(100);unique;not null" json:"name"`
+	Description string    `json:"description"`
+
+	TimeStamp
+}
@@ -11,5 +11,6 @@ type Position struct {
 
 	Members []Member `gorm:"foreignKey:PositionID;constraint:OnDelete:CASCADE" json:"-"`
 
+	Authorship
 	TimeStamp
 }
@@ -7,9 +7,11 @@ import (
 type Ship struct {
 	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
 	Name        string    `gorm:"type:varchar(150);not null" json:"name"`
+	Slug        string    `gorm:"type:varchar(120);default:null;uniqueIndex" json:"slug"`
 	Description string    `json:"description"`
 
 	Images []ShipImage `gorm:"foreignKey:ShipID;constraint:OnDelete:CASCADE" json:"-"`
 
+	Authorship
 	TimeStamp
 }
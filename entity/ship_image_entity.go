@@ -9,5 +9,6 @@ type ShipImage struct {
 	ShipID *uuid.UUID `gorm:"type:uuid" json:"ship_id,omitempty"`
 	Ship   Ship       `gorm:"foreignKey:ShipID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"ship,omitempty"`
 
+	Gallery
 	TimeStamp
 }
@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SlugRedirect menyimpan slug lama setelah judul diganti, supaya URL lama tetap bisa diarahkan ke slug baru
+type SlugRedirect struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	EntityType string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_slug_redirects_slug" json:"entity_type"`
+	Slug       string    `gorm:"type:varchar(120);not null;uniqueIndex:idx_slug_redirects_slug" json:"slug"`
+	TargetID   uuid.UUID `gorm:"type:uuid;not null;index" json:"target_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TwoFactorRecoveryCode struct {
+	ID       uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	CodeHash string     `gorm:"type:varchar(64);not null" json:"-"`
+	UsedAt   *time.Time `json:"used_at"`
+
+	AdminID *uuid.UUID `gorm:"type:uuid;index" json:"admin_id,omitempty"`
+	Admin   Admin      `gorm:"foreignKey:AdminID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"admin,omitempty"`
+
+	TimeStamp
+}
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UploadSession menyimpan progres upload bertahap, isi chunk-nya ada di file sementara di server
+type UploadSession struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	Filename   string    `gorm:"type:varchar(255);not null" json:"filename"`
+	Size       int64     `gorm:"not null" json:"size"`
+	Offset     int64     `gorm:"default:0" json:"offset"`
+	Checksum   string    `gorm:"type:varchar(64);not null" json:"checksum"`
+	Visibility string    `gorm:"type:varchar(10);not null;default:'public'" json:"visibility"`
+	ExpiresAt  time.Time `gorm:"index" json:"expires_at"`
+
+	Authorship
+	TimeStamp
+}
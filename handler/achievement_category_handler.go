@@ -49,7 +49,7 @@ func (ph *achievementCategoryHandler) Create(ctx *gin.Context) {
 }
 
 func (ph *achievementCategoryHandler) GetAll(ctx *gin.Context) {
-	result, err := ph.achievementCategoryService.GetAll(ctx)
+	result, err := ph.achievementCategoryService.GetAll(ctx, ctx.Query("created_by"))
 	if err != nil {
 		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_ACHIEVEMENT_CATEGORY, err.Error(), nil)
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
@@ -17,6 +17,10 @@ type (
 		GetFeatured(ctx *gin.Context)
 		Update(ctx *gin.Context)
 		Delete(ctx *gin.Context)
+		AddImage(ctx *gin.Context)
+		UpdateImage(ctx *gin.Context)
+		RemoveImage(ctx *gin.Context)
+		ReorderImages(ctx *gin.Context)
 	}
 
 	achievementHandler struct {
@@ -55,7 +59,7 @@ func (ah *achievementHandler) GetAll(ctx *gin.Context) {
 
 	if !usePagination {
 		// Tanpa pagination
-		result, err := ah.achievementService.GetAll(ctx)
+		result, err := ah.achievementService.GetAll(ctx, ctx.Query("created_by"))
 		if err != nil {
 			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_ACHIEVEMENT, err.Error(), nil)
 			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
@@ -95,6 +99,10 @@ func (ah *achievementHandler) GetDetail(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 	result, err := ah.achievementService.GetDetail(ctx, idStr)
 	if err != nil {
+		if redirectMovedSlug(ctx, err) {
+			return
+		}
+
 		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DETAIL_ACHIEVEMENT, err.Error(), nil)
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
 		return
@@ -150,3 +158,76 @@ func (ah *achievementHandler) Delete(ctx *gin.Context) {
 	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_ACHIEVEMENT, result)
 	ctx.JSON(http.StatusOK, res)
 }
+
+func (ah *achievementHandler) AddImage(ctx *gin.Context) {
+	var payload dto.AddImageRequest
+	payload.ParentID = ctx.Param("id")
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.achievementService.AddImage(ctx, payload)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_ADD_IMAGE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_ADD_IMAGE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *achievementHandler) UpdateImage(ctx *gin.Context) {
+	var payload dto.UpdateImageRequest
+	payload.ParentID = ctx.Param("id")
+	payload.ImageID = ctx.Param("image_id")
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.achievementService.UpdateImage(ctx, payload)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_IMAGE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_IMAGE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *achievementHandler) RemoveImage(ctx *gin.Context) {
+	result, err := ah.achievementService.RemoveImage(ctx, ctx.Param("id"), ctx.Param("image_id"))
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_REMOVE_IMAGE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REMOVE_IMAGE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *achievementHandler) ReorderImages(ctx *gin.Context) {
+	var payload dto.ReorderImagesRequest
+	payload.ParentID = ctx.Param("id")
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.achievementService.ReorderImages(ctx, payload)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_REORDER_IMAGES, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REORDER_IMAGES, result)
+	ctx.JSON(http.StatusOK, res)
+}
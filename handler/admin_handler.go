@@ -16,6 +16,8 @@ type (
 		GetDetail(ctx *gin.Context)
 		Update(ctx *gin.Context)
 		Delete(ctx *gin.Context)
+		GetMe(ctx *gin.Context)
+		UpdateMe(ctx *gin.Context)
 	}
 
 	adminHandler struct {
@@ -126,7 +128,7 @@ func (ah *adminHandler) Update(ctx *gin.Context) {
 
 func (ah *adminHandler) Delete(ctx *gin.Context) {
 	idStr := ctx.Param("id")
-	result, err := ah.adminService.Delete(ctx, idStr)
+	result, err := ah.adminService.Delete(ctx, idStr, ctx.GetString("admin_id"))
 	if err != nil {
 		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_DELETE_ADMIN, err.Error(), nil)
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
@@ -136,3 +138,35 @@ func (ah *adminHandler) Delete(ctx *gin.Context) {
 	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_ADMIN, result)
 	ctx.JSON(http.StatusOK, res)
 }
+
+func (ah *adminHandler) GetMe(ctx *gin.Context) {
+	result, err := ah.adminService.GetMe(ctx, ctx.GetString("admin_id"))
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_PROFILE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_PROFILE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *adminHandler) UpdateMe(ctx *gin.Context) {
+	var payload dto.UpdateMeRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	payload.ID = ctx.GetString("admin_id")
+
+	result, err := ah.adminService.UpdateMe(ctx, payload)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_PROFILE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_PROFILE, result)
+	ctx.JSON(http.StatusOK, res)
+}
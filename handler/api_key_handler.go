@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/response"
+	"github.com/Amierza/nawasena-backend/service"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	IAPIKeyHandler interface {
+		Create(ctx *gin.Context)
+		GetAll(ctx *gin.Context)
+		GetAllByAdmin(ctx *gin.Context)
+		Revoke(ctx *gin.Context)
+	}
+
+	apiKeyHandler struct {
+		apiKeyService service.IAPIKeyService
+	}
+)
+
+func NewAPIKeyHandler(apiKeyService service.IAPIKeyService) *apiKeyHandler {
+	return &apiKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+func (akh *apiKeyHandler) Create(ctx *gin.Context) {
+	var payload dto.CreateAPIKeyRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	payload.AdminID = ctx.GetString("admin_id")
+
+	result, err := akh.apiKeyService.Create(ctx, payload)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_API_KEY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CREATE_API_KEY, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (akh *apiKeyHandler) GetAll(ctx *gin.Context) {
+	result, err := akh.apiKeyService.GetAll(ctx, ctx.GetString("admin_id"))
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_API_KEY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_LIST_API_KEY, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (akh *apiKeyHandler) GetAllByAdmin(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	result, err := akh.apiKeyService.GetAll(ctx, idStr)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_API_KEY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_LIST_API_KEY, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (akh *apiKeyHandler) Revoke(ctx *gin.Context) {
+	payload := dto.RevokeAPIKeyRequest{
+		ID:      ctx.Param("id"),
+		ActorID: ctx.GetString("admin_id"),
+	}
+
+	result, err := akh.apiKeyService.Revoke(ctx, payload)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_REVOKE_API_KEY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REVOKE_API_KEY, result)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/response"
+	"github.com/Amierza/nawasena-backend/service"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	IAuditLogHandler interface {
+		GetAll(ctx *gin.Context)
+	}
+
+	auditLogHandler struct {
+		auditLogService service.IAuditLogService
+	}
+)
+
+func NewAuditLogHandler(auditLogService service.IAuditLogService) *auditLogHandler {
+	return &auditLogHandler{
+		auditLogService: auditLogService,
+	}
+}
+
+func (alh *auditLogHandler) GetAll(ctx *gin.Context) {
+	var payload dto.AuditLogPaginationRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := alh.auditLogService.GetAllWithPagination(ctx, payload)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_AUDIT_LOG, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.Response{
+		Status:   true,
+		Messsage: dto.MESSAGE_SUCCESS_GET_LIST_AUDIT_LOG,
+		Data:     result.Data,
+		Meta:     result.PaginationResponse,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/Amierza/nawasena-backend/dto"
@@ -13,6 +14,18 @@ type (
 	IAuthHandler interface {
 		Login(ctx *gin.Context)
 		RefreshToken(ctx *gin.Context)
+		Logout(ctx *gin.Context)
+		LogoutAll(ctx *gin.Context)
+		LogoutAdmin(ctx *gin.Context)
+		ChangePassword(ctx *gin.Context)
+		ForgotPassword(ctx *gin.Context)
+		ResetPassword(ctx *gin.Context)
+		LoginTwoFactor(ctx *gin.Context)
+		EnrollTwoFactor(ctx *gin.Context)
+		ConfirmTwoFactor(ctx *gin.Context)
+		ResetTwoFactor(ctx *gin.Context)
+		GetLoginThrottles(ctx *gin.Context)
+		ClearLoginThrottle(ctx *gin.Context)
 	}
 
 	authHandler struct {
@@ -34,10 +47,12 @@ func (ah *authHandler) Login(ctx *gin.Context) {
 		return
 	}
 
+	payload.IP = ctx.ClientIP()
+
 	result, err := ah.authService.Login(ctx, payload)
 	if err != nil {
 		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_LOGIN_USER, err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		ctx.AbortWithStatusJSON(loginFailedStatus(err), res)
 		return
 	}
 
@@ -63,3 +78,192 @@ func (ah *authHandler) RefreshToken(ctx *gin.Context) {
 	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REFRESH_TOKEN, result)
 	ctx.AbortWithStatusJSON(http.StatusOK, res)
 }
+
+func (ah *authHandler) Logout(ctx *gin.Context) {
+	sessionID := ctx.GetString("session_id")
+	if err := ah.authService.Logout(ctx, sessionID); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_LOGOUT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_LOGOUT, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *authHandler) LogoutAll(ctx *gin.Context) {
+	adminID := ctx.GetString("admin_id")
+	if err := ah.authService.LogoutAll(ctx, adminID); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_LOGOUT_ALL, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_LOGOUT_ALL, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *authHandler) LogoutAdmin(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	if err := ah.authService.LogoutAll(ctx, idStr); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_LOGOUT_ALL, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_LOGOUT_ALL, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *authHandler) ChangePassword(ctx *gin.Context) {
+	var payload dto.ChangePasswordRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	payload.AdminID = ctx.GetString("admin_id")
+
+	if err := ah.authService.ChangePassword(ctx, payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_CHANGE_PASSWORD, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CHANGE_PASSWORD, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *authHandler) ForgotPassword(ctx *gin.Context) {
+	var payload dto.ForgotPasswordRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if err := ah.authService.ForgotPassword(ctx, payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_FORGOT_PASSWORD, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_FORGOT_PASSWORD, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *authHandler) ResetPassword(ctx *gin.Context) {
+	var payload dto.ResetPasswordRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if err := ah.authService.ResetPassword(ctx, payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_RESET_PASSWORD, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_RESET_PASSWORD, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *authHandler) LoginTwoFactor(ctx *gin.Context) {
+	var payload dto.LoginTwoFactorRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	payload.IP = ctx.ClientIP()
+
+	result, err := ah.authService.LoginTwoFactor(ctx, payload)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_LOGIN_2FA, err.Error(), nil)
+		ctx.AbortWithStatusJSON(loginFailedStatus(err), res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_LOGIN_2FA, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *authHandler) EnrollTwoFactor(ctx *gin.Context) {
+	adminID := ctx.GetString("admin_id")
+	result, err := ah.authService.EnrollTwoFactor(ctx, adminID)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_ENROLL_2FA, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_ENROLL_2FA, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *authHandler) ConfirmTwoFactor(ctx *gin.Context) {
+	var payload dto.ConfirmTwoFactorRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	payload.AdminID = ctx.GetString("admin_id")
+
+	result, err := ah.authService.ConfirmTwoFactor(ctx, payload)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_CONFIRM_2FA, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CONFIRM_2FA, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *authHandler) ResetTwoFactor(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	if err := ah.authService.ResetTwoFactor(ctx, idStr); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_RESET_2FA, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_RESET_2FA, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *authHandler) GetLoginThrottles(ctx *gin.Context) {
+	result, err := ah.authService.GetLoginThrottles(ctx)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_LOCKOUT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_LIST_LOCKOUT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *authHandler) ClearLoginThrottle(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	if err := ah.authService.ClearLoginThrottle(ctx, idStr); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_CLEAR_LOCKOUT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CLEAR_LOCKOUT, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func loginFailedStatus(err error) int {
+	if errors.Is(err, dto.ErrLoginLocked) {
+		return http.StatusTooManyRequests
+	}
+
+	return http.StatusBadRequest
+}
@@ -16,6 +16,10 @@ type (
 		GetDetail(ctx *gin.Context)
 		Update(ctx *gin.Context)
 		Delete(ctx *gin.Context)
+		AddImage(ctx *gin.Context)
+		UpdateImage(ctx *gin.Context)
+		RemoveImage(ctx *gin.Context)
+		ReorderImages(ctx *gin.Context)
 	}
 
 	competitionHandler struct {
@@ -54,7 +58,7 @@ func (ah *competitionHandler) GetAll(ctx *gin.Context) {
 
 	if !usePagination {
 		// Tanpa pagination
-		result, err := ah.competitionService.GetAll(ctx)
+		result, err := ah.competitionService.GetAll(ctx, ctx.Query("created_by"))
 		if err != nil {
 			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_COMPETITION, err.Error(), nil)
 			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
@@ -94,6 +98,10 @@ func (ah *competitionHandler) GetDetail(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 	result, err := ah.competitionService.GetDetail(ctx, idStr)
 	if err != nil {
+		if redirectMovedSlug(ctx, err) {
+			return
+		}
+
 		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DETAIL_COMPETITION, err.Error(), nil)
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
 		return
@@ -136,3 +144,76 @@ func (ah *competitionHandler) Delete(ctx *gin.Context) {
 	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_COMPETITION, result)
 	ctx.JSON(http.StatusOK, res)
 }
+
+func (ah *competitionHandler) AddImage(ctx *gin.Context) {
+	var payload dto.AddImageRequest
+	payload.ParentID = ctx.Param("id")
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.competitionService.AddImage(ctx, payload)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_ADD_IMAGE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_ADD_IMAGE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *competitionHandler) UpdateImage(ctx *gin.Context) {
+	var payload dto.UpdateImageRequest
+	payload.ParentID = ctx.Param("id")
+	payload.ImageID = ctx.Param("image_id")
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.competitionService.UpdateImage(ctx, payload)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_IMAGE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_IMAGE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *competitionHandler) RemoveImage(ctx *gin.Context) {
+	result, err := ah.competitionService.RemoveImage(ctx, ctx.Param("id"), ctx.Param("image_id"))
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_REMOVE_IMAGE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REMOVE_IMAGE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *competitionHandler) ReorderImages(ctx *gin.Context) {
+	var payload dto.ReorderImagesRequest
+	payload.ParentID = ctx.Param("id")
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.competitionService.ReorderImages(ctx, payload)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_REORDER_IMAGES, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REORDER_IMAGES, result)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"errors"
+	"io"
 	"mime/multipart"
 	"net/http"
 
@@ -13,6 +15,7 @@ import (
 type (
 	IFileHandler interface {
 		Upload(ctx *gin.Context)
+		Serve(ctx *gin.Context)
 	}
 
 	fileHandler struct {
@@ -27,10 +30,20 @@ func NewFileHandler(fileService service.IFileService) *fileHandler {
 }
 
 func (fh *fileHandler) Upload(ctx *gin.Context) {
+	// batasi body request supaya upload raksasa berhenti sebelum selesai dibaca,
+	// tambahan 1 MB untuk boundary dan field multipart lainnya
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, fh.fileService.MaxRequestSize()+1<<20)
+
 	// coba ambil semua files dari multipart form
 	form, err := ctx.MultipartForm()
-	var files []*multipart.FileHeader
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_UPLOAD_FILES, dto.ErrUploadTooLarge.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, res)
+		return
+	}
 
+	var files []*multipart.FileHeader
 	if err == nil && form.File != nil && len(form.File["files"]) > 0 {
 		// kalau user upload banyak file (key = "files")
 		files = form.File["files"]
@@ -45,22 +58,77 @@ func (fh *fileHandler) Upload(ctx *gin.Context) {
 		files = []*multipart.FileHeader{file}
 	}
 
-	// call service
-	uploadedURLs, err := fh.fileService.Upload(ctx, files)
+	// call service, visibility=private untuk dokumen yang hanya boleh diunduh lewat signed url
+	result, err := fh.fileService.Upload(ctx, files, ctx.PostForm("visibility"))
+	if errors.Is(err, dto.ErrUploadTooLarge) {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_UPLOAD_FILES, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, res)
+		return
+	}
 	if err != nil {
 		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_UPLOAD_FILES, err.Error(), nil)
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
 		return
 	}
 
-	// kalau hanya 1 file → balikin string saja
-	if len(uploadedURLs) == 1 {
-		res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPLOAD_FILE, uploadedURLs[0])
+	// semua file ditolak → detail alasan per file ada di data
+	if len(result.Files) == 0 {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_UPLOAD_FILES, dto.ErrAllFilesRejected.Error(), result)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	// kalau hanya 1 file → balikin object file saja
+	if len(files) == 1 {
+		res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPLOAD_FILE, result.Files[0])
 		ctx.JSON(http.StatusOK, res)
 		return
 	}
 
-	// kalau banyak file → balikin array
-	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPLOAD_FILES, uploadedURLs)
+	// kalau banyak file → balikin daftar file beserta error per file yang ditolak
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPLOAD_FILES, result)
 	ctx.JSON(http.StatusOK, res)
 }
+
+// Serve menggantikan server.Static("/uploads"), file diambil dari storage backend yang aktif
+func (fh *fileHandler) Serve(ctx *gin.Context) {
+	key := ctx.Param("filepath")
+
+	// backend dengan url publik (s3 / supabase) cukup di-redirect
+	if url := fh.fileService.URL(key); url != "" {
+		ctx.Redirect(http.StatusFound, url)
+		return
+	}
+
+	body, info, err := fh.fileService.Open(ctx, key)
+	if errors.Is(err, dto.ErrFileNotFound) {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_FILE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+		return
+	}
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_FILE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, res)
+		return
+	}
+	defer body.Close()
+
+	if info.ContentType != "" {
+		ctx.Header("Content-Type", info.ContentType)
+	}
+	if info.ETag != "" {
+		ctx.Header("ETag", `"`+info.ETag+`"`)
+	}
+	ctx.Header("Cache-Control", "public, max-age=86400")
+
+	// file lokal bisa di-seek, jadi Range / If-Modified-Since ditangani http.ServeContent
+	if seeker, ok := body.(io.ReadSeeker); ok {
+		http.ServeContent(ctx.Writer, ctx.Request, info.Key, info.LastModified, seeker)
+		return
+	}
+
+	if !info.LastModified.IsZero() {
+		ctx.Header("Last-Modified", info.LastModified.UTC().Format(http.TimeFormat))
+	}
+	ctx.DataFromReader(http.StatusOK, info.Size, info.ContentType, body, nil)
+}
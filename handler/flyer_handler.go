@@ -54,7 +54,7 @@ func (ah *flyerHandler) GetAll(ctx *gin.Context) {
 
 	if !usePagination {
 		// Tanpa pagination
-		result, err := ah.flyerService.GetAll(ctx)
+		result, err := ah.flyerService.GetAll(ctx, ctx.Query("created_by"))
 		if err != nil {
 			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_FLYER, err.Error(), nil)
 			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Amierza/nawasena-backend/jwt"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	IJWKSHandler interface {
+		GetJWKS(ctx *gin.Context)
+	}
+
+	jwksHandler struct {
+		jwt jwt.IJWT
+	}
+)
+
+func NewJWKSHandler(jwt jwt.IJWT) *jwksHandler {
+	return &jwksHandler{
+		jwt: jwt,
+	}
+}
+
+// GetJWKS sengaja tidak dibungkus response.Response karena formatnya mengikuti RFC 7517
+func (jh *jwksHandler) GetJWKS(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, jh.jwt.JWKS())
+}
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/response"
+	"github.com/Amierza/nawasena-backend/service"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	IMediaHandler interface {
+		GetAll(ctx *gin.Context)
+		GetDetail(ctx *gin.Context)
+		Update(ctx *gin.Context)
+		Delete(ctx *gin.Context)
+		SignURL(ctx *gin.Context)
+		Download(ctx *gin.Context)
+	}
+
+	mediaHandler struct {
+		mediaService service.IMediaService
+	}
+)
+
+func NewMediaHandler(mediaService service.IMediaService) *mediaHandler {
+	return &mediaHandler{
+		mediaService: mediaService,
+	}
+}
+
+func (mh *mediaHandler) GetAll(ctx *gin.Context) {
+	var payload dto.MediaPaginationRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := mh.mediaService.GetAllWithPagination(ctx, payload)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_MEDIA, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.Response{
+		Status:   true,
+		Messsage: dto.MESSAGE_SUCCESS_GET_LIST_MEDIA,
+		Data:     result.Data,
+		Meta:     result.PaginationResponse,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (mh *mediaHandler) GetDetail(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	result, err := mh.mediaService.GetDetail(ctx, idStr)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DETAIL_MEDIA, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_DETAIL_MEDIA, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (mh *mediaHandler) Update(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	var payload dto.UpdateMediaRequest
+	payload.ID = idStr
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := mh.mediaService.Update(ctx, payload)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_MEDIA, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_MEDIA, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (mh *mediaHandler) Delete(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	result, err := mh.mediaService.Delete(ctx, idStr)
+	if errors.Is(err, dto.ErrMediaInUse) {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_DELETE_MEDIA, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusConflict, res)
+		return
+	}
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_DELETE_MEDIA, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_MEDIA, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (mh *mediaHandler) SignURL(ctx *gin.Context) {
+	var payload dto.SignMediaURLRequest
+	payload.ID = ctx.Param("id")
+	// body boleh kosong, expiry default dipakai
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBind(&payload); err != nil {
+			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
+	}
+
+	result, err := mh.mediaService.SignURL(ctx, payload)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_SIGN_MEDIA_URL, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_SIGN_MEDIA_URL, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Download melayani /files/:name?expires=...&signature=..., file private tidak pernah lewat /uploads
+func (mh *mediaHandler) Download(ctx *gin.Context) {
+	result, err := mh.mediaService.Download(ctx, dto.DownloadMediaRequest{
+		Name:      ctx.Param("name"),
+		Expires:   ctx.Query("expires"),
+		Signature: ctx.Query("signature"),
+	})
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, dto.ErrInvalidSignature):
+			status = http.StatusForbidden
+		case errors.Is(err, dto.ErrSignedURLExpired):
+			status = http.StatusGone
+		case errors.Is(err, dto.ErrFileNotFound):
+			status = http.StatusNotFound
+		}
+
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_DOWNLOAD_MEDIA, err.Error(), nil)
+		ctx.AbortWithStatusJSON(status, res)
+		return
+	}
+	defer result.Body.Close()
+
+	info := result.Info
+	if info.ContentType != "" {
+		ctx.Header("Content-Type", info.ContentType)
+	}
+	if result.Filename != "" {
+		ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": result.Filename}))
+	}
+	// link bisa bocor lewat cache / referer, jadi jangan disimpan di mana pun
+	ctx.Header("Cache-Control", "private, no-store")
+	ctx.Header("Referrer-Policy", "no-referrer")
+	ctx.Header("X-Content-Type-Options", "nosniff")
+
+	if seeker, ok := result.Body.(io.ReadSeeker); ok {
+		http.ServeContent(ctx.Writer, ctx.Request, info.Key, info.LastModified, seeker)
+		return
+	}
+
+	ctx.DataFromReader(http.StatusOK, info.Size, info.ContentType, result.Body, nil)
+}
@@ -54,7 +54,7 @@ func (mh *memberHandler) GetAll(ctx *gin.Context) {
 
 	if !usePagination {
 		// Tanpa pagination
-		result, err := mh.memberService.GetAll(ctx)
+		result, err := mh.memberService.GetAll(ctx, ctx.Query("created_by"))
 		if err != nil {
 			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_MEMBER, err.Error(), nil)
 			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
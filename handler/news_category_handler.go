@@ -49,7 +49,7 @@ func (ph *newsCategoryHandler) Create(ctx *gin.Context) {
 }
 
 func (ph *newsCategoryHandler) GetAll(ctx *gin.Context) {
-	result, err := ph.newsCategoryService.GetAll(ctx)
+	result, err := ph.newsCategoryService.GetAll(ctx, ctx.Query("created_by"))
 	if err != nil {
 		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_NEWS_CATEGORY, err.Error(), nil)
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/Amierza/nawasena-backend/dto"
 	"github.com/Amierza/nawasena-backend/response"
@@ -13,10 +14,22 @@ type (
 	INewsHandler interface {
 		Create(ctx *gin.Context)
 		GetAll(ctx *gin.Context)
+		GetAdminAll(ctx *gin.Context)
 		GetFeatured(ctx *gin.Context)
 		GetDetail(ctx *gin.Context)
+		GetTags(ctx *gin.Context)
+		GetRelated(ctx *gin.Context)
+		GetAdminDetail(ctx *gin.Context)
 		Update(ctx *gin.Context)
 		Delete(ctx *gin.Context)
+		AddImage(ctx *gin.Context)
+		UpdateImage(ctx *gin.Context)
+		RemoveImage(ctx *gin.Context)
+		ReorderImages(ctx *gin.Context)
+		GetRevisions(ctx *gin.Context)
+		GetRevision(ctx *gin.Context)
+		DiffRevisions(ctx *gin.Context)
+		RestoreRevision(ctx *gin.Context)
 	}
 
 	newsHandler struct {
@@ -49,13 +62,31 @@ func (ah *newsHandler) Create(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, res)
 }
 
+// GetAll hanya menampilkan berita published, GetAdminAll menampilkan semua status
+// dan bisa difilter dengan ?publication_status=
 func (ah *newsHandler) GetAll(ctx *gin.Context) {
+	ah.getAll(ctx, true)
+}
+
+func (ah *newsHandler) GetAdminAll(ctx *gin.Context) {
+	ah.getAll(ctx, false)
+}
+
+func (ah *newsHandler) getAll(ctx *gin.Context, publishedOnly bool) {
+	var payload dto.NewsPaginationRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	payload.PublishedOnly = publishedOnly
+
 	paginationParam := ctx.DefaultQuery("pagination", "true")
 	usePagination := paginationParam != "false"
 
 	if !usePagination {
 		// Tanpa pagination
-		result, err := ah.newsService.GetAll(ctx)
+		result, err := ah.newsService.GetAll(ctx, payload)
 		if err != nil {
 			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_NEWS, err.Error(), nil)
 			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
@@ -67,13 +98,6 @@ func (ah *newsHandler) GetAll(ctx *gin.Context) {
 		return
 	}
 
-	var payload response.PaginationRequest
-	if err := ctx.ShouldBind(&payload); err != nil {
-		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
-		return
-	}
-
 	result, err := ah.newsService.GetAllWithPagination(ctx, payload)
 	if err != nil {
 		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_NEWS, err.Error(), nil)
@@ -107,6 +131,53 @@ func (ah *newsHandler) GetFeatured(ctx *gin.Context) {
 func (ah *newsHandler) GetDetail(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 	result, err := ah.newsService.GetDetail(ctx, idStr)
+	if err != nil {
+		if redirectMovedSlug(ctx, err) {
+			return
+		}
+
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DETAIL_NEWS, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_DETAIL_NEWS, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *newsHandler) GetTags(ctx *gin.Context) {
+	result, err := ah.newsService.GetTags(ctx)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_NEWS_TAGS, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_NEWS_TAGS, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *newsHandler) GetRelated(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	limit := ctx.Query("limit")
+	result, err := ah.newsService.GetRelated(ctx, idStr, limit)
+	if err != nil {
+		if redirectMovedSlug(ctx, err) {
+			return
+		}
+
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_RELATED, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_RELATED, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *newsHandler) GetAdminDetail(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	result, err := ah.newsService.GetAdminDetail(ctx, idStr)
 	if err != nil {
 		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DETAIL_NEWS, err.Error(), nil)
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
@@ -150,3 +221,147 @@ func (ah *newsHandler) Delete(ctx *gin.Context) {
 	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_NEWS, result)
 	ctx.JSON(http.StatusOK, res)
 }
+
+func (ah *newsHandler) AddImage(ctx *gin.Context) {
+	var payload dto.AddImageRequest
+	payload.ParentID = ctx.Param("id")
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.newsService.AddImage(ctx, payload)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_ADD_IMAGE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_ADD_IMAGE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *newsHandler) UpdateImage(ctx *gin.Context) {
+	var payload dto.UpdateImageRequest
+	payload.ParentID = ctx.Param("id")
+	payload.ImageID = ctx.Param("image_id")
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.newsService.UpdateImage(ctx, payload)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_IMAGE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_IMAGE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *newsHandler) RemoveImage(ctx *gin.Context) {
+	result, err := ah.newsService.RemoveImage(ctx, ctx.Param("id"), ctx.Param("image_id"))
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_REMOVE_IMAGE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REMOVE_IMAGE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *newsHandler) ReorderImages(ctx *gin.Context) {
+	var payload dto.ReorderImagesRequest
+	payload.ParentID = ctx.Param("id")
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.newsService.ReorderImages(ctx, payload)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_REORDER_IMAGES, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REORDER_IMAGES, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *newsHandler) GetRevisions(ctx *gin.Context) {
+	result, err := ah.newsService.GetRevisions(ctx, ctx.Param("id"))
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_NEWS_REVISION, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_LIST_NEWS_REVISION, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *newsHandler) GetRevision(ctx *gin.Context) {
+	number, err := strconv.Atoi(ctx.Param("rev"))
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DETAIL_NEWS_REVISION, dto.ErrInvalidRevisionNumber.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.newsService.GetRevision(ctx, ctx.Param("id"), number)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DETAIL_NEWS_REVISION, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_DETAIL_NEWS_REVISION, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// DiffRevisions: ?from=<rev>&to=<rev>, to kosong berarti dibandingkan dengan isi saat ini
+func (ah *newsHandler) DiffRevisions(ctx *gin.Context) {
+	var payload dto.NewsRevisionDiffRequest
+	if err := ctx.ShouldBindQuery(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	payload.ID = ctx.Param("id")
+
+	result, err := ah.newsService.DiffRevisions(ctx, payload)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_DIFF_NEWS_REVISION, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DIFF_NEWS_REVISION, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *newsHandler) RestoreRevision(ctx *gin.Context) {
+	number, err := strconv.Atoi(ctx.Param("rev"))
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_RESTORE_NEWS_REVISION, dto.ErrInvalidRevisionNumber.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.newsService.RestoreRevision(ctx, ctx.Param("id"), number)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_RESTORE_NEWS_REVISION, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_RESTORE_NEWS_REVISION, result)
+	ctx.JSON(http.StatusOK, res)
+}
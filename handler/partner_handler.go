@@ -54,7 +54,7 @@ func (ah *partnerHandler) GetAll(ctx *gin.Context) {
 
 	if !usePagination {
 		// Tanpa pagination
-		result, err := ah.partnerService.GetAll(ctx)
+		result, err := ah.partnerService.GetAll(ctx, ctx.Query("created_by"))
 		if err != nil {
 			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_PARTNER, err.Error(), nil)
 			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
@@ -54,7 +54,7 @@ func (ph *positionHandler) GetAll(ctx *gin.Context) {
 
 	if !usePagination {
 		// Tanpa pagination
-		result, err := ph.positionService.GetAll(ctx)
+		result, err := ph.positionService.GetAll(ctx, ctx.Query("created_by"))
 		if err != nil {
 			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_POSITION, err.Error(), nil)
 			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
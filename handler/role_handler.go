@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/response"
+	"github.com/Amierza/nawasena-backend/service"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	IRoleHandler interface {
+		Create(ctx *gin.Context)
+		GetAll(ctx *gin.Context)
+		GetDetail(ctx *gin.Context)
+		Update(ctx *gin.Context)
+		Delete(ctx *gin.Context)
+		GetAllPermissions(ctx *gin.Context)
+		AssignRole(ctx *gin.Context)
+	}
+
+	roleHandler struct {
+		roleService service.IRoleService
+	}
+)
+
+func NewRoleHandler(roleService service.IRoleService) *roleHandler {
+	return &roleHandler{
+		roleService: roleService,
+	}
+}
+
+func (rh *roleHandler) Create(ctx *gin.Context) {
+	var payload dto.CreateRoleRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := rh.roleService.Create(ctx, payload)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_ROLE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CREATE_ROLE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (rh *roleHandler) GetAll(ctx *gin.Context) {
+	result, err := rh.roleService.GetAll(ctx)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_ROLE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_LIST_ROLE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (rh *roleHandler) GetDetail(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	result, err := rh.roleService.GetDetail(ctx, idStr)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DETAIL_ROLE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_DETAIL_ROLE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (rh *roleHandler) Update(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	var payload dto.UpdateRoleRequest
+	payload.ID = idStr
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := rh.roleService.Update(ctx, payload)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_ROLE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_ROLE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (rh *roleHandler) Delete(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	result, err := rh.roleService.Delete(ctx, idStr)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_DELETE_ROLE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_ROLE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (rh *roleHandler) GetAllPermissions(ctx *gin.Context) {
+	result, err := rh.roleService.GetAllPermissions(ctx)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_PERMISSION, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_LIST_PERMISSION, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (rh *roleHandler) AssignRole(ctx *gin.Context) {
+	var payload dto.AssignRoleRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	payload.AdminID = ctx.Param("id")
+	payload.ActorID = ctx.GetString("admin_id")
+	if err := rh.roleService.AssignRole(ctx, payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_ASSIGN_ROLE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_ASSIGN_ROLE, nil)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -16,6 +16,10 @@ type (
 		GetDetail(ctx *gin.Context)
 		Update(ctx *gin.Context)
 		Delete(ctx *gin.Context)
+		AddImage(ctx *gin.Context)
+		UpdateImage(ctx *gin.Context)
+		RemoveImage(ctx *gin.Context)
+		ReorderImages(ctx *gin.Context)
 	}
 
 	shipHandler struct {
@@ -54,7 +58,7 @@ func (ah *shipHandler) GetAll(ctx *gin.Context) {
 
 	if !usePagination {
 		// Tanpa pagination
-		result, err := ah.shipService.GetAll(ctx)
+		result, err := ah.shipService.GetAll(ctx, ctx.Query("created_by"))
 		if err != nil {
 			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_SHIP, err.Error(), nil)
 			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
@@ -94,6 +98,10 @@ func (ah *shipHandler) GetDetail(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 	result, err := ah.shipService.GetDetail(ctx, idStr)
 	if err != nil {
+		if redirectMovedSlug(ctx, err) {
+			return
+		}
+
 		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DETAIL_SHIP, err.Error(), nil)
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
 		return
@@ -136,3 +144,76 @@ func (ah *shipHandler) Delete(ctx *gin.Context) {
 	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_SHIP, result)
 	ctx.JSON(http.StatusOK, res)
 }
+
+func (ah *shipHandler) AddImage(ctx *gin.Context) {
+	var payload dto.AddImageRequest
+	payload.ParentID = ctx.Param("id")
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.shipService.AddImage(ctx, payload)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_ADD_IMAGE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_ADD_IMAGE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *shipHandler) UpdateImage(ctx *gin.Context) {
+	var payload dto.UpdateImageRequest
+	payload.ParentID = ctx.Param("id")
+	payload.ImageID = ctx.Param("image_id")
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.shipService.UpdateImage(ctx, payload)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_IMAGE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_IMAGE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *shipHandler) RemoveImage(ctx *gin.Context) {
+	result, err := ah.shipService.RemoveImage(ctx, ctx.Param("id"), ctx.Param("image_id"))
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_REMOVE_IMAGE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REMOVE_IMAGE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ah *shipHandler) ReorderImages(ctx *gin.Context) {
+	var payload dto.ReorderImagesRequest
+	payload.ParentID = ctx.Param("id")
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.shipService.ReorderImages(ctx, payload)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_REORDER_IMAGES, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REORDER_IMAGES, result)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// redirectMovedSlug membalas 301 ke URL dengan slug terbaru kalau data diakses lewat slug lama (:id),
+// segmen setelahnya ikut dipertahankan (/news/slug-lama/related -> /news/slug-baru/related)
+func redirectMovedSlug(ctx *gin.Context, err error) bool {
+	var moved dto.SlugMovedError
+	if !errors.As(err, &moved) {
+		return false
+	}
+
+	segments := strings.Split(ctx.Request.URL.Path, "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if segments[i] == ctx.Param("id") {
+			segments[i] = moved.Slug
+			break
+		}
+	}
+
+	location := strings.Join(segments, "/")
+	if ctx.Request.URL.RawQuery != "" {
+		location += "?" + ctx.Request.URL.RawQuery
+	}
+
+	ctx.Redirect(http.StatusMovedPermanently, location)
+	return true
+}
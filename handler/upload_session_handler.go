@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/response"
+	"github.com/Amierza/nawasena-backend/service"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	IUploadSessionHandler interface {
+		Create(ctx *gin.Context)
+		GetDetail(ctx *gin.Context)
+		Append(ctx *gin.Context)
+		Complete(ctx *gin.Context)
+		Abort(ctx *gin.Context)
+	}
+
+	uploadSessionHandler struct {
+		uploadSessionService service.IUploadSessionService
+	}
+)
+
+func NewUploadSessionHandler(uploadSessionService service.IUploadSessionService) *uploadSessionHandler {
+	return &uploadSessionHandler{
+		uploadSessionService: uploadSessionService,
+	}
+}
+
+func (ush *uploadSessionHandler) Create(ctx *gin.Context) {
+	var payload dto.CreateUploadSessionRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ush.uploadSessionService.Create(ctx, payload)
+	if errors.Is(err, dto.ErrUploadTooLarge) {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_UPLOAD_SESSION, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, res)
+		return
+	}
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_UPLOAD_SESSION, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	setUploadHeaders(ctx, result)
+	ctx.Header("Location", ctx.Request.URL.Path+"/"+result.ID)
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CREATE_UPLOAD_SESSION, result)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// GetDetail juga melayani HEAD, client memakai Upload-Offset untuk melanjutkan upload
+func (ush *uploadSessionHandler) GetDetail(ctx *gin.Context) {
+	result, err := ush.uploadSessionService.GetDetail(ctx, ctx.Param("id"))
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_GET_UPLOAD_SESSION, err.Error(), nil)
+		ctx.AbortWithStatusJSON(uploadSessionStatus(err), res)
+		return
+	}
+
+	setUploadHeaders(ctx, result)
+	ctx.Header("Cache-Control", "no-store")
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_UPLOAD_SESSION, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Append menerima isi chunk mentah di body (application/offset+octet-stream), posisinya di header Upload-Offset
+func (ush *uploadSessionHandler) Append(ctx *gin.Context) {
+	offset, err := strconv.ParseInt(ctx.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_APPEND_UPLOAD_CHUNK, dto.ErrInvalidUploadOffset.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, ush.uploadSessionService.ChunkSize()+1)
+
+	result, err := ush.uploadSessionService.Append(ctx, dto.AppendUploadChunkRequest{
+		ID:       ctx.Param("id"),
+		Offset:   offset,
+		Checksum: ctx.GetHeader("Upload-Checksum"),
+		Body:     ctx.Request.Body,
+	})
+	if errors.Is(err, dto.ErrUploadOffsetMismatch) {
+		// offset server dikembalikan supaya client bisa langsung melanjutkan
+		setUploadHeaders(ctx, result)
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_APPEND_UPLOAD_CHUNK, err.Error(), result)
+		ctx.AbortWithStatusJSON(http.StatusConflict, res)
+		return
+	}
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_APPEND_UPLOAD_CHUNK, err.Error(), nil)
+		ctx.AbortWithStatusJSON(uploadSessionStatus(err), res)
+		return
+	}
+
+	setUploadHeaders(ctx, result)
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_APPEND_UPLOAD_CHUNK, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ush *uploadSessionHandler) Complete(ctx *gin.Context) {
+	result, err := ush.uploadSessionService.Complete(ctx, ctx.Param("id"))
+	var fileErr dto.FileError
+	if errors.As(err, &fileErr) {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_COMPLETE_UPLOAD, err.Error(), fileErr)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	if err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_COMPLETE_UPLOAD, err.Error(), nil)
+		ctx.AbortWithStatusJSON(uploadSessionStatus(err), res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_COMPLETE_UPLOAD, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ush *uploadSessionHandler) Abort(ctx *gin.Context) {
+	if err := ush.uploadSessionService.Abort(ctx, ctx.Param("id")); err != nil {
+		res := response.BuildResponseFailed(dto.MESSAGE_FAILED_ABORT_UPLOAD, err.Error(), nil)
+		ctx.AbortWithStatusJSON(uploadSessionStatus(err), res)
+		return
+	}
+
+	res := response.BuildResponseSuccess(dto.MESSAGE_SUCCESS_ABORT_UPLOAD, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func setUploadHeaders(ctx *gin.Context, session dto.UploadSessionResponse) {
+	ctx.Header("Upload-Offset", strconv.FormatInt(session.Offset, 10))
+	ctx.Header("Upload-Length", strconv.FormatInt(session.Size, 10))
+	ctx.Header("Upload-Expires", session.ExpiresAt.UTC().Format(http.TimeFormat))
+}
+
+func uploadSessionStatus(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.Is(err, dto.ErrUploadSessionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, dto.ErrUploadSessionExpired):
+		return http.StatusGone
+	case errors.Is(err, dto.ErrChunkTooLarge), errors.As(err, &maxBytesErr):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, dto.ErrChunkChecksumMismatch), errors.Is(err, dto.ErrUploadChecksumMismatch):
+		// 460 dipakai tus untuk checksum mismatch
+		return 460
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,55 @@
+package helper
+
+import (
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"github.com/gen2brain/webp"
+	"golang.org/x/image/draw"
+)
+
+const (
+	jpegQuality = 85
+	webpQuality = 80
+)
+
+// ResizeImage mengecilkan gambar ke lebar tertentu dengan rasio tetap, gambar yang
+// sudah lebih kecil tidak diperbesar
+func ResizeImage(img image.Image, width int) image.Image {
+	bounds := img.Bounds()
+	if bounds.Dx() <= width {
+		return img
+	}
+
+	height := bounds.Dy() * width / bounds.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+
+	return dst
+}
+
+// EncodeImage menulis gambar sesuai content type aslinya (image/jpeg atau image/png)
+func EncodeImage(w io.Writer, img image.Image, contentType string) error {
+	switch contentType {
+	case "image/jpeg":
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: jpegQuality})
+	case "image/png":
+		encoder := png.Encoder{CompressionLevel: png.BestCompression}
+		return encoder.Encode(w, img)
+	default:
+		return fmt.Errorf("unsupported image content type %s", contentType)
+	}
+}
+
+// EncodeWebP memakai libwebp versi WASM (wazero), jadi tetap tanpa cgo. Sengaja lossy
+// karena WebP lossless untuk foto malah lebih besar dari JPEG aslinya
+func EncodeWebP(w io.Writer, img image.Image) error {
+	return webp.Encode(w, img, webp.Options{Quality: webpQuality, Method: 4})
+}
@@ -0,0 +1,358 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/draw"
+	"io"
+	"sort"
+	"strings"
+)
+
+type ImageMetadata struct {
+	// Orientation sesuai tag EXIF 0x0112, 1 berarti tidak perlu diputar
+	Orientation int
+	// Fields berisi tag teks yang didukung (Artist, Copyright), key memakai nama tag EXIF
+	Fields map[string]string
+}
+
+const (
+	exifTagOrientation = 0x0112
+	exifTypeASCII      = 2
+	exifTypeShort      = 3
+)
+
+// MetadataFields adalah tag teks yang boleh dipertahankan lewat allow-list
+var MetadataFields = map[string]uint16{
+	"Artist":    0x013B,
+	"Copyright": 0x8298,
+}
+
+// pngTextKeywords memetakan keyword tEXt PNG ke nama tag EXIF
+var pngTextKeywords = map[string]string{
+	"Author":    "Artist",
+	"Copyright": "Copyright",
+}
+
+// ReadImageMetadata membaca orientasi dan tag teks dari JPEG (APP1 Exif) atau
+// PNG (chunk eXIf / tEXt). Metadata yang rusak diabaikan, bukan dianggap error.
+func ReadImageMetadata(data []byte, contentType string) ImageMetadata {
+	meta := ImageMetadata{Orientation: 1, Fields: map[string]string{}}
+
+	switch contentType {
+	case "image/jpeg":
+		if tiff := jpegExif(data); tiff != nil {
+			parseExif(tiff, &meta)
+		}
+	case "image/png":
+		readPNGMetadata(data, &meta)
+	}
+
+	if meta.Orientation < 1 || meta.Orientation > 8 {
+		meta.Orientation = 1
+	}
+
+	return meta
+}
+
+// ApplyOrientation memutar / membalik gambar sesuai orientasi EXIF sehingga hasilnya
+// tampil benar tanpa perlu tag orientasi lagi
+func ApplyOrientation(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	src := image.NewNRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+
+	dstW, dstH := w, h
+	if orientation >= 5 {
+		dstW, dstH = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dstW, dstH))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = w-1-x, y
+			case 3:
+				dx, dy = w-1-x, h-1-y
+			case 4:
+				dx, dy = x, h-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = h-1-y, x
+			case 7:
+				dx, dy = h-1-y, w-1-x
+			case 8:
+				dx, dy = y, w-1-x
+			}
+
+			si := src.PixOffset(x, y)
+			di := dst.PixOffset(dx, dy)
+			copy(dst.Pix[di:di+4], src.Pix[si:si+4])
+		}
+	}
+
+	return dst
+}
+
+// EncodeImageWithMetadata sama dengan EncodeImage, tapi hanya menulis ulang tag teks
+// yang diberikan. Metadata lain (GPS, kamera, thumbnail EXIF) otomatis hilang karena
+// gambar di-encode ulang dari pixel.
+func EncodeImageWithMetadata(w io.Writer, img image.Image, contentType string, fields map[string]string) error {
+	var buf bytes.Buffer
+	if err := EncodeImage(&buf, img, contentType); err != nil {
+		return err
+	}
+
+	encoded := buf.Bytes()
+	if len(fields) == 0 {
+		_, err := w.Write(encoded)
+		return err
+	}
+
+	switch contentType {
+	case "image/jpeg":
+		// segment APP1 Exif diletakkan tepat setelah SOI
+		payload := append([]byte("Exif\x00\x00"), buildExif(fields)...)
+		if len(payload)+2 > 0xFFFF {
+			_, err := w.Write(encoded)
+			return err
+		}
+
+		segment := []byte{0xFF, 0xE1, 0, 0}
+		binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+
+		if _, err := w.Write(encoded[:2]); err != nil {
+			return err
+		}
+		if _, err := w.Write(append(segment, payload...)); err != nil {
+			return err
+		}
+		_, err := w.Write(encoded[2:])
+		return err
+	case "image/png":
+		// chunk tEXt diletakkan setelah IHDR (8 byte signature + 25 byte IHDR)
+		if _, err := w.Write(encoded[:33]); err != nil {
+			return err
+		}
+		for _, keyword := range sortedKeys(pngTextKeywords) {
+			value, ok := fields[pngTextKeywords[keyword]]
+			if !ok {
+				continue
+			}
+			if _, err := w.Write(pngChunk("tEXt", []byte(keyword+"\x00"+value))); err != nil {
+				return err
+			}
+		}
+		_, err := w.Write(encoded[33:])
+		return err
+	default:
+		_, err := w.Write(encoded)
+		return err
+	}
+}
+
+// jpegExif mencari segment APP1 Exif dan mengembalikan isi TIFF-nya
+func jpegExif(data []byte) []byte {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return nil
+		}
+		marker := data[i+1]
+		// start of scan, setelah ini data gambar
+		if marker == 0xDA || marker == 0xD9 {
+			return nil
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return nil
+		}
+
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return segment[6:]
+		}
+
+		i += 2 + length
+	}
+
+	return nil
+}
+
+func readPNGMetadata(data []byte, meta *ImageMetadata) {
+	if len(data) < 8 || !bytes.Equal(data[:8], []byte("\x89PNG\r\n\x1a\n")) {
+		return
+	}
+
+	for i := 8; i+8 <= len(data); {
+		length := int(binary.BigEndian.Uint32(data[i:]))
+		chunkType := string(data[i+4 : i+8])
+		if length < 0 || i+12+length > len(data) {
+			return
+		}
+		chunk := data[i+8 : i+8+length]
+
+		switch chunkType {
+		case "eXIf":
+			parseExif(chunk, meta)
+		case "tEXt":
+			if keyword, value, ok := strings.Cut(string(chunk), "\x00"); ok {
+				if field, ok := pngTextKeywords[keyword]; ok {
+					meta.Fields[field] = value
+				}
+			}
+		case "IDAT", "IEND":
+			return
+		}
+
+		i += 12 + length
+	}
+}
+
+// parseExif hanya membaca IFD0, cukup untuk orientasi, Artist dan Copyright
+func parseExif(tiff []byte, meta *ImageMetadata) {
+	if len(tiff) < 8 {
+		return
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return
+	}
+
+	// offset harus di belakang header, nilai negatif terjadi di platform 32-bit
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return
+	}
+	count := int(order.Uint16(tiff[offset:]))
+
+	names := map[uint16]string{}
+	for name, tag := range MetadataFields {
+		names[tag] = name
+	}
+
+	for n := 0; n < count; n++ {
+		entry := offset + 2 + n*12
+		if entry+12 > len(tiff) {
+			return
+		}
+
+		tag := order.Uint16(tiff[entry:])
+		typ := order.Uint16(tiff[entry+2:])
+		valueCount := int(order.Uint32(tiff[entry+4:]))
+		value := tiff[entry+8 : entry+12]
+
+		if tag == exifTagOrientation && typ == exifTypeShort {
+			meta.Orientation = int(order.Uint16(value))
+			continue
+		}
+
+		name, ok := names[tag]
+		if !ok || typ != exifTypeASCII || valueCount <= 0 {
+			continue
+		}
+
+		raw := value
+		if valueCount > 4 {
+			start := int(order.Uint32(value))
+			if start < 0 || start+valueCount > len(tiff) {
+				continue
+			}
+			raw = tiff[start : start+valueCount]
+		} else {
+			raw = raw[:valueCount]
+		}
+		if text := strings.TrimRight(string(raw), "\x00 "); text != "" {
+			meta.Fields[name] = text
+		}
+	}
+}
+
+// buildExif membentuk TIFF big-endian dengan satu IFD berisi tag teks saja
+func buildExif(fields map[string]string) []byte {
+	type entry struct {
+		tag   uint16
+		value []byte
+	}
+
+	var entries []entry
+	for name, value := range fields {
+		if tag, ok := MetadataFields[name]; ok {
+			entries = append(entries, entry{tag: tag, value: append([]byte(value), 0)})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].tag < entries[j].tag })
+
+	ifdSize := 2 + 12*len(entries) + 4
+	dataOffset := 8 + ifdSize
+
+	var header, ifd, values bytes.Buffer
+	header.WriteString("MM\x00\x2A")
+	binary.Write(&header, binary.BigEndian, uint32(8))
+
+	binary.Write(&ifd, binary.BigEndian, uint16(len(entries)))
+	for _, e := range entries {
+		binary.Write(&ifd, binary.BigEndian, e.tag)
+		binary.Write(&ifd, binary.BigEndian, uint16(exifTypeASCII))
+		binary.Write(&ifd, binary.BigEndian, uint32(len(e.value)))
+
+		if len(e.value) <= 4 {
+			inline := make([]byte, 4)
+			copy(inline, e.value)
+			ifd.Write(inline)
+			continue
+		}
+
+		binary.Write(&ifd, binary.BigEndian, uint32(dataOffset+values.Len()))
+		values.Write(e.value)
+		// offset di TIFF harus genap
+		if values.Len()%2 == 1 {
+			values.WriteByte(0)
+		}
+	}
+	binary.Write(&ifd, binary.BigEndian, uint32(0))
+
+	return append(append(header.Bytes(), ifd.Bytes()...), values.Bytes()...)
+}
+
+func pngChunk(chunkType string, data []byte) []byte {
+	chunk := make([]byte, 8, 12+len(data))
+	binary.BigEndian.PutUint32(chunk, uint32(len(data)))
+	copy(chunk[4:], chunkType)
+	chunk = append(chunk, data...)
+
+	crc := crc32.NewIEEE()
+	crc.Write(chunk[4:])
+	return binary.BigEndian.AppendUint32(chunk, crc.Sum32())
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
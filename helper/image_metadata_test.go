@@ -0,0 +1,249 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+type exifEntry struct {
+	tag   uint16
+	typ   uint16
+	count uint32
+	value []byte
+}
+
+// tiffIFD membentuk TIFF dengan satu IFD, tail ditaruh tepat setelah IFD (lihat tiffTailOffset)
+func tiffIFD(order binary.AppendByteOrder, entries []exifEntry, tail []byte) []byte {
+	buf := []byte("MM\x00\x2A")
+	if order == binary.LittleEndian {
+		buf = []byte("II\x2A\x00")
+	}
+	buf = order.AppendUint32(buf, 8)
+
+	buf = order.AppendUint16(buf, uint16(len(entries)))
+	for _, e := range entries {
+		buf = order.AppendUint16(buf, e.tag)
+		buf = order.AppendUint16(buf, e.typ)
+		buf = order.AppendUint32(buf, e.count)
+		value := make([]byte, 4)
+		copy(value, e.value)
+		buf = append(buf, value...)
+	}
+	buf = order.AppendUint32(buf, 0)
+
+	return append(buf, tail...)
+}
+
+func tiffTailOffset(entries int) uint32 {
+	return uint32(8 + 2 + 12*entries + 4)
+}
+
+func jpegWithExif(tiff []byte) []byte {
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	data := []byte{0xFF, 0xD8, 0xFF, 0xE1}
+	data = binary.BigEndian.AppendUint16(data, uint16(len(payload)+2))
+	data = append(data, payload...)
+
+	return append(data, 0xFF, 0xD9)
+}
+
+func pngWithChunks(chunks ...[]byte) []byte {
+	data := []byte("\x89PNG\r\n\x1a\n")
+	for _, chunk := range chunks {
+		data = append(data, chunk...)
+	}
+
+	return append(data, pngChunk("IEND", nil)...)
+}
+
+func orientationEntry(order binary.AppendByteOrder, orientation uint16) exifEntry {
+	return exifEntry{tag: exifTagOrientation, typ: exifTypeShort, count: 1, value: order.AppendUint16(nil, orientation)}
+}
+
+func TestReadImageMetadataMalformed(t *testing.T) {
+	be := binary.BigEndian
+	outside := be.AppendUint32(nil, 0xFFFFFFF0)
+
+	tests := []struct {
+		name        string
+		data        []byte
+		contentType string
+	}{
+		{name: "jpeg kosong", data: nil, contentType: "image/jpeg"},
+		{name: "jpeg tanpa app1", data: []byte{0xFF, 0xD8, 0xFF, 0xD9}, contentType: "image/jpeg"},
+		{name: "app1 terpotong sebelum panjang", data: []byte{0xFF, 0xD8, 0xFF, 0xE1}, contentType: "image/jpeg"},
+		{name: "panjang app1 melebihi data", data: append([]byte{0xFF, 0xD8, 0xFF, 0xE1, 0xFF, 0xFF}, "Exif\x00\x00MM"...), contentType: "image/jpeg"},
+		{name: "panjang app1 kurang dari 2", data: append([]byte{0xFF, 0xD8, 0xFF, 0xE1, 0x00, 0x01}, "Exif\x00\x00"...), contentType: "image/jpeg"},
+		{name: "tiff terlalu pendek", data: jpegWithExif([]byte("MM\x00")), contentType: "image/jpeg"},
+		{name: "byte order tidak dikenal", data: jpegWithExif([]byte("XX\x00\x2A\x00\x00\x00\x08\x00\x00")), contentType: "image/jpeg"},
+		{name: "offset ifd di dalam header", data: jpegWithExif(append([]byte("MM\x00\x2A\x00\x00\x00\x00"), make([]byte, 16)...)), contentType: "image/jpeg"},
+		{name: "offset ifd di luar buffer", data: jpegWithExif(append([]byte("MM\x00\x2A"), outside...)), contentType: "image/jpeg"},
+		{
+			name:        "jumlah entry melebihi buffer",
+			data:        jpegWithExif(append([]byte("MM\x00\x2A\x00\x00\x00\x08"), 0xFF, 0xFF)),
+			contentType: "image/jpeg",
+		},
+		{
+			name: "offset teks di luar buffer",
+			data: jpegWithExif(tiffIFD(be, []exifEntry{
+				{tag: MetadataFields["Artist"], typ: exifTypeASCII, count: 32, value: outside},
+				{tag: MetadataFields["Copyright"], typ: exifTypeASCII, count: 0xFFFFFFFF, value: be.AppendUint32(nil, 8)},
+			}, nil)),
+			contentType: "image/jpeg",
+		},
+		{name: "orientasi di luar 1-8", data: jpegWithExif(tiffIFD(be, []exifEntry{orientationEntry(be, 9)}, nil)), contentType: "image/jpeg"},
+		{name: "png kosong", data: nil, contentType: "image/png"},
+		{name: "header chunk terpotong", data: append([]byte("\x89PNG\r\n\x1a\n"), 0, 0, 0, 1), contentType: "image/png"},
+		{name: "panjang chunk melebihi data", data: append([]byte("\x89PNG\r\n\x1a\n\xFF\xFF\xFF\xFFtEXt"), "Author\x00Budi"...), contentType: "image/png"},
+		{name: "exif png dengan offset rusak", data: pngWithChunks(pngChunk("eXIf", append([]byte("MM\x00\x2A"), outside...))), contentType: "image/png"},
+		{name: "text tanpa pemisah", data: pngWithChunks(pngChunk("tEXt", []byte("AuthorBudi"))), contentType: "image/png"},
+		{name: "tipe lain diabaikan", data: jpegWithExif(tiffIFD(be, []exifEntry{orientationEntry(be, 6)}, nil)), contentType: "image/webp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta := ReadImageMetadata(tt.data, tt.contentType)
+			if meta.Orientation != 1 {
+				t.Errorf("Orientation = %d, want 1", meta.Orientation)
+			}
+			if len(meta.Fields) != 0 {
+				t.Errorf("Fields = %v, want empty", meta.Fields)
+			}
+		})
+	}
+}
+
+func TestReadImageMetadataOrientation(t *testing.T) {
+	for _, order := range []binary.AppendByteOrder{binary.BigEndian, binary.LittleEndian} {
+		tiff := tiffIFD(order, []exifEntry{orientationEntry(order, 6)}, nil)
+
+		if got := ReadImageMetadata(jpegWithExif(tiff), "image/jpeg").Orientation; got != 6 {
+			t.Errorf("%v jpeg Orientation = %d, want 6", order, got)
+		}
+		if got := ReadImageMetadata(pngWithChunks(pngChunk("eXIf", tiff)), "image/png").Orientation; got != 6 {
+			t.Errorf("%v png Orientation = %d, want 6", order, got)
+		}
+	}
+}
+
+func TestApplyOrientation(t *testing.T) {
+	// gambar 3x2 dengan warna unik per pixel, dicek posisi akhir pixel (0,0) dan (1,0)
+	src := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 3; x++ {
+			src.Set(x, y, color.NRGBA{R: uint8(x * 60), G: uint8(y * 120), B: 10, A: 255})
+		}
+	}
+	first, second := src.At(0, 0), src.At(1, 0)
+
+	tests := []struct {
+		orientation int
+		size        image.Point
+		first       image.Point
+		second      image.Point
+	}{
+		{orientation: 0, size: image.Pt(3, 2), first: image.Pt(0, 0), second: image.Pt(1, 0)},
+		{orientation: 1, size: image.Pt(3, 2), first: image.Pt(0, 0), second: image.Pt(1, 0)},
+		{orientation: 2, size: image.Pt(3, 2), first: image.Pt(2, 0), second: image.Pt(1, 0)},
+		{orientation: 3, size: image.Pt(3, 2), first: image.Pt(2, 1), second: image.Pt(1, 1)},
+		{orientation: 4, size: image.Pt(3, 2), first: image.Pt(0, 1), second: image.Pt(1, 1)},
+		{orientation: 5, size: image.Pt(2, 3), first: image.Pt(0, 0), second: image.Pt(0, 1)},
+		{orientation: 6, size: image.Pt(2, 3), first: image.Pt(1, 0), second: image.Pt(1, 1)},
+		{orientation: 7, size: image.Pt(2, 3), first: image.Pt(1, 2), second: image.Pt(1, 1)},
+		{orientation: 8, size: image.Pt(2, 3), first: image.Pt(0, 2), second: image.Pt(0, 1)},
+		{orientation: 9, size: image.Pt(3, 2), first: image.Pt(0, 0), second: image.Pt(1, 0)},
+	}
+
+	for _, tt := range tests {
+		got := ApplyOrientation(src, tt.orientation)
+		if size := got.Bounds().Size(); size != tt.size {
+			t.Errorf("orientation %d: size = %v, want %v", tt.orientation, size, tt.size)
+			continue
+		}
+		if c := got.At(tt.first.X, tt.first.Y); c != first {
+			t.Errorf("orientation %d: pixel at %v = %v, want %v", tt.orientation, tt.first, c, first)
+		}
+		if c := got.At(tt.second.X, tt.second.Y); c != second {
+			t.Errorf("orientation %d: pixel at %v = %v, want %v", tt.orientation, tt.second, c, second)
+		}
+	}
+}
+
+func TestEncodeImageWithMetadataRoundTrip(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+
+	tests := []struct {
+		name        string
+		contentType string
+		fields      map[string]string
+	}{
+		{name: "jpeg", contentType: "image/jpeg", fields: map[string]string{"Artist": "Budi Santoso", "Copyright": "(c) Nawasena 2026"}},
+		{name: "jpeg nilai pendek", contentType: "image/jpeg", fields: map[string]string{"Artist": "Ani"}},
+		{name: "png", contentType: "image/png", fields: map[string]string{"Artist": "Budi Santoso", "Copyright": "(c) Nawasena 2026"}},
+		{name: "tanpa tag", contentType: "image/jpeg", fields: map[string]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := EncodeImageWithMetadata(&buf, img, tt.contentType, tt.fields); err != nil {
+				t.Fatal(err)
+			}
+			if _, _, err := image.Decode(bytes.NewReader(buf.Bytes())); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+
+			meta := ReadImageMetadata(buf.Bytes(), tt.contentType)
+			if !reflect.DeepEqual(meta.Fields, tt.fields) {
+				t.Errorf("Fields = %v, want %v", meta.Fields, tt.fields)
+			}
+		})
+	}
+}
+
+func TestImageMetadataAllowList(t *testing.T) {
+	be := binary.BigEndian
+	tail := []byte("Canon\x00Budi Santoso\x00")
+	offset := tiffTailOffset(4)
+	tiff := tiffIFD(be, []exifEntry{
+		{tag: 0x010F, typ: exifTypeASCII, count: 6, value: be.AppendUint32(nil, offset)}, // Make
+		{tag: MetadataFields["Artist"], typ: exifTypeASCII, count: 13, value: be.AppendUint32(nil, offset+6)},
+		{tag: 0x8825, typ: 4, count: 1, value: be.AppendUint32(nil, 8)}, // pointer GPS IFD
+		{tag: 0x8827, typ: exifTypeShort, count: 1, value: be.AppendUint16(nil, 100)},
+	}, tail)
+
+	want := map[string]string{"Artist": "Budi Santoso"}
+	if meta := ReadImageMetadata(jpegWithExif(tiff), "image/jpeg"); !reflect.DeepEqual(meta.Fields, want) {
+		t.Errorf("jpeg Fields = %v, want %v", meta.Fields, want)
+	}
+
+	png := pngWithChunks(
+		pngChunk("tEXt", []byte("Author\x00Budi Santoso")),
+		pngChunk("tEXt", []byte("Software\x00Canon")),
+		pngChunk("tEXt", []byte("GPSLatitude\x00-6.2")),
+	)
+	if meta := ReadImageMetadata(png, "image/png"); !reflect.DeepEqual(meta.Fields, want) {
+		t.Errorf("png Fields = %v, want %v", meta.Fields, want)
+	}
+
+	// tag di luar allow-list tidak ikut ditulis walau diberikan
+	fields := map[string]string{"Artist": "Budi Santoso", "Make": "Canon", "GPSLatitude": "-6.2"}
+	for _, contentType := range []string{"image/jpeg", "image/png"} {
+		var buf bytes.Buffer
+		if err := EncodeImageWithMetadata(&buf, image.NewNRGBA(image.Rect(0, 0, 2, 2)), contentType, fields); err != nil {
+			t.Fatal(err)
+		}
+		for _, dropped := range []string{"Canon", "-6.2", "GPS"} {
+			if bytes.Contains(buf.Bytes(), []byte(dropped)) {
+				t.Errorf("%s output contains %q", contentType, dropped)
+			}
+		}
+		if meta := ReadImageMetadata(buf.Bytes(), contentType); !reflect.DeepEqual(meta.Fields, want) {
+			t.Errorf("%s Fields = %v, want %v", contentType, meta.Fields, want)
+		}
+	}
+}
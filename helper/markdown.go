@@ -0,0 +1,192 @@
+package helper
+
+import (
+	"bytes"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+const (
+	excerptLength  = 200
+	wordsPerMinute = 200
+)
+
+type (
+	MarkdownHeading struct {
+		Level int
+		Text  string
+		ID    string
+	}
+	// MarkdownImage adalah hasil resolve referensi gambar di Markdown ke file upload
+	MarkdownImage struct {
+		Name   string
+		URL    string
+		Srcset string
+	}
+	MarkdownDocument struct {
+		HTML        string
+		Excerpt     string
+		ReadingTime int
+		Headings    []MarkdownHeading
+		// Images berisi nama file upload yang dipakai di body, dipakai GC supaya filenya tidak terhapus
+		Images []string
+	}
+)
+
+var (
+	markdown = goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+	)
+
+	// raw HTML sudah dibuang goldmark (tanpa WithUnsafe), sanitizer tetap dipasang untuk link javascript: dkk.
+	markdownPolicy = func() *bluemonday.Policy {
+		policy := bluemonday.UGCPolicy()
+		policy.AllowAttrs("srcset", "sizes", "loading").OnElements("img")
+		policy.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+-]+$`)).OnElements("code")
+		return policy
+	}()
+)
+
+// RenderMarkdown merender Markdown ke HTML yang sudah disanitasi sekaligus mengambil excerpt, estimasi
+// waktu baca (menit) dan daftar heading untuk daftar isi. resolveImage dipanggil untuk setiap gambar yang
+// bukan url absolut (![alt](nama-file.png) atau id media), nil berarti referensi dibiarkan apa adanya
+func RenderMarkdown(source string, resolveImage func(ref string) (MarkdownImage, error)) (MarkdownDocument, error) {
+	src := []byte(source)
+	doc := markdown.Parser().Parse(text.NewReader(src))
+
+	res := MarkdownDocument{
+		Headings: []MarkdownHeading{},
+		Images:   []string{},
+	}
+	var (
+		words   int
+		excerpt []string
+	)
+	err := ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch node := n.(type) {
+		case *ast.Heading:
+			heading := MarkdownHeading{Level: node.Level, Text: plainText(node, src)}
+			if id, ok := node.AttributeString("id"); ok {
+				if value, ok := id.([]byte); ok {
+					heading.ID = string(value)
+				}
+			}
+			res.Headings = append(res.Headings, heading)
+
+		case *ast.Paragraph:
+			content := plainText(node, src)
+			if content != "" && utf8.RuneCountInString(strings.Join(excerpt, " ")) < excerptLength {
+				excerpt = append(excerpt, content)
+			}
+
+		case *ast.Text:
+			words += len(strings.Fields(string(node.Segment.Value(src))))
+
+		case *ast.FencedCodeBlock, *ast.CodeBlock:
+			lines := node.Lines()
+			for i := 0; i < lines.Len(); i++ {
+				segment := lines.At(i)
+				words += len(strings.Fields(string(segment.Value(src))))
+			}
+
+		case *ast.Image:
+			ref := string(node.Destination)
+			if resolveImage == nil || isAbsoluteURL(ref) {
+				return ast.WalkContinue, nil
+			}
+
+			image, err := resolveImage(ref)
+			if err != nil {
+				return ast.WalkStop, err
+			}
+
+			node.Destination = []byte(image.URL)
+			if image.Srcset != "" {
+				node.SetAttributeString("srcset", []byte(image.Srcset))
+			}
+			node.SetAttributeString("loading", []byte("lazy"))
+			if !slices.Contains(res.Images, image.Name) {
+				res.Images = append(res.Images, image.Name)
+			}
+		}
+
+		return ast.WalkContinue, nil
+	})
+	if err != nil {
+		return MarkdownDocument{}, err
+	}
+
+	var buf bytes.Buffer
+	if err := markdown.Renderer().Render(&buf, src, doc); err != nil {
+		return MarkdownDocument{}, err
+	}
+
+	res.HTML = markdownPolicy.Sanitize(buf.String())
+	res.Excerpt = Excerpt(strings.Join(excerpt, " "), excerptLength)
+	if words > 0 {
+		res.ReadingTime = int(math.Ceil(float64(words) / wordsPerMinute))
+	}
+
+	return res, nil
+}
+
+// Excerpt memotong teks di batas kata terdekat sebelum limit karakter
+func Excerpt(str string, limit int) string {
+	str = strings.Join(strings.Fields(str), " ")
+	if utf8.RuneCountInString(str) <= limit {
+		return str
+	}
+
+	cut := string([]rune(str)[:limit])
+	if i := strings.LastIndexByte(cut, ' '); i > limit/2 {
+		cut = cut[:i]
+	}
+
+	return strings.TrimRight(cut, " ,.;:-") + "…"
+}
+
+// plainText menggabungkan teks di dalam node tanpa markup (link, penekanan, kode inline)
+func plainText(n ast.Node, src []byte) string {
+	var b strings.Builder
+	_ = ast.Walk(n, func(child ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch node := child.(type) {
+		case *ast.Text:
+			b.Write(node.Segment.Value(src))
+			if node.SoftLineBreak() || node.HardLineBreak() {
+				b.WriteByte(' ')
+			}
+		case *ast.String:
+			b.Write(node.Value)
+		case *ast.Image:
+			// alt text gambar tidak ikut excerpt
+			return ast.WalkSkipChildren, nil
+		}
+
+		return ast.WalkContinue, nil
+	})
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+func isAbsoluteURL(ref string) bool {
+	return strings.Contains(ref, "://") || strings.HasPrefix(ref, "//") || strings.HasPrefix(ref, "data:")
+}
@@ -0,0 +1,179 @@
+package helper
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRenderMarkdownSanitize(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "tag script dibuang",
+			source:  "halo\n\n<script>alert(1)</script>",
+			want:    []string{"<p>halo</p>"},
+			notWant: []string{"<script", "alert(1)"},
+		},
+		{
+			name:    "html mentah dengan event handler",
+			source:  `<img src="x" onerror="alert(1)">`,
+			notWant: []string{"onerror", "<img"},
+		},
+		{
+			name:    "link javascript",
+			source:  "[klik](javascript:alert(1))",
+			want:    []string{"klik"},
+			notWant: []string{"javascript:"},
+		},
+		{
+			name:    "link data",
+			source:  "[klik](data:text/html;base64,PHNjcmlwdD4=)",
+			notWant: []string{"data:text/html"},
+		},
+		{
+			name:    "gambar javascript",
+			source:  "![x](javascript:alert(1))",
+			notWant: []string{"javascript:"},
+		},
+		{
+			name:   "link https tetap",
+			source: "[situs](https://example.com/a?b=1)",
+			want:   []string{`href="https://example.com/a?b=1"`, `rel="nofollow"`},
+		},
+		{
+			name:   "kelas bahasa code block tetap",
+			source: "```go\nfmt.Println(1)\n```",
+			want:   []string{`class="language-go"`},
+		},
+		{
+			name:    "kelas lain pada code dibuang",
+			source:  `<code class="evil">x</code>`,
+			notWant: []string{`class="evil"`},
+		},
+		{
+			name:   "tabel gfm",
+			source: "| a | b |\n|---|---|\n| 1 | 2 |",
+			want:   []string{"<table>", "<td>1</td>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := RenderMarkdown(tt.source, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, want := range tt.want {
+				if !strings.Contains(doc.HTML, want) {
+					t.Errorf("HTML %q does not contain %q", doc.HTML, want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(doc.HTML, notWant) {
+					t.Errorf("HTML %q contains %q", doc.HTML, notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderMarkdownImages(t *testing.T) {
+	var resolved []string
+	resolve := func(ref string) (MarkdownImage, error) {
+		resolved = append(resolved, ref)
+		return MarkdownImage{
+			Name:   "file-" + ref,
+			URL:    "/uploads/file-" + ref,
+			Srcset: "/uploads/file-" + ref + " 1x",
+		}, nil
+	}
+
+	source := "![satu](a.png)\n\n![dua](a.png)\n\n![luar](https://cdn.example.com/b.png)"
+	doc, err := RenderMarkdown(source, resolve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// url absolut tidak di-resolve, gambar yang sama dicatat sekali
+	if !reflect.DeepEqual(resolved, []string{"a.png", "a.png"}) {
+		t.Errorf("resolved = %v", resolved)
+	}
+	if !reflect.DeepEqual(doc.Images, []string{"file-a.png"}) {
+		t.Errorf("Images = %v", doc.Images)
+	}
+	for _, want := range []string{`src="/uploads/file-a.png"`, `srcset="/uploads/file-a.png 1x"`, `loading="lazy"`, `src="https://cdn.example.com/b.png"`} {
+		if !strings.Contains(doc.HTML, want) {
+			t.Errorf("HTML %q does not contain %q", doc.HTML, want)
+		}
+	}
+
+	errMissing := errors.New("media not found")
+	_, err = RenderMarkdown("![x](hilang.png)", func(string) (MarkdownImage, error) {
+		return MarkdownImage{}, errMissing
+	})
+	if !errors.Is(err, errMissing) {
+		t.Errorf("err = %v, want %v", err, errMissing)
+	}
+}
+
+func TestRenderMarkdownSummary(t *testing.T) {
+	source := "# Judul Utama\n\nParagraf **pertama** dengan [link](https://example.com).\n\n## Sub Judul\n\n![gambar](https://example.com/a.png)\n\nParagraf kedua."
+
+	doc, err := RenderMarkdown(source, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantHeadings := []MarkdownHeading{
+		{Level: 1, Text: "Judul Utama", ID: "judul-utama"},
+		{Level: 2, Text: "Sub Judul", ID: "sub-judul"},
+	}
+	if !reflect.DeepEqual(doc.Headings, wantHeadings) {
+		t.Errorf("Headings = %+v, want %+v", doc.Headings, wantHeadings)
+	}
+	if want := "Paragraf pertama dengan link. Paragraf kedua."; doc.Excerpt != want {
+		t.Errorf("Excerpt = %q, want %q", doc.Excerpt, want)
+	}
+	if doc.ReadingTime != 1 {
+		t.Errorf("ReadingTime = %d, want 1", doc.ReadingTime)
+	}
+
+	long, err := RenderMarkdown(strings.Repeat("kata ", 401), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if long.ReadingTime != 3 {
+		t.Errorf("ReadingTime for 401 words = %d, want 3", long.ReadingTime)
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		limit int
+		want  string
+	}{
+		{name: "lebih pendek dari limit", in: "halo dunia", limit: 20, want: "halo dunia"},
+		{name: "spasi dirapikan", in: "  halo \n  dunia  ", limit: 20, want: "halo dunia"},
+		{name: "dipotong di batas kata", in: "satu dua tiga empat", limit: 12, want: "satu dua…"},
+		{name: "tanda baca di ujung dibuang", in: "satu, dua, tiga", limit: 10, want: "satu, dua…"},
+		{name: "kata panjang dipotong paksa", in: "abcdefghijklmnop", limit: 5, want: "abcde…"},
+		{name: "multibyte tidak terpotong", in: "ééééé ééééé", limit: 7, want: "ééééé…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Excerpt(tt.in, tt.limit); got != tt.want {
+				t.Errorf("Excerpt(%q, %d) = %q, want %q", tt.in, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,11 +1,24 @@
 package helper
 
 import (
+	"os"
+	"strconv"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
+// PasswordCost baca BCRYPT_COST dari env, default bcrypt.DefaultCost
+func PasswordCost() int {
+	cost, err := strconv.Atoi(os.Getenv("BCRYPT_COST"))
+	if err != nil || cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return bcrypt.DefaultCost
+	}
+
+	return cost
+}
+
 func HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 4)
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), PasswordCost())
 	return string(bytes), err
 }
 
@@ -17,3 +30,13 @@ func CheckPassword(hashPassword string, plainPassword []byte) (bool, error) {
 
 	return true, nil
 }
+
+// PasswordNeedsRehash true kalau hash lama dibuat dengan cost lebih rendah dari konfigurasi sekarang
+func PasswordNeedsRehash(hashPassword string) bool {
+	cost, err := bcrypt.Cost([]byte(hashPassword))
+	if err != nil {
+		return false
+	}
+
+	return cost < PasswordCost()
+}
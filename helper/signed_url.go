@@ -0,0 +1,50 @@
+package helper
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"os"
+	"strconv"
+)
+
+var errSigningKeyNotSet = errors.New("SIGNED_URL_SECRET is not set")
+
+// signingKey memakai SIGNED_URL_SECRET, kalau kosong diturunkan dari ENCRYPTION_KEY
+// supaya deployment lama tetap jalan tanpa env baru
+func signingKey() ([]byte, error) {
+	secret := os.Getenv("SIGNED_URL_SECRET")
+	if secret == "" {
+		secret = os.Getenv("ENCRYPTION_KEY")
+		if secret == "" {
+			return nil, errSigningKeyNotSet
+		}
+		secret = "signed-url:" + secret
+	}
+
+	return []byte(secret), nil
+}
+
+// SignDownload membuat HMAC-SHA256 dari nama file dan waktu kadaluarsa (unix), hasilnya base64 url-safe
+func SignDownload(name string, expires int64) (string, error) {
+	key, err := signingKey()
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(name + "\n" + strconv.FormatInt(expires, 10)))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// VerifyDownload membandingkan signature dengan constant time
+func VerifyDownload(name string, expires int64, signature string) bool {
+	expected, err := SignDownload(name, expires)
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
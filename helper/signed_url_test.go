@@ -0,0 +1,74 @@
+package helper
+
+import (
+	"testing"
+)
+
+func TestVerifyDownload(t *testing.T) {
+	t.Setenv("SIGNED_URL_SECRET", "rahasia-signed-url")
+
+	const (
+		name    = "kontrak.pdf"
+		expires = int64(1700000000)
+	)
+	signature, err := SignDownload(name, expires)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		file      string
+		expires   int64
+		signature string
+		want      bool
+	}{
+		{name: "signature valid", file: name, expires: expires, signature: signature, want: true},
+		{name: "nama file diganti", file: "kontrak2.pdf", expires: expires, signature: signature},
+		{name: "expiry diperpanjang", file: name, expires: expires + 3600, signature: signature},
+		{name: "signature diubah", file: name, expires: expires, signature: signature[:len(signature)-1] + "A"},
+		{name: "signature dipotong", file: name, expires: expires, signature: signature[:10]},
+		{name: "signature kosong", file: name, expires: expires, signature: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyDownload(tt.file, tt.expires, tt.signature); got != tt.want {
+				t.Errorf("VerifyDownload = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSignDownloadSigningKey(t *testing.T) {
+	t.Run("tanpa secret ditolak", func(t *testing.T) {
+		t.Setenv("SIGNED_URL_SECRET", "")
+		t.Setenv("ENCRYPTION_KEY", "")
+
+		if _, err := SignDownload("a.pdf", 1); err == nil {
+			t.Error("SignDownload without any key succeeded")
+		}
+		if VerifyDownload("a.pdf", 1, "") {
+			t.Error("VerifyDownload without any key accepted an empty signature")
+		}
+	})
+
+	t.Run("fallback ENCRYPTION_KEY", func(t *testing.T) {
+		t.Setenv("SIGNED_URL_SECRET", "")
+		t.Setenv("ENCRYPTION_KEY", "kunci-enkripsi")
+
+		signature, err := SignDownload("a.pdf", 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !VerifyDownload("a.pdf", 1, signature) {
+			t.Error("signature from ENCRYPTION_KEY fallback was rejected")
+		}
+
+		// signature lama tidak berlaku lagi setelah SIGNED_URL_SECRET diisi
+		t.Setenv("SIGNED_URL_SECRET", "kunci-enkripsi")
+		if VerifyDownload("a.pdf", 1, signature) {
+			t.Error("fallback key must differ from SIGNED_URL_SECRET with the same value")
+		}
+	})
+}
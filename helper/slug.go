@@ -0,0 +1,67 @@
+package helper
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+const maxSlugLength = 100
+
+// huruf yang tidak terurai lewat NFD, jadi ditransliterasi manual
+var slugReplacer = strings.NewReplacer(
+	"&", " dan ",
+	"@", " at ",
+	"ß", "ss",
+	"æ", "ae",
+	"Æ", "ae",
+	"œ", "oe",
+	"Œ", "oe",
+	"ø", "o",
+	"Ø", "o",
+	"đ", "d",
+	"Đ", "d",
+	"ł", "l",
+	"Ł", "l",
+	"ı", "i",
+)
+
+// Slugify mengubah judul jadi slug URL: "Juara 1 KRI 2024 – Surabaya" -> "juara-1-kri-2024-surabaya".
+// Aksen dibuang (é -> e), apostrof dihapus tanpa pemisah (Jum'at -> jumat), huruf non-latin diabaikan
+func Slugify(str string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	plain, _, err := transform.String(t, slugReplacer.Replace(str))
+	if err != nil {
+		plain = str
+	}
+
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(plain) {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			hyphen = false
+		case r == '\'' || r == '’' || r == '`':
+		default:
+			hyphen = true
+		}
+	}
+
+	slug := b.String()
+	if len(slug) > maxSlugLength {
+		slug = slug[:maxSlugLength]
+		if i := strings.LastIndexByte(slug, '-'); i > maxSlugLength/2 {
+			slug = slug[:i]
+		}
+		slug = strings.TrimRight(slug, "-")
+	}
+
+	return slug
+}
@@ -0,0 +1,66 @@
+package helper
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "judul biasa", in: "Juara 1 KRI 2024 – Surabaya", want: "juara-1-kri-2024-surabaya"},
+		{name: "aksen dibuang", in: "Café Crème Brûlée", want: "cafe-creme-brulee"},
+		{name: "apostrof tanpa pemisah", in: "Jum'at Berkah", want: "jumat-berkah"},
+		{name: "apostrof tipografis", in: "Nawasena’s Ship", want: "nawasenas-ship"},
+		{name: "ampersand dan at", in: "R&D @ ITS", want: "r-dan-d-at-its"},
+		{name: "transliterasi manual", in: "Straße Øresund Łódź", want: "strasse-oresund-lodz"},
+		{name: "pemisah berulang & di tepi", in: "  --Hello,   World!--  ", want: "hello-world"},
+		{name: "huruf non-latin diabaikan", in: "Kapal 船 Nawasena", want: "kapal-nawasena"},
+		{name: "hanya simbol", in: "!!! ???", want: ""},
+		{name: "kosong", in: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Slugify(tt.in); got != tt.want {
+				t.Errorf("Slugify(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlugifyMaxLength(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			// dipotong di tanda hubung terakhir supaya kata tidak terpotong
+			name: "dipotong di batas kata",
+			in:   strings.Repeat("kapal ", 30),
+			want: strings.TrimSuffix(strings.Repeat("kapal-", 16), "-"),
+		},
+		{
+			// satu kata panjang tanpa tanda hubung dipotong persis di batas
+			name: "satu kata panjang",
+			in:   strings.Repeat("a", 150),
+			want: strings.Repeat("a", maxSlugLength),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Slugify(tt.in)
+			if got != tt.want {
+				t.Errorf("Slugify = %q, want %q", got, tt.want)
+			}
+			if len(got) > maxSlugLength || strings.HasSuffix(got, "-") {
+				t.Errorf("Slugify = %q, length %d or trailing hyphen", got, len(got))
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package helper
+
+import (
+	"testing"
+	"time"
+)
+
+// secret RFC 6238 lampiran B ("12345678901234567890") dalam base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCodeRFC6238(t *testing.T) {
+	// kode 8 digit di RFC dipotong jadi 6 digit terakhir
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+		{unix: 20000000000, want: "353130"},
+	}
+
+	for _, tt := range tests {
+		got, err := TOTPCode(rfc6238Secret, TOTPStep(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("TOTPCode(%d): %v", tt.unix, err)
+		}
+		if got != tt.want {
+			t.Errorf("TOTPCode(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTOTPWindow(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	current := TOTPStep(now)
+
+	codeAt := func(step int64) string {
+		code, err := TOTPCode(rfc6238Secret, step)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return code
+	}
+
+	tests := []struct {
+		name     string
+		code     string
+		lastStep int64
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "step sekarang", code: codeAt(current), wantStep: current, wantOK: true},
+		{name: "satu step sebelum", code: codeAt(current - 1), wantStep: current - 1, wantOK: true},
+		{name: "satu step sesudah", code: codeAt(current + 1), wantStep: current + 1, wantOK: true},
+		{name: "dua step sebelum ditolak", code: codeAt(current - 2)},
+		{name: "dua step sesudah ditolak", code: codeAt(current + 2)},
+		{name: "spasi diabaikan", code: codeAt(current)[:3] + " " + codeAt(current)[3:], wantStep: current, wantOK: true},
+		{name: "step sudah dipakai ditolak", code: codeAt(current), lastStep: current},
+		{name: "step lama ditolak setelah step baru dipakai", code: codeAt(current - 1), lastStep: current - 1},
+		{name: "step baru tetap diterima", code: codeAt(current + 1), lastStep: current, wantStep: current + 1, wantOK: true},
+		{name: "panjang salah", code: codeAt(current)[:5]},
+		{name: "kosong", code: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := ValidateTOTP(rfc6238Secret, tt.code, now, tt.lastStep)
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Errorf("ValidateTOTP = (%d, %v), want (%d, %v)", step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestValidateTOTPInvalidSecret(t *testing.T) {
+	if _, ok := ValidateTOTP("bukan base32!", "123456", time.Now(), 0); ok {
+		t.Error("ValidateTOTP accepted a code for an invalid secret")
+	}
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// kode yang dibuat dari secret baru harus lolos validasi
+	now := time.Now()
+	code, err := TOTPCode(secret, TOTPStep(now))
+	if err != nil {
+		t.Fatalf("TOTPCode with generated secret: %v", err)
+	}
+	if _, ok := ValidateTOTP(secret, code, now, 0); !ok {
+		t.Error("code from generated secret was rejected")
+	}
+}
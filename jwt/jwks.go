@@ -0,0 +1,29 @@
+package jwt
+
+import "sort"
+
+type JWKSet struct {
+	Keys []map[string]string `json:"keys"`
+}
+
+// JWKS mengembalikan public key yang masih aktif untuk verifikasi, secret HS256 tidak pernah dipublikasikan
+func (j *JWT) JWKS() JWKSet {
+	set := JWKSet{Keys: []map[string]string{}}
+	for kid, key := range j.keys {
+		jwk, err := publicKeyJWK(key.key)
+		if err != nil {
+			continue
+		}
+
+		jwk["kid"] = kid
+		jwk["alg"] = key.method.Alg()
+		jwk["use"] = "sig"
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	sort.Slice(set.Keys, func(a, b int) bool {
+		return set.Keys[a]["kid"] < set.Keys[b]["kid"]
+	})
+
+	return set
+}
@@ -2,78 +2,76 @@ package jwt
 
 import (
 	"fmt"
-	"os"
 	"time"
 
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/dto"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 type (
 	IJWT interface {
-		GenerateToken(adminID, roleName string) (string, string, error)
-		ValidateToken(token string) (*jwt.Token, error)
-		GetAdminIDByToken(tokenString string) (string, error)
-		GetAdminRoleNameByToken(tokenString string) (string, error)
+		GenerateToken(adminID, roleName, sessionID string) (string, string, error)
+		GenerateChallengeToken(adminID string) (string, error)
+		ValidateToken(token string) (*Claims, error)
+		JWKS() JWKSet
 	}
 
-	jwtCustomClaim struct {
-		AdminID  string `json:"admin_id"`
-		RoleName string `json:"role_name"`
+	Claims struct {
+		AdminID   string `json:"admin_id"`
+		RoleName  string `json:"role_name"`
+		SessionID string `json:"session_id"`
+		TokenType string `json:"token_type"`
 		jwt.RegisteredClaims
 	}
 
 	JWT struct {
-		secretKey string
-		issuer    string
+		method     jwt.SigningMethod
+		signingKey any
+		keyID      string
+		keys       map[string]verificationKey
+
+		issuer       string
+		audience     []string
+		accessTTL    time.Duration
+		refreshTTL   time.Duration
+		challengeTTL time.Duration
 	}
 )
 
 func NewJWT() *JWT {
-	return &JWT{
-		secretKey: getSecretKey(),
-		issuer:    "Template",
-	}
-}
-
-func getSecretKey() string {
-	secretKey := os.Getenv("JWT_SECRET")
-	if secretKey == "" {
-		secretKey = "Template"
+	j, err := loadConfig()
+	if err != nil {
+		panic(fmt.Errorf("failed to load jwt keys: %v", err))
 	}
 
-	return secretKey
+	return j
 }
 
-func (j *JWT) GenerateToken(adminID, roleName string) (string, string, error) {
-	accessClaims := jwtCustomClaim{
+func (j *JWT) GenerateToken(adminID, roleName, sessionID string) (string, string, error) {
+	accessClaims := Claims{
 		adminID,
 		roleName,
-		jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Second * 300)),
-			Issuer:    j.issuer,
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+		sessionID,
+		constants.ENUM_TOKEN_ACCESS,
+		j.registeredClaims(j.accessTTL),
 	}
 
-	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
-	accessTokenString, err := accessToken.SignedString([]byte(j.secretKey))
+	accessTokenString, err := j.sign(accessClaims)
 	if err != nil {
 		return "", "", dto.ErrGenerateAccessToken
 	}
 
-	refreshClaims := jwtCustomClaim{
+	refreshClaims := Claims{
 		adminID,
 		roleName,
-		jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Second * 3600 * 24 * 7)),
-			Issuer:    j.issuer,
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+		sessionID,
+		constants.ENUM_TOKEN_REFRESH,
+		j.registeredClaims(j.refreshTTL),
 	}
 
-	refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims)
-	refreshTokenString, err := refreshToken.SignedString([]byte(j.secretKey))
+	refreshTokenString, err := j.sign(refreshClaims)
 	if err != nil {
 		return "", "", dto.ErrGenerateRefreshToken
 	}
@@ -81,51 +79,70 @@ func (j *JWT) GenerateToken(adminID, roleName string) (string, string, error) {
 	return accessTokenString, refreshTokenString, nil
 }
 
-func (j *JWT) parseToken(t_ *jwt.Token) (any, error) {
-	if _, ok := t_.Method.(*jwt.SigningMethodHMAC); !ok {
-		return nil, dto.ErrUnexpectedSigningMethod
+func (j *JWT) GenerateChallengeToken(adminID string) (string, error) {
+	claims := Claims{
+		AdminID:          adminID,
+		TokenType:        constants.ENUM_TOKEN_2FA,
+		RegisteredClaims: j.registeredClaims(j.challengeTTL),
 	}
 
-	return []byte(j.secretKey), nil
-}
-
-func (j *JWT) ValidateToken(tokenString string) (*jwt.Token, error) {
-	token, err := jwt.Parse(tokenString, j.parseToken)
+	tokenString, err := j.sign(claims)
 	if err != nil {
-		return nil, err
+		return "", dto.ErrGenerateChallengeToken
 	}
 
-	return token, err
+	return tokenString, nil
 }
 
-func (j *JWT) GetAdminIDByToken(tokenString string) (string, error) {
-	token, err := j.ValidateToken(tokenString)
-	if err != nil {
-		return "", dto.ErrValidateToken
-	}
+func (j *JWT) registeredClaims(ttl time.Duration) jwt.RegisteredClaims {
+	now := time.Now()
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok || !token.Valid {
-		return "", dto.ErrTokenInvalid
+	return jwt.RegisteredClaims{
+		ID:        uuid.New().String(),
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		Issuer:    j.issuer,
+		Audience:  j.audience,
+		IssuedAt:  jwt.NewNumericDate(now),
 	}
+}
 
-	userID := fmt.Sprintf("%v", claims["admin_id"])
+func (j *JWT) sign(claims Claims) (string, error) {
+	token := jwt.NewWithClaims(j.method, claims)
+	token.Header["kid"] = j.keyID
 
-	return userID, nil
+	return token.SignedString(j.signingKey)
 }
 
-func (j *JWT) GetAdminRoleNameByToken(tokenString string) (string, error) {
-	token, err := j.ValidateToken(tokenString)
-	if err != nil {
-		return "", dto.ErrValidateToken
+func (j *JWT) parseToken(t_ *jwt.Token) (any, error) {
+	kid, _ := t_.Header["kid"].(string)
+	key, ok := j.keys[kid]
+	if !ok {
+		return nil, dto.ErrUnknownSigningKey
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok || !token.Valid {
-		return "", dto.ErrTokenInvalid
+	if t_.Method.Alg() != key.method.Alg() {
+		return nil, dto.ErrUnexpectedSigningMethod
 	}
 
-	roleName := fmt.Sprintf("%v", claims["role_name"])
+	return key.key, nil
+}
+
+// ValidateToken memverifikasi token sekali dan mengembalikan claims-nya,
+// token yang tidak valid atau kedaluwarsa selalu menghasilkan error
+func (j *JWT) ValidateToken(tokenString string) (*Claims, error) {
+	options := []jwt.ParserOption{jwt.WithIssuer(j.issuer)}
+	if len(j.audience) > 0 {
+		options = append(options, jwt.WithAudience(j.audience[0]))
+	}
+
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, j.parseToken, options...)
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, dto.ErrTokenInvalid
+	}
 
-	return roleName, nil
+	return claims, nil
 }
@@ -0,0 +1,231 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Amierza/nawasena-backend/constants"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type verificationKey struct {
+	method jwt.SigningMethod
+	key    any
+}
+
+// loadConfig membaca konfigurasi JWT dari env:
+//
+//	JWT_ALGORITHM                HS256 (default), RS256 atau EdDSA
+//	JWT_SECRET                   secret HS256
+//	JWT_PREVIOUS_SECRETS         secret HS256 lama yang masih diterima, dipisah koma
+//	JWT_SIGNING_KEY_FILE         private key PEM untuk RS256 / EdDSA
+//	JWT_VERIFICATION_KEY_FILES   public key PEM lama yang masih diterima, dipisah koma
+//	JWT_ISSUER, JWT_AUDIENCE     claim iss / aud (aud boleh dipisah koma)
+//	JWT_ACCESS_TTL, JWT_REFRESH_TTL, JWT_CHALLENGE_TTL   durasi Go, mis. 5m, 168h
+func loadConfig() (*JWT, error) {
+	j := &JWT{
+		keys:         map[string]verificationKey{},
+		issuer:       envOrDefault("JWT_ISSUER", "nawasena"),
+		audience:     splitEnv("JWT_AUDIENCE"),
+		accessTTL:    durationEnv("JWT_ACCESS_TTL", 5*time.Minute),
+		refreshTTL:   durationEnv("JWT_REFRESH_TTL", 7*24*time.Hour),
+		challengeTTL: durationEnv("JWT_CHALLENGE_TTL", 5*time.Minute),
+	}
+
+	production := os.Getenv("APP_ENV") == constants.ENUM_RUN_PRODUCTION
+
+	switch algorithm := strings.ToUpper(envOrDefault("JWT_ALGORITHM", "HS256")); algorithm {
+	case "HS256":
+		secret := os.Getenv("JWT_SECRET")
+		if secret == "" || secret == "Template" {
+			if production {
+				return nil, fmt.Errorf("JWT_SECRET must be set in production")
+			}
+
+			// di luar production pakai secret acak, token jadi tidak valid setiap restart
+			log.Println("warning: JWT_SECRET is not set, using a random secret for this process")
+			random := make([]byte, 32)
+			if _, err := rand.Read(random); err != nil {
+				return nil, err
+			}
+			secret = hex.EncodeToString(random)
+		}
+		if production && len(secret) < 32 {
+			return nil, fmt.Errorf("JWT_SECRET must be at least 32 characters in production")
+		}
+
+		j.method = jwt.SigningMethodHS256
+		j.signingKey = []byte(secret)
+		j.keyID = secretKeyID(secret)
+		j.keys[j.keyID] = verificationKey{method: jwt.SigningMethodHS256, key: []byte(secret)}
+
+		for _, previous := range splitEnv("JWT_PREVIOUS_SECRETS") {
+			j.keys[secretKeyID(previous)] = verificationKey{method: jwt.SigningMethodHS256, key: []byte(previous)}
+		}
+	case "RS256", "EDDSA":
+		path := os.Getenv("JWT_SIGNING_KEY_FILE")
+		if path == "" {
+			return nil, fmt.Errorf("JWT_SIGNING_KEY_FILE must be set for %s", algorithm)
+		}
+
+		method, privateKey, publicKey, err := loadPrivateKey(path)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(method.Alg(), algorithm) {
+			return nil, fmt.Errorf("JWT_SIGNING_KEY_FILE %s is not a %s key", path, algorithm)
+		}
+
+		j.method = method
+		j.signingKey = privateKey
+		j.keyID, err = publicKeyID(publicKey)
+		if err != nil {
+			return nil, err
+		}
+		j.keys[j.keyID] = verificationKey{method: method, key: publicKey}
+	default:
+		return nil, fmt.Errorf("unsupported JWT_ALGORITHM %q", algorithm)
+	}
+
+	// key lama tetap diterima selama rotasi supaya admin tidak ter-logout semua
+	for _, path := range splitEnv("JWT_VERIFICATION_KEY_FILES") {
+		method, publicKey, err := loadPublicKey(path)
+		if err != nil {
+			return nil, err
+		}
+
+		kid, err := publicKeyID(publicKey)
+		if err != nil {
+			return nil, err
+		}
+		j.keys[kid] = verificationKey{method: method, key: publicKey}
+	}
+
+	return j, nil
+}
+
+func loadPrivateKey(path string) (jwt.SigningMethod, any, any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed read jwt signing key: %w", err)
+	}
+
+	if key, err := jwt.ParseRSAPrivateKeyFromPEM(data); err == nil {
+		return jwt.SigningMethodRS256, key, &key.PublicKey, nil
+	}
+
+	if key, err := jwt.ParseEdPrivateKeyFromPEM(data); err == nil {
+		edKey, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("unsupported jwt signing key in %s", path)
+		}
+
+		return jwt.SigningMethodEdDSA, edKey, edKey.Public(), nil
+	}
+
+	return nil, nil, nil, fmt.Errorf("unsupported jwt signing key in %s, expected RSA or Ed25519 PEM", path)
+}
+
+func loadPublicKey(path string) (jwt.SigningMethod, any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed read jwt verification key: %w", err)
+	}
+
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+		return jwt.SigningMethodRS256, key, nil
+	}
+
+	if key, err := jwt.ParseEdPublicKeyFromPEM(data); err == nil {
+		return jwt.SigningMethodEdDSA, key, nil
+	}
+
+	// boleh juga menunjuk ke private key lama
+	method, _, publicKey, err := loadPrivateKey(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unsupported jwt verification key in %s, expected RSA or Ed25519 PEM", path)
+	}
+
+	return method, publicKey, nil
+}
+
+// publicKeyJWK membentuk JWK publik (tanpa kid/alg/use) sesuai urutan member RFC 7638
+func publicKeyJWK(publicKey any) (map[string]string, error) {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return map[string]string{
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			"kty": "RSA",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		}, nil
+	case ed25519.PublicKey:
+		return map[string]string{
+			"crv": "Ed25519",
+			"kty": "OKP",
+			"x":   base64.RawURLEncoding.EncodeToString(key),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported jwt public key type %T", publicKey)
+	}
+}
+
+// publicKeyID memakai JWK thumbprint (RFC 7638), jadi kid tetap sama selama key-nya sama
+func publicKeyID(publicKey any) (string, error) {
+	jwk, err := publicKeyJWK(publicKey)
+	if err != nil {
+		return "", err
+	}
+
+	// json.Marshal mengurutkan key map, sesuai yang diminta RFC 7638
+	data, err := json.Marshal(jwk)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+func secretKeyID(secret string) string {
+	sum := sha256.Sum256([]byte("jwt-kid:" + secret))
+	return "hs-" + hex.EncodeToString(sum[:8])
+}
+
+func envOrDefault(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	return fallback
+}
+
+func splitEnv(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+func durationEnv(key string, fallback time.Duration) time.Duration {
+	duration, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || duration <= 0 {
+		return fallback
+	}
+
+	return duration
+}
@@ -0,0 +1,37 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Amierza/nawasena-backend/constants"
+)
+
+type (
+	IMailer interface {
+		Send(ctx context.Context, msg Message) error
+	}
+
+	Message struct {
+		To      string
+		Subject string
+		Body    string
+	}
+)
+
+// NewMailer pakai SMTP kalau SMTP_HOST diisi. Di luar production email hanya disimpan di memori,
+// di production SMTP wajib diisi supaya link reset password tidak hilang begitu saja
+func NewMailer() IMailer {
+	if os.Getenv("SMTP_HOST") == "" {
+		if os.Getenv("APP_ENV") == constants.ENUM_RUN_PRODUCTION {
+			panic(fmt.Errorf("SMTP_HOST must be set in production"))
+		}
+
+		log.Println("warning: SMTP_HOST is not set, emails are not sent")
+		return NewMemoryMailer()
+	}
+
+	return NewSMTPMailer()
+}
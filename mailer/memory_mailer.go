@@ -0,0 +1,40 @@
+package mailer
+
+import (
+	"context"
+	"log"
+	"sync"
+)
+
+// maxMemoryMessages membatasi email yang disimpan, yang paling lama dibuang lebih dulu
+const maxMemoryMessages = 50
+
+type MemoryMailer struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+// Send hanya mencatat penerima dan subject, body bisa berisi token reset password
+func (m *MemoryMailer) Send(ctx context.Context, msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = append(m.messages, msg)
+	if len(m.messages) > maxMemoryMessages {
+		m.messages = append([]Message(nil), m.messages[len(m.messages)-maxMemoryMessages:]...)
+	}
+	log.Printf("mail to %s: %s", msg.To, msg.Subject)
+
+	return nil
+}
+
+func (m *MemoryMailer) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Message(nil), m.messages...)
+}
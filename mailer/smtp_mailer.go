@@ -0,0 +1,57 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"strings"
+)
+
+type SMTPMailer struct {
+	host       string
+	port       string
+	senderName string
+	authEmail  string
+	password   string
+}
+
+func NewSMTPMailer() *SMTPMailer {
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+
+	return &SMTPMailer{
+		host:       os.Getenv("SMTP_HOST"),
+		port:       port,
+		senderName: os.Getenv("SMTP_SENDER_NAME"),
+		authEmail:  os.Getenv("SMTP_AUTH_EMAIL"),
+		password:   os.Getenv("SMTP_AUTH_PASSWORD"),
+	}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// nama pengirim & subject di-encode supaya karakter non-ASCII tidak rusak
+	from := (&mail.Address{Name: m.senderName, Address: m.authEmail}).String()
+
+	header := []string{
+		"From: " + from,
+		"To: " + msg.To,
+		"Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=\"UTF-8\"",
+	}
+	body := strings.Join(header, "\r\n") + "\r\n\r\n" + msg.Body
+
+	auth := smtp.PlainAuth("", m.authEmail, m.password, m.host)
+	addr := fmt.Sprintf("%s:%s", m.host, m.port)
+
+	return smtp.SendMail(addr, auth, m.authEmail, []string{msg.To}, []byte(body))
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 
@@ -8,10 +9,12 @@ import (
 	"github.com/Amierza/nawasena-backend/config/database"
 	"github.com/Amierza/nawasena-backend/handler"
 	"github.com/Amierza/nawasena-backend/jwt"
+	"github.com/Amierza/nawasena-backend/mailer"
 	"github.com/Amierza/nawasena-backend/middleware"
 	"github.com/Amierza/nawasena-backend/repository"
 	"github.com/Amierza/nawasena-backend/routes"
 	"github.com/Amierza/nawasena-backend/service"
+	"github.com/Amierza/nawasena-backend/storage"
 	"github.com/gin-gonic/gin"
 )
 
@@ -25,91 +28,139 @@ func main() {
 	}
 
 	var (
-		jwt = jwt.NewJWT()
+		jwt    = jwt.NewJWT()
+		mailer = mailer.NewMailer()
+		// private harus dibuat sebelum variabel storage menutupi nama package-nya
+		privateStorage = storage.NewPrivateStorage()
+		storage        = storage.NewStorage()
+
+		// JWKS
+		jwksHandler = handler.NewJWKSHandler(jwt)
+
+		// Audit Log
+		auditLogRepo    = repository.NewAuditLogRepository(db)
+		auditLogService = service.NewAuditLogService(auditLogRepo)
+		auditLogHandler = handler.NewAuditLogHandler(auditLogService)
 
 		// Auth
 		authRepo    = repository.NewAuthRepository(db)
-		authService = service.NewAuthService(authRepo, jwt)
+		authService = service.NewAuthService(authRepo, mailer, auditLogService, jwt)
 		authHandler = handler.NewAuthHandler(authService)
 
-		// Files
-		fileService = service.NewFileService()
-		fileHandler = handler.NewFileHandler(fileService)
+		// API Key
+		apiKeyRepo    = repository.NewAPIKeyRepository(db)
+		apiKeyService = service.NewAPIKeyService(apiKeyRepo, authService, auditLogService)
+		apiKeyHandler = handler.NewAPIKeyHandler(apiKeyService)
+
+		// Files & Media
+		mediaRepo           = repository.NewMediaRepository(db)
+		uploadReferenceRepo = repository.NewUploadReferenceRepository(db)
+		fileService         = service.NewFileService(storage, privateStorage, mediaRepo)
+		fileHandler         = handler.NewFileHandler(fileService)
+		mediaService        = service.NewMediaService(mediaRepo, uploadReferenceRepo, fileService, auditLogService)
+		mediaHandler        = handler.NewMediaHandler(mediaService)
+
+		// Upload Session
+		uploadSessionRepo    = repository.NewUploadSessionRepository(db)
+		uploadSessionService = service.NewUploadSessionService(uploadSessionRepo, fileService)
+		uploadSessionHandler = handler.NewUploadSessionHandler(uploadSessionService)
+
+		// Upload GC
+		uploadGCService = service.NewUploadGCService(storage, fileService, uploadReferenceRepo, mediaRepo, auditLogService)
+
+		// Slug
+		slugRepo    = repository.NewSlugRepository(db)
+		slugService = service.NewSlugService(slugRepo)
+
+		// Role
+		roleRepo    = repository.NewRoleRepository(db)
+		roleService = service.NewRoleService(roleRepo, auditLogService)
+		roleHandler = handler.NewRoleHandler(roleService)
 
 		// Admin
 		adminRepo    = repository.NewAdminRepository(db)
-		adminService = service.NewAdminService(adminRepo, jwt)
+		adminService = service.NewAdminService(adminRepo, mediaRepo, fileService, authService, auditLogService, jwt)
 		adminHandler = handler.NewAdminHandler(adminService)
 
 		// Position
 		positionRepo    = repository.NewPositionRepository(db)
-		positionService = service.NewPositionService(positionRepo, jwt)
+		positionService = service.NewPositionService(positionRepo, auditLogService, jwt)
 		positionHandler = handler.NewPositionHandler(positionService)
 
 		// Member
 		memberRepo    = repository.NewMemberRepository(db)
-		memberService = service.NewMemberService(memberRepo, fileService, jwt)
+		memberService = service.NewMemberService(memberRepo, fileService, auditLogService, jwt)
 		memberHandler = handler.NewMemberHandler(memberService)
 
 		// Achievement Category
 		achievementCategoryRepo    = repository.NewAchievementCategoryRepository(db)
-		achievementCategoryService = service.NewAchievementCategoryService(achievementCategoryRepo, jwt)
+		achievementCategoryService = service.NewAchievementCategoryService(achievementCategoryRepo, auditLogService, jwt)
 		achievementCategoryHandler = handler.NewAchievementCategoryHandler(achievementCategoryService)
 
 		// Achievement
 		achievementRepo    = repository.NewAchievementRepository(db)
-		achievementService = service.NewAchievementService(achievementRepo, jwt)
+		achievementService = service.NewAchievementService(achievementRepo, fileService, slugService, auditLogService, jwt)
 		achievementHandler = handler.NewAchievementHandler(achievementService)
 
 		// Ship
 		shipRepo    = repository.NewShipRepository(db)
-		shipService = service.NewShipService(shipRepo, jwt)
+		shipService = service.NewShipService(shipRepo, fileService, slugService, auditLogService, jwt)
 		shipHandler = handler.NewShipHandler(shipService)
 
 		// Competition
 		competitionRepo    = repository.NewCompetitionRepository(db)
-		competitionService = service.NewCompetitionService(competitionRepo, jwt)
+		competitionService = service.NewCompetitionService(competitionRepo, fileService, slugService, auditLogService, jwt)
 		competitionHandler = handler.NewCompetitionHandler(competitionService)
 
 		// News Category
 		newsCategoryRepo    = repository.NewNewsCategoryRepository(db)
-		newsCategoryService = service.NewNewsCategoryService(newsCategoryRepo, jwt)
+		newsCategoryService = service.NewNewsCategoryService(newsCategoryRepo, auditLogService, jwt)
 		newsCategoryHandler = handler.NewNewsCategoryHandler(newsCategoryService)
 
 		// News
 		newsRepo    = repository.NewNewsRepository(db)
-		newsService = service.NewNewsService(newsRepo, jwt)
+		newsService = service.NewNewsService(newsRepo, fileService, slugService, auditLogService, jwt)
 		newsHandler = handler.NewNewsHandler(newsService)
 
 		// Partner
 		partnerRepo    = repository.NewPartnerRepository(db)
-		partnerService = service.NewPartnerService(partnerRepo, jwt)
+		partnerService = service.NewPartnerService(partnerRepo, fileService, auditLogService, jwt)
 		partnerHandler = handler.NewPartnerHandler(partnerService)
 
 		// Flyer
 		flyerRepo    = repository.NewFlyerRepository(db)
-		flyerService = service.NewFlyerService(flyerRepo, jwt)
+		flyerService = service.NewFlyerService(flyerRepo, fileService, auditLogService, jwt)
 		flyerHandler = handler.NewFlyerHandler(flyerService)
 	)
 
+	// GC upload berkala, hanya aktif kalau UPLOAD_GC_INTERVAL_HOURS diisi
+	uploadGCService.Start(context.Background())
+	uploadSessionService.Start(context.Background())
+	// menayangkan berita scheduled yang publish_at-nya sudah lewat
+	newsService.Start(context.Background())
+
 	server := gin.Default()
 	server.Use(middleware.CORSMiddleware())
 
-	routes.Auth(server, authHandler, jwt)
-	routes.File(server, fileHandler, jwt)
-	routes.Admin(server, adminHandler, jwt)
-	routes.Position(server, positionHandler, jwt)
-	routes.Member(server, memberHandler, jwt)
-	routes.AchievementCategory(server, achievementCategoryHandler, jwt)
-	routes.Achievement(server, achievementHandler, jwt)
-	routes.Ship(server, shipHandler, jwt)
-	routes.Competition(server, competitionHandler, jwt)
-	routes.NewsCategory(server, newsCategoryHandler, jwt)
-	routes.News(server, newsHandler, jwt)
-	routes.Partner(server, partnerHandler, jwt)
-	routes.Flyer(server, flyerHandler, jwt)
-
-	server.Static("/uploads", "./uploads")
+	routes.JWKS(server, jwksHandler)
+	routes.Auth(server, authHandler, jwt, authService)
+	routes.File(server, fileHandler, jwt, authService)
+	routes.UploadSession(server, uploadSessionHandler, jwt, authService)
+	routes.Media(server, mediaHandler, jwt, authService)
+	routes.Admin(server, adminHandler, jwt, authService)
+	routes.Role(server, roleHandler, jwt, authService)
+	routes.AuditLog(server, auditLogHandler, jwt, authService)
+	routes.APIKey(server, apiKeyHandler, jwt, authService)
+	routes.Position(server, positionHandler, jwt, authService)
+	routes.Member(server, memberHandler, jwt, authService)
+	routes.AchievementCategory(server, achievementCategoryHandler, jwt, authService)
+	routes.Achievement(server, achievementHandler, jwt, authService)
+	routes.Ship(server, shipHandler, jwt, authService)
+	routes.Competition(server, competitionHandler, jwt, authService)
+	routes.NewsCategory(server, newsCategoryHandler, jwt, authService)
+	routes.News(server, newsHandler, jwt, authService)
+	routes.Partner(server, partnerHandler, jwt, authService)
+	routes.Flyer(server, flyerHandler, jwt, authService)
 
 	port := os.Getenv("PORT")
 	if port == "" {
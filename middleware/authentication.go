@@ -4,14 +4,33 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/dto"
 	"github.com/Amierza/nawasena-backend/jwt"
 	"github.com/Amierza/nawasena-backend/response"
+	"github.com/Amierza/nawasena-backend/service"
 	"github.com/gin-gonic/gin"
 )
 
-func Authentication(jwt jwt.IJWT) gin.HandlerFunc {
+func Authentication(jwt jwt.IJWT, authService service.IAuthService) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
+		if apiKey := ctx.GetHeader("X-API-Key"); apiKey != "" {
+			principal, err := authService.AuthenticateAPIKey(ctx, apiKey, ctx.ClientIP())
+			if err != nil {
+				res := response.BuildResponseFailed(dto.MESSAGE_FAILED_API_KEY_NOT_VALID, err.Error(), nil)
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
+				return
+			}
+
+			ctx.Set("admin_id", principal.AdminID)
+			ctx.Set("api_key_id", principal.KeyID)
+			ctx.Set("api_key_scopes", principal.Scopes)
+			ctx.Set("ip_address", ctx.ClientIP())
+			ctx.Set("user_agent", ctx.Request.UserAgent())
+			ctx.Next()
+			return
+		}
+
 		authHeader := ctx.GetHeader("Authorization")
 		if authHeader == "" {
 			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_TOKEN_NOT_FOUND, nil)
@@ -26,28 +45,31 @@ func Authentication(jwt jwt.IJWT) gin.HandlerFunc {
 		}
 
 		authHeader = strings.Replace(authHeader, "Bearer ", "", -1)
-		token, err := jwt.ValidateToken(authHeader)
+		// token cukup diverifikasi sekali, semua data diambil dari claims hasil verifikasi
+		claims, err := jwt.ValidateToken(authHeader)
 		if err != nil {
 			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_TOKEN_NOT_VALID, nil)
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
 			return
 		}
 
-		if !token.Valid {
-			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_TOKEN_DENIED_ACCESS, nil)
+		if claims.TokenType != constants.ENUM_TOKEN_ACCESS || claims.AdminID == "" || claims.SessionID == "" {
+			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_TOKEN_NOT_VALID, nil)
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
 			return
 		}
 
-		adminID, err := jwt.GetAdminIDByToken(authHeader)
-		if err != nil {
-			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, err.Error(), nil)
+		if err := authService.ValidateSession(ctx, claims.SessionID); err != nil {
+			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_SESSION_REVOKED, err.Error(), nil)
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
 			return
 		}
 
 		ctx.Set("Authorization", authHeader)
-		ctx.Set("admin_id", adminID)
+		ctx.Set("admin_id", claims.AdminID)
+		ctx.Set("session_id", claims.SessionID)
+		ctx.Set("ip_address", ctx.ClientIP())
+		ctx.Set("user_agent", ctx.Request.UserAgent())
 		ctx.Next()
 	}
 }
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/response"
+	"github.com/Amierza/nawasena-backend/service"
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission dipasang setelah Authentication, admin harus punya semua permission yang diminta
+func RequirePermission(authService service.IAuthService, permissions ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		adminID := ctx.GetString("admin_id")
+		if adminID == "" {
+			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_TOKEN_NOT_FOUND, nil)
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
+			return
+		}
+
+		granted, err := authService.GetPermissions(ctx, adminID)
+		if err != nil {
+			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, err.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, res)
+			return
+		}
+
+		// request lewat api key dibatasi lagi ke scope key tersebut
+		if scopes, ok := ctx.Get("api_key_scopes"); ok {
+			granted = slices.DeleteFunc(granted, func(permission string) bool {
+				return !slices.Contains(scopes.([]string), permission)
+			})
+		}
+
+		owned := make(map[string]bool, len(granted))
+		for _, permission := range granted {
+			owned[permission] = true
+		}
+
+		for _, permission := range permissions {
+			if !owned[permission] {
+				res := response.BuildResponseFailed(dto.MESSAGE_FAILED_ACCESS_DENIED, dto.ErrPermissionDenied.Error()+": "+permission, nil)
+				ctx.AbortWithStatusJSON(http.StatusForbidden, res)
+				return
+			}
+		}
+
+		ctx.Set("permissions", granted)
+		ctx.Next()
+	}
+}
@@ -1,65 +0,0 @@
-package middleware
-
-import (
-	"net/http"
-	"strings"
-
-	"github.com/Amierza/nawasena-backend/dto"
-	"github.com/Amierza/nawasena-backend/jwt"
-	"github.com/Amierza/nawasena-backend/response"
-	"github.com/gin-gonic/gin"
-	jwtP "github.com/golang-jwt/jwt/v5"
-)
-
-func RouteAccessControl(jwtService jwt.IJWT) gin.HandlerFunc {
-	return func(ctx *gin.Context) {
-		authHeader := ctx.GetHeader("Authorization")
-		if authHeader == "" {
-			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_TOKEN_NOT_FOUND, nil)
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
-			return
-		}
-
-		if !strings.Contains(authHeader, "Bearer") {
-			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_TOKEN_NOT_VALID, nil)
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
-			return
-		}
-
-		authHeader = strings.Replace(authHeader, "Bearer ", "", -1)
-		token, err := jwtService.ValidateToken(authHeader)
-		if err != nil {
-			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_TOKEN_NOT_VALID, nil)
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
-			return
-		}
-
-		if !token.Valid {
-			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_TOKEN_DENIED_ACCESS, nil)
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
-			return
-		}
-
-		claims, ok := token.Claims.(jwtP.MapClaims)
-		if !ok {
-			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_GET_CUSTOM_CLAIMS, nil)
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
-			return
-		}
-
-		roleName, ok := claims["role_name"]
-		if !ok {
-			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_GET_ROLE_USER, nil)
-			ctx.AbortWithStatusJSON(http.StatusForbidden, res)
-			return
-		}
-
-		if roleName != "super admin" {
-			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_ACCESS_DENIED, nil)
-			ctx.AbortWithStatusJSON(http.StatusForbidden, res)
-			return
-		}
-
-		ctx.Next()
-	}
-}
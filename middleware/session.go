@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/response"
+	"github.com/gin-gonic/gin"
+)
+
+// RequireSession dipasang setelah Authentication untuk endpoint yang hanya boleh diakses admin yang login,
+// bukan lewat api key (logout, ganti password, 2fa, kelola api key)
+func RequireSession() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.GetString("api_key_id") != "" {
+			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_ACCESS_DENIED, dto.ErrAPIKeyNotAllowed.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusForbidden, res)
+			return
+		}
+
+		ctx.Next()
+	}
+}
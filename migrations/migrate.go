@@ -7,7 +7,17 @@ import (
 
 func Migrate(db *gorm.DB) error {
 	if err := db.AutoMigrate(
+		&entity.Permission{},
+		&entity.AccessRole{},
 		&entity.Admin{},
+		&entity.RefreshToken{},
+		&entity.PasswordResetToken{},
+		&entity.TwoFactorRecoveryCode{},
+		&entity.LoginThrottle{},
+		&entity.APIKey{},
+
+		&entity.Media{},
+		&entity.UploadSession{},
 
 		&entity.AchievementCategory{},
 		&entity.Achievement{},
@@ -20,14 +30,21 @@ func Migrate(db *gorm.DB) error {
 		&entity.CompetitionImage{},
 
 		&entity.NewsCategory{},
+		&entity.NewsTag{},
 		&entity.News{},
 		&entity.NewsImage{},
+		&entity.NewsRevision{},
+		&entity.NewsRevisionImage{},
+
+		&entity.SlugRedirect{},
 
 		&entity.Partner{},
 		&entity.Flyer{},
 
 		&entity.Position{},
 		&entity.Member{},
+
+		&entity.AuditLog{},
 	); err != nil {
 		return err
 	}
@@ -7,11 +7,19 @@ import (
 
 func Rollback(db *gorm.DB) error {
 	tables := []interface{}{
+		&entity.AuditLog{},
+
 		&entity.Member{},
 		&entity.Position{},
 
+		&entity.SlugRedirect{},
+
+		&entity.NewsRevisionImage{},
+		&entity.NewsRevision{},
 		&entity.NewsImage{},
+		"news_tag_relations",
 		&entity.News{},
+		&entity.NewsTag{},
 		&entity.NewsCategory{},
 
 		&entity.Flyer{},
@@ -27,7 +35,18 @@ func Rollback(db *gorm.DB) error {
 		&entity.Achievement{},
 		&entity.AchievementCategory{},
 
+		&entity.UploadSession{},
+		&entity.Media{},
+
+		&entity.APIKey{},
+		&entity.LoginThrottle{},
+		&entity.TwoFactorRecoveryCode{},
+		&entity.PasswordResetToken{},
+		&entity.RefreshToken{},
 		&entity.Admin{},
+		"role_permissions",
+		&entity.AccessRole{},
+		&entity.Permission{},
 	}
 
 	for _, table := range tables {
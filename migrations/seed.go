@@ -6,7 +6,12 @@ import (
 )
 
 func Seed(db *gorm.DB) error {
-	err := SeedFromJSON[entity.Admin](db, "./migrations/json/admins.json", entity.Admin{}, "Email")
+	err := SeedRoles(db)
+	if err != nil {
+		return err
+	}
+
+	err = SeedFromJSON[entity.Admin](db, "./migrations/json/admins.json", entity.Admin{}, "Email")
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,154 @@
+package migrations
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Amierza/nawasena-backend/constants"
+	"github.com/Amierza/nawasena-backend/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var defaultPermissions = []entity.Permission{
+	{Name: constants.ENUM_PERMISSION_ADMINS_MANAGE, Description: "manage admins, roles, sessions and lockouts"},
+	{Name: constants.ENUM_PERMISSION_AUDIT_LOGS_READ, Description: "read audit logs"},
+	{Name: constants.ENUM_PERMISSION_UPLOADS_WRITE, Description: "upload files and edit media"},
+	{Name: constants.ENUM_PERMISSION_MEDIA_DELETE, Description: "delete media"},
+	{Name: constants.ENUM_PERMISSION_MEMBERS_WRITE, Description: "create and update members"},
+	{Name: constants.ENUM_PERMISSION_MEMBERS_DELETE, Description: "delete members"},
+	{Name: constants.ENUM_PERMISSION_POSITIONS_WRITE, Description: "create and update positions"},
+	{Name: constants.ENUM_PERMISSION_POSITIONS_DELETE, Description: "delete positions"},
+	{Name: constants.ENUM_PERMISSION_ACHIEVEMENT_CATEGORIES_WRITE, Description: "create and update achievement categories"},
+	{Name: constants.ENUM_PERMISSION_ACHIEVEMENT_CATEGORIES_DELETE, Description: "delete achievement categories"},
+	{Name: constants.ENUM_PERMISSION_ACHIEVEMENTS_WRITE, Description: "create and update achievements"},
+	{Name: constants.ENUM_PERMISSION_ACHIEVEMENTS_DELETE, Description: "delete achievements"},
+	{Name: constants.ENUM_PERMISSION_SHIPS_WRITE, Description: "create and update ships"},
+	{Name: constants.ENUM_PERMISSION_SHIPS_DELETE, Description: "delete ships"},
+	{Name: constants.ENUM_PERMISSION_COMPETITIONS_WRITE, Description: "create and update competitions"},
+	{Name: constants.ENUM_PERMISSION_COMPETITIONS_DELETE, Description: "delete competitions"},
+	{Name: constants.ENUM_PERMISSION_NEWS_CATEGORIES_WRITE, Description: "create and update news categories"},
+	{Name: constants.ENUM_PERMISSION_NEWS_CATEGORIES_DELETE, Description: "delete news categories"},
+	{Name: constants.ENUM_PERMISSION_NEWS_WRITE, Description: "create and update news"},
+	{Name: constants.ENUM_PERMISSION_NEWS_DELETE, Description: "delete news"},
+	{Name: constants.ENUM_PERMISSION_PARTNERS_WRITE, Description: "create and update partners"},
+	{Name: constants.ENUM_PERMISSION_PARTNERS_DELETE, Description: "delete partners"},
+	{Name: constants.ENUM_PERMISSION_FLYERS_WRITE, Description: "create and update flyers"},
+	{Name: constants.ENUM_PERMISSION_FLYERS_DELETE, Description: "delete flyers"},
+}
+
+// defaultRoles menyamakan perilaku lama: super admin bebas semua, admin biasa
+// tidak bisa kelola admin, member dan position. nil berarti semua permission.
+var defaultRoles = []struct {
+	Name        string
+	Description string
+	Permissions []string
+}{
+	{
+		Name:        constants.ENUM_ROLE_SUPER_ADMIN,
+		Description: "full access",
+		Permissions: nil,
+	},
+	{
+		Name:        constants.ENUM_ROLE_ADMIN,
+		Description: "manage all public content except members and positions",
+		Permissions: []string{
+			constants.ENUM_PERMISSION_UPLOADS_WRITE,
+			constants.ENUM_PERMISSION_MEDIA_DELETE,
+			constants.ENUM_PERMISSION_ACHIEVEMENT_CATEGORIES_WRITE,
+			constants.ENUM_PERMISSION_ACHIEVEMENT_CATEGORIES_DELETE,
+			constants.ENUM_PERMISSION_ACHIEVEMENTS_WRITE,
+			constants.ENUM_PERMISSION_ACHIEVEMENTS_DELETE,
+			constants.ENUM_PERMISSION_SHIPS_WRITE,
+			constants.ENUM_PERMISSION_SHIPS_DELETE,
+			constants.ENUM_PERMISSION_COMPETITIONS_WRITE,
+			constants.ENUM_PERMISSION_COMPETITIONS_DELETE,
+			constants.ENUM_PERMISSION_NEWS_CATEGORIES_WRITE,
+			constants.ENUM_PERMISSION_NEWS_CATEGORIES_DELETE,
+			constants.ENUM_PERMISSION_NEWS_WRITE,
+			constants.ENUM_PERMISSION_NEWS_DELETE,
+			constants.ENUM_PERMISSION_PARTNERS_WRITE,
+			constants.ENUM_PERMISSION_PARTNERS_DELETE,
+			constants.ENUM_PERMISSION_FLYERS_WRITE,
+			constants.ENUM_PERMISSION_FLYERS_DELETE,
+		},
+	},
+	{
+		Name:        constants.ENUM_ROLE_EDITOR,
+		Description: "manage news and flyers only",
+		Permissions: []string{
+			constants.ENUM_PERMISSION_UPLOADS_WRITE,
+			constants.ENUM_PERMISSION_NEWS_WRITE,
+			constants.ENUM_PERMISSION_NEWS_DELETE,
+			constants.ENUM_PERMISSION_FLYERS_WRITE,
+			constants.ENUM_PERMISSION_FLYERS_DELETE,
+		},
+	},
+}
+
+// SeedRoles aman dijalankan berulang: permission dan role yang belum ada dibuat,
+// role yang sudah ada tidak diubah kecuali super admin yang selalu dapat semua permission.
+func SeedRoles(db *gorm.DB) error {
+	if err := db.AutoMigrate(&entity.Permission{}, &entity.AccessRole{}); err != nil {
+		return fmt.Errorf("failed to migrate role: %w", err)
+	}
+
+	permissions := make(map[string]entity.Permission)
+	var all []entity.Permission
+	for _, data := range defaultPermissions {
+		var permission entity.Permission
+		err := db.Where("name = ?", data.Name).Take(&permission).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			permission = entity.Permission{
+				ID:          uuid.New(),
+				Name:        data.Name,
+				Description: data.Description,
+			}
+			err = db.Create(&permission).Error
+		}
+		if err != nil {
+			return fmt.Errorf("failed to seed permission %s: %w", data.Name, err)
+		}
+
+		permissions[permission.Name] = permission
+		all = append(all, permission)
+	}
+
+	for _, data := range defaultRoles {
+		rolePermissions := all
+		if data.Permissions != nil {
+			rolePermissions = nil
+			for _, name := range data.Permissions {
+				rolePermissions = append(rolePermissions, permissions[name])
+			}
+		}
+
+		var role entity.AccessRole
+		err := db.Where("name = ?", data.Name).Take(&role).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			role = entity.AccessRole{
+				ID:          uuid.New(),
+				Name:        data.Name,
+				Description: data.Description,
+				IsSystem:    data.Name != constants.ENUM_ROLE_EDITOR,
+				Permissions: rolePermissions,
+			}
+			if err := db.Create(&role).Error; err != nil {
+				return fmt.Errorf("failed to seed role %s: %w", data.Name, err)
+			}
+
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get role %s: %w", data.Name, err)
+		}
+
+		if data.Permissions == nil {
+			if err := db.Model(&role).Association("Permissions").Replace(rolePermissions); err != nil {
+				return fmt.Errorf("failed to sync role %s: %w", data.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -17,7 +17,7 @@ type (
 
 		// READ / GET
 		GetByName(ctx context.Context, tx *gorm.DB, name string) (*entity.AchievementCategory, bool, error)
-		GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.AchievementCategory, error)
+		GetAll(ctx context.Context, tx *gorm.DB, createdBy string) ([]*entity.AchievementCategory, error)
 		GetByID(ctx context.Context, tx *gorm.DB, id string) (*entity.AchievementCategory, bool, error)
 
 		// UPDATE / PATCH
@@ -71,7 +71,7 @@ func (pr *achievementCategoryRepository) GetByName(ctx context.Context, tx *gorm
 
 	return achievementCategory, true, nil
 }
-func (pr *achievementCategoryRepository) GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.AchievementCategory, error) {
+func (pr *achievementCategoryRepository) GetAll(ctx context.Context, tx *gorm.DB, createdBy string) ([]*entity.AchievementCategory, error) {
 	if tx == nil {
 		tx = pr.db
 	}
@@ -81,7 +81,7 @@ func (pr *achievementCategoryRepository) GetAll(ctx context.Context, tx *gorm.DB
 		err                  error
 	)
 
-	query := tx.WithContext(ctx).Model(&entity.AchievementCategory{})
+	query := tx.WithContext(ctx).Model(&entity.AchievementCategory{}).Scopes(PreloadAuthors, FilterCreatedBy(createdBy))
 	if err := query.Order(`"created_at" DESC`).Find(&achievementCategorys).Error; err != nil {
 		return []*entity.AchievementCategory{}, err
 	}
@@ -94,7 +94,7 @@ func (pr *achievementCategoryRepository) GetByID(ctx context.Context, tx *gorm.D
 	}
 
 	var achievementCategory *entity.AchievementCategory
-	err := tx.WithContext(ctx).Where("id = ?", id).Take(&achievementCategory).Error
+	err := tx.WithContext(ctx).Scopes(PreloadAuthors).Where("id = ?", id).Take(&achievementCategory).Error
 	if err != nil {
 		return &entity.AchievementCategory{}, false, err
 	}
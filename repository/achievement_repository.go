@@ -22,7 +22,7 @@ type (
 
 		// READ / GET
 		GetByNameAndYear(ctx context.Context, tx *gorm.DB, name string, year int) (*entity.Achievement, bool, error)
-		GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.Achievement, error)
+		GetAll(ctx context.Context, tx *gorm.DB, createdBy string) ([]*entity.Achievement, error)
 		GetAllWithPagination(ctx context.Context, tx *gorm.DB, req response.PaginationRequest) (dto.AchievementPaginationRepositoryResponse, error)
 		GetByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Achievement, bool, error)
 		GetCategoryByCategoryID(ctx context.Context, tx *gorm.DB, categoryID string) (*entity.AchievementCategory, bool, error)
@@ -31,10 +31,12 @@ type (
 
 		// UPDATE / PATCH
 		Update(ctx context.Context, tx *gorm.DB, achievement *entity.Achievement) error
+		UpdateImage(ctx context.Context, tx *gorm.DB, image *entity.AchievementImage) error
 
 		// DELETE / DELETE
 		DeleteByID(ctx context.Context, tx *gorm.DB, id string) error
 		DeleteImagesByID(ctx context.Context, tx *gorm.DB, id string) error
+		DeleteImageByID(ctx context.Context, tx *gorm.DB, achievementID, imageID string) error
 	}
 
 	achievementRepository struct {
@@ -88,7 +90,7 @@ func (pr *achievementRepository) GetByNameAndYear(ctx context.Context, tx *gorm.
 
 	return achievement, true, nil
 }
-func (pr *achievementRepository) GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.Achievement, error) {
+func (pr *achievementRepository) GetAll(ctx context.Context, tx *gorm.DB, createdBy string) ([]*entity.Achievement, error) {
 	if tx == nil {
 		tx = pr.db
 	}
@@ -98,7 +100,7 @@ func (pr *achievementRepository) GetAll(ctx context.Context, tx *gorm.DB) ([]*en
 		err          error
 	)
 
-	query := tx.WithContext(ctx).Model(&entity.Achievement{}).Preload("Images").Preload("AchievementCategory")
+	query := tx.WithContext(ctx).Model(&entity.Achievement{}).Preload("Images", OrderGallery).Preload("AchievementCategory").Scopes(PreloadAuthors, FilterCreatedBy(createdBy))
 	if err := query.Order(`"created_at" DESC`).Find(&achievements).Error; err != nil {
 		return []*entity.Achievement{}, err
 	}
@@ -122,7 +124,7 @@ func (pr *achievementRepository) GetAllWithPagination(ctx context.Context, tx *g
 		req.Page = 1
 	}
 
-	query := tx.WithContext(ctx).Model(&entity.Achievement{}).Preload("Images").Preload("AchievementCategory")
+	query := tx.WithContext(ctx).Model(&entity.Achievement{}).Preload("Images", OrderGallery).Preload("AchievementCategory").Scopes(PreloadAuthors, FilterCreatedBy(req.CreatedBy))
 
 	if req.Search != "" {
 		searchValue := "%" + strings.ToLower(req.Search) + "%"
@@ -155,7 +157,7 @@ func (pr *achievementRepository) GetByID(ctx context.Context, tx *gorm.DB, id st
 	}
 
 	var achievement *entity.Achievement
-	err := tx.WithContext(ctx).Preload("Images").Preload("AchievementCategory").Where("id = ?", id).Take(&achievement).Error
+	err := tx.WithContext(ctx).Scopes(PreloadAuthors).Preload("Images", OrderGallery).Preload("AchievementCategory").Where("id = ?", id).Take(&achievement).Error
 	if err != nil {
 		return &entity.Achievement{}, false, err
 	}
@@ -188,7 +190,8 @@ func (ar *achievementRepository) GetFeatured(ctx context.Context, tx *gorm.DB, l
 
 	var achievement []*entity.Achievement
 	query := tx.WithContext(ctx).Model(&entity.Achievement{}).
-		Preload("Images").
+		Preload("Images", OrderGallery).
+		Scopes(PreloadAuthors).
 		Preload("AchievementCategory").
 		Where("featured = ?", true).
 		Order("created_at DESC")
@@ -207,7 +210,8 @@ func (ar *achievementRepository) GetFeatured(ctx context.Context, tx *gorm.DB, l
 
 		var fallback []*entity.Achievement
 		err := tx.WithContext(ctx).Model(&entity.Achievement{}).
-			Preload("Images").
+			Preload("Images", OrderGallery).
+			Scopes(PreloadAuthors).
 			Preload("AchievementCategory").
 			Where("featured = ?", false). // jangan ambil yang udah featured
 			Order("created_at DESC").
@@ -230,7 +234,8 @@ func (ar *achievementRepository) GetImagesByID(ctx context.Context, tx *gorm.DB,
 	query := tx.WithContext(ctx).
 		Model(&entity.AchievementImage{}).
 		Preload("Achievement").
-		Where("achievement_id = ?", id)
+		Where("achievement_id = ?", id).
+		Scopes(OrderGallery)
 
 	var achievementImages []*entity.AchievementImage
 	if err := query.Find(&achievementImages).Error; err != nil {
@@ -248,6 +253,16 @@ func (ar *achievementRepository) Update(ctx context.Context, tx *gorm.DB, achiev
 
 	return tx.WithContext(ctx).Model(&entity.Achievement{}).Where("id = ?", achievement.ID).Updates(achievement).Error
 }
+func (ar *achievementRepository) UpdateImage(ctx context.Context, tx *gorm.DB, image *entity.AchievementImage) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.AchievementImage{}).
+		Where("id = ?", image.ID).
+		Select("Position", "Caption", "Alt", "IsCover").
+		Updates(image).Error
+}
 
 // DELETE / DELETE
 func (ar *achievementRepository) DeleteByID(ctx context.Context, tx *gorm.DB, id string) error {
@@ -264,3 +279,10 @@ func (ar *achievementRepository) DeleteImagesByID(ctx context.Context, tx *gorm.
 
 	return tx.WithContext(ctx).Where("achievement_id = ?", id).Delete(&entity.AchievementImage{}).Error
 }
+func (ar *achievementRepository) DeleteImageByID(ctx context.Context, tx *gorm.DB, achievementID, imageID string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Where("id = ? AND achievement_id = ?", imageID, achievementID).Delete(&entity.AchievementImage{}).Error
+}
@@ -10,10 +10,13 @@ import (
 	"github.com/Amierza/nawasena-backend/entity"
 	"github.com/Amierza/nawasena-backend/response"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type (
 	IAdminRepository interface {
+		RunInTransaction(ctx context.Context, fn func(txRepo IAdminRepository) error) error
+
 		// CREATE / POST
 		Create(ctx context.Context, tx *gorm.DB, admin *entity.Admin) error
 
@@ -22,6 +25,7 @@ type (
 		GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.Admin, error)
 		GetAllWithPagination(ctx context.Context, tx *gorm.DB, req response.PaginationRequest) (dto.AdminPaginationRepositoryResponse, error)
 		GetByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Admin, bool, error)
+		LockAdminIDsByRoleName(ctx context.Context, tx *gorm.DB, name string) ([]string, error)
 
 		// UPDATE / PATCH
 		Update(ctx context.Context, tx *gorm.DB, admin *entity.Admin) error
@@ -41,6 +45,13 @@ func NewAdminRepository(db *gorm.DB) *adminRepository {
 	}
 }
 
+func (ar *adminRepository) RunInTransaction(ctx context.Context, fn func(txRepo IAdminRepository) error) error {
+	return ar.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := &adminRepository{db: tx}
+		return fn(txRepo)
+	})
+}
+
 // CREATE / POST
 func (ar *adminRepository) Create(ctx context.Context, tx *gorm.DB, admin *entity.Admin) error {
 	if tx == nil {
@@ -144,6 +155,13 @@ func (ar *adminRepository) GetByID(ctx context.Context, tx *gorm.DB, id string)
 
 	return admin, true, nil
 }
+func (ar *adminRepository) LockAdminIDsByRoleName(ctx context.Context, tx *gorm.DB, name string) ([]string, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return lockAdminIDsByRoleName(ctx, tx, name)
+}
 
 // UPDATE / PATCH
 func (ar *adminRepository) Update(ctx context.Context, tx *gorm.DB, admin *entity.Admin) error {
@@ -162,3 +180,15 @@ func (ar *adminRepository) DeleteByID(ctx context.Context, tx *gorm.DB, id strin
 
 	return tx.WithContext(ctx).Where("id = ?", id).Delete(&entity.Admin{}).Error
 }
+
+// lockAdminIDsByRoleName dipanggil di dalam transaksi, row dikunci supaya dua request
+// (assign role / hapus admin) tidak lolos pengecekan super admin terakhir bersamaan
+func lockAdminIDsByRoleName(ctx context.Context, tx *gorm.DB, name string) ([]string, error) {
+	var ids []string
+	err := tx.WithContext(ctx).Model(&entity.Admin{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("role = ?", name).
+		Pluck("id", &ids).Error
+
+	return ids, err
+}
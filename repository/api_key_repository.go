@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Amierza/nawasena-backend/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	IAPIKeyRepository interface {
+		RunInTransaction(ctx context.Context, fn func(txRepo IAPIKeyRepository) error) error
+
+		// CREATE / POST
+		Create(ctx context.Context, tx *gorm.DB, apiKey *entity.APIKey) error
+
+		// READ / GET
+		GetAllByAdminID(ctx context.Context, tx *gorm.DB, adminID string) ([]*entity.APIKey, error)
+		GetByID(ctx context.Context, tx *gorm.DB, id string) (*entity.APIKey, bool, error)
+
+		// UPDATE / PATCH
+		RevokeByID(ctx context.Context, tx *gorm.DB, id string) (bool, error)
+	}
+
+	apiKeyRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewAPIKeyRepository(db *gorm.DB) *apiKeyRepository {
+	return &apiKeyRepository{
+		db: db,
+	}
+}
+
+func (akr *apiKeyRepository) RunInTransaction(ctx context.Context, fn func(txRepo IAPIKeyRepository) error) error {
+	return akr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := &apiKeyRepository{db: tx}
+		return fn(txRepo)
+	})
+}
+
+// CREATE / POST
+func (akr *apiKeyRepository) Create(ctx context.Context, tx *gorm.DB, apiKey *entity.APIKey) error {
+	if tx == nil {
+		tx = akr.db
+	}
+
+	return tx.WithContext(ctx).Create(&apiKey).Error
+}
+
+// READ / GET
+func (akr *apiKeyRepository) GetAllByAdminID(ctx context.Context, tx *gorm.DB, adminID string) ([]*entity.APIKey, error) {
+	if tx == nil {
+		tx = akr.db
+	}
+
+	var apiKeys []*entity.APIKey
+	if err := tx.WithContext(ctx).Where("admin_id = ?", adminID).Order(`"created_at" DESC`).Find(&apiKeys).Error; err != nil {
+		return []*entity.APIKey{}, err
+	}
+
+	return apiKeys, nil
+}
+func (akr *apiKeyRepository) GetByID(ctx context.Context, tx *gorm.DB, id string) (*entity.APIKey, bool, error) {
+	if tx == nil {
+		tx = akr.db
+	}
+
+	var apiKey *entity.APIKey
+	err := tx.WithContext(ctx).Where("id = ?", id).Take(&apiKey).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.APIKey{}, false, nil
+	}
+	if err != nil {
+		return &entity.APIKey{}, false, err
+	}
+
+	return apiKey, true, nil
+}
+
+// UPDATE / PATCH
+func (akr *apiKeyRepository) RevokeByID(ctx context.Context, tx *gorm.DB, id string) (bool, error) {
+	if tx == nil {
+		tx = akr.db
+	}
+
+	result := tx.WithContext(ctx).
+		Model(&entity.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/entity"
+	"github.com/Amierza/nawasena-backend/response"
+	"gorm.io/gorm"
+)
+
+type (
+	IAuditLogRepository interface {
+		// CREATE / POST
+		Create(ctx context.Context, tx *gorm.DB, auditLog *entity.AuditLog) error
+
+		// READ / GET
+		GetAllWithPagination(ctx context.Context, tx *gorm.DB, req response.PaginationRequest, filter dto.AuditLogFilter) (dto.AuditLogPaginationRepositoryResponse, error)
+	}
+
+	auditLogRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewAuditLogRepository(db *gorm.DB) *auditLogRepository {
+	return &auditLogRepository{
+		db: db,
+	}
+}
+
+// CREATE / POST
+func (alr *auditLogRepository) Create(ctx context.Context, tx *gorm.DB, auditLog *entity.AuditLog) error {
+	if tx == nil {
+		tx = alr.db
+	}
+
+	return tx.WithContext(ctx).Create(&auditLog).Error
+}
+
+// READ / GET
+func (alr *auditLogRepository) GetAllWithPagination(ctx context.Context, tx *gorm.DB, req response.PaginationRequest, filter dto.AuditLogFilter) (dto.AuditLogPaginationRepositoryResponse, error) {
+	if tx == nil {
+		tx = alr.db
+	}
+
+	var auditLogs []entity.AuditLog
+	var err error
+	var count int64
+
+	if req.PerPage == 0 {
+		req.PerPage = 10
+	}
+
+	if req.Page == 0 {
+		req.Page = 1
+	}
+
+	query := tx.WithContext(ctx).Model(&entity.AuditLog{})
+
+	if req.Search != "" {
+		searchValue := "%" + strings.ToLower(req.Search) + "%"
+		query = query.Where("LOWER(entity_type) LIKE ? OR LOWER(action) LIKE ? OR LOWER(entity_id) LIKE ?", searchValue, searchValue, searchValue)
+	}
+	if filter.ActorID != "" {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return dto.AuditLogPaginationRepositoryResponse{}, err
+	}
+
+	// admin yang sudah dihapus tetap ditampilkan namanya
+	query = query.Preload("Actor", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	})
+
+	if err := query.Order(`"created_at" DESC`).Scopes(Paginate(req.Page, req.PerPage)).Find(&auditLogs).Error; err != nil {
+		return dto.AuditLogPaginationRepositoryResponse{}, err
+	}
+
+	totalPage := int64(math.Ceil(float64(count) / float64(req.PerPage)))
+
+	return dto.AuditLogPaginationRepositoryResponse{
+		AuditLogs: auditLogs,
+		PaginationResponse: response.PaginationResponse{
+			Page:    req.Page,
+			PerPage: req.PerPage,
+			MaxPage: totalPage,
+			Count:   count,
+		},
+	}, err
+}
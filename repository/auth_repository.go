@@ -3,14 +3,55 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/entity"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type (
 	IAuthRepository interface {
+		RunInTransaction(ctx context.Context, fn func(txRepo IAuthRepository) error) error
+
+		// CREATE / POST
+		CreateRefreshToken(ctx context.Context, tx *gorm.DB, refreshToken *entity.RefreshToken) error
+		CreatePasswordResetToken(ctx context.Context, tx *gorm.DB, resetToken *entity.PasswordResetToken) error
+		CreateRecoveryCode(ctx context.Context, tx *gorm.DB, recoveryCode *entity.TwoFactorRecoveryCode) error
+
+		// READ / GET
 		GetAdminByEmail(ctx context.Context, tx *gorm.DB, email string) (*entity.Admin, bool, error)
+		GetAdminByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Admin, bool, error)
+		GetPasswordResetTokenByHash(ctx context.Context, tx *gorm.DB, tokenHash string) (*entity.PasswordResetToken, bool, error)
+		GetRefreshTokenByHash(ctx context.Context, tx *gorm.DB, tokenHash string) (*entity.RefreshToken, bool, error)
+		IsSessionActive(ctx context.Context, tx *gorm.DB, sessionID string) (bool, error)
+		GetLoginThrottleByKey(ctx context.Context, tx *gorm.DB, key string) (*entity.LoginThrottle, bool, error)
+		GetAllLoginThrottles(ctx context.Context, tx *gorm.DB) ([]*entity.LoginThrottle, error)
+		GetUnusedRecoveryCodeByHash(ctx context.Context, tx *gorm.DB, adminID string, codeHash string) (*entity.TwoFactorRecoveryCode, bool, error)
+		GetPermissionNamesByAdminID(ctx context.Context, tx *gorm.DB, adminID string) ([]string, error)
+		GetAPIKeyByHash(ctx context.Context, tx *gorm.DB, keyHash string) (*entity.APIKey, bool, error)
+
+		// UPDATE / PATCH
+		RevokeRefreshTokenByID(ctx context.Context, tx *gorm.DB, id string) (bool, error)
+		RevokeRefreshTokensBySessionID(ctx context.Context, tx *gorm.DB, sessionID string) error
+		RevokeRefreshTokensByAdminID(ctx context.Context, tx *gorm.DB, adminID string) error
+		UpdateAdminPassword(ctx context.Context, tx *gorm.DB, adminID string, hashedPassword string) error
+		UsePasswordResetTokenByID(ctx context.Context, tx *gorm.DB, id string) (bool, error)
+		UsePasswordResetTokensByAdminID(ctx context.Context, tx *gorm.DB, adminID string) error
+		UpdateAdminTwoFactor(ctx context.Context, tx *gorm.DB, adminID string, secret string, enabled bool) error
+		UpdateAdminLastLogin(ctx context.Context, tx *gorm.DB, adminID string, ip string) error
+		UpdateAdminTwoFactorLastStep(ctx context.Context, tx *gorm.DB, adminID string, step int64) (bool, error)
+		UseRecoveryCodeByID(ctx context.Context, tx *gorm.DB, id string) (bool, error)
+		IncrementLoginThrottle(ctx context.Context, tx *gorm.DB, key string) (*entity.LoginThrottle, error)
+		UpdateLoginThrottleLockedUntil(ctx context.Context, tx *gorm.DB, id string, lockedUntil time.Time) error
+		ResetLoginThrottleByKey(ctx context.Context, tx *gorm.DB, key string) error
+		ResetLoginThrottleByID(ctx context.Context, tx *gorm.DB, id string) (bool, error)
+		UpdateAPIKeyLastUsed(ctx context.Context, tx *gorm.DB, id string, ip string) error
+
+		// DELETE / DELETE
+		DeleteRecoveryCodesByAdminID(ctx context.Context, tx *gorm.DB, adminID string) error
 	}
 
 	authRepository struct {
@@ -24,6 +65,37 @@ func NewAuthRepository(db *gorm.DB) *authRepository {
 	}
 }
 
+func (ar *authRepository) RunInTransaction(ctx context.Context, fn func(txRepo IAuthRepository) error) error {
+	return ar.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := &authRepository{db: tx}
+		return fn(txRepo)
+	})
+}
+
+// CREATE / POST
+func (ar *authRepository) CreateRefreshToken(ctx context.Context, tx *gorm.DB, refreshToken *entity.RefreshToken) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Create(&refreshToken).Error
+}
+func (ar *authRepository) CreatePasswordResetToken(ctx context.Context, tx *gorm.DB, resetToken *entity.PasswordResetToken) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Create(&resetToken).Error
+}
+func (ar *authRepository) CreateRecoveryCode(ctx context.Context, tx *gorm.DB, recoveryCode *entity.TwoFactorRecoveryCode) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Create(&recoveryCode).Error
+}
+
+// READ / GET
 func (ar *authRepository) GetAdminByEmail(ctx context.Context, tx *gorm.DB, email string) (*entity.Admin, bool, error) {
 	if tx == nil {
 		tx = ar.db
@@ -40,3 +112,366 @@ func (ar *authRepository) GetAdminByEmail(ctx context.Context, tx *gorm.DB, emai
 
 	return admin, true, nil
 }
+func (ar *authRepository) GetAdminByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Admin, bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var admin *entity.Admin
+	err := tx.WithContext(ctx).Where("id = ?", id).Take(&admin).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.Admin{}, false, nil
+	}
+	if err != nil {
+		return &entity.Admin{}, false, err
+	}
+
+	return admin, true, nil
+}
+func (ar *authRepository) GetRefreshTokenByHash(ctx context.Context, tx *gorm.DB, tokenHash string) (*entity.RefreshToken, bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var refreshToken *entity.RefreshToken
+	err := tx.WithContext(ctx).Preload("Admin").Where("token_hash = ?", tokenHash).Take(&refreshToken).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.RefreshToken{}, false, nil
+	}
+	if err != nil {
+		return &entity.RefreshToken{}, false, err
+	}
+
+	return refreshToken, true, nil
+}
+func (ar *authRepository) GetPasswordResetTokenByHash(ctx context.Context, tx *gorm.DB, tokenHash string) (*entity.PasswordResetToken, bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var resetToken *entity.PasswordResetToken
+	err := tx.WithContext(ctx).Where("token_hash = ?", tokenHash).Take(&resetToken).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.PasswordResetToken{}, false, nil
+	}
+	if err != nil {
+		return &entity.PasswordResetToken{}, false, err
+	}
+
+	return resetToken, true, nil
+}
+func (ar *authRepository) IsSessionActive(ctx context.Context, tx *gorm.DB, sessionID string) (bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var count int64
+	err := tx.WithContext(ctx).
+		Model(&entity.RefreshToken{}).
+		Where("session_id = ? AND revoked_at IS NULL AND expires_at > ?", sessionID, time.Now()).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+func (ar *authRepository) GetLoginThrottleByKey(ctx context.Context, tx *gorm.DB, key string) (*entity.LoginThrottle, bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var throttle *entity.LoginThrottle
+	err := tx.WithContext(ctx).Where("key = ?", key).Take(&throttle).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.LoginThrottle{}, false, nil
+	}
+	if err != nil {
+		return &entity.LoginThrottle{}, false, err
+	}
+
+	return throttle, true, nil
+}
+func (ar *authRepository) GetAllLoginThrottles(ctx context.Context, tx *gorm.DB) ([]*entity.LoginThrottle, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var throttles []*entity.LoginThrottle
+	query := tx.WithContext(ctx).Model(&entity.LoginThrottle{}).Where("failed_count > 0")
+	if err := query.Order(`"last_failed_at" DESC`).Find(&throttles).Error; err != nil {
+		return []*entity.LoginThrottle{}, err
+	}
+
+	return throttles, nil
+}
+func (ar *authRepository) GetUnusedRecoveryCodeByHash(ctx context.Context, tx *gorm.DB, adminID string, codeHash string) (*entity.TwoFactorRecoveryCode, bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var recoveryCode *entity.TwoFactorRecoveryCode
+	err := tx.WithContext(ctx).Where("admin_id = ? AND code_hash = ? AND used_at IS NULL", adminID, codeHash).Take(&recoveryCode).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.TwoFactorRecoveryCode{}, false, nil
+	}
+	if err != nil {
+		return &entity.TwoFactorRecoveryCode{}, false, err
+	}
+
+	return recoveryCode, true, nil
+}
+func (ar *authRepository) GetPermissionNamesByAdminID(ctx context.Context, tx *gorm.DB, adminID string) ([]string, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var names []string
+	err := tx.WithContext(ctx).Model(&entity.Permission{}).
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Joins("JOIN access_roles ON access_roles.id = role_permissions.access_role_id AND access_roles.deleted_at IS NULL").
+		Joins("JOIN admins ON admins.role = access_roles.name AND admins.deleted_at IS NULL").
+		Where("admins.id = ?", adminID).
+		Order("permissions.name").
+		Pluck("permissions.name", &names).Error
+	if err != nil {
+		return []string{}, err
+	}
+
+	return names, nil
+}
+func (ar *authRepository) GetAPIKeyByHash(ctx context.Context, tx *gorm.DB, keyHash string) (*entity.APIKey, bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var apiKey *entity.APIKey
+	err := tx.WithContext(ctx).Preload("Admin").Where("key_hash = ?", keyHash).Take(&apiKey).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.APIKey{}, false, nil
+	}
+	if err != nil {
+		return &entity.APIKey{}, false, err
+	}
+
+	return apiKey, true, nil
+}
+
+// UPDATE / PATCH
+func (ar *authRepository) RevokeRefreshTokenByID(ctx context.Context, tx *gorm.DB, id string) (bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	// hanya token yang belum di-revoke, biar dua request refresh barengan ga sama-sama lolos
+	result := tx.WithContext(ctx).
+		Model(&entity.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+func (ar *authRepository) RevokeRefreshTokensBySessionID(ctx context.Context, tx *gorm.DB, sessionID string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).
+		Model(&entity.RefreshToken{}).
+		Where("session_id = ? AND revoked_at IS NULL", sessionID).
+		Update("revoked_at", time.Now()).Error
+}
+func (ar *authRepository) RevokeRefreshTokensByAdminID(ctx context.Context, tx *gorm.DB, adminID string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).
+		Model(&entity.RefreshToken{}).
+		Where("admin_id = ? AND revoked_at IS NULL", adminID).
+		Update("revoked_at", time.Now()).Error
+}
+func (ar *authRepository) UpdateAdminPassword(ctx context.Context, tx *gorm.DB, adminID string, hashedPassword string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).
+		Model(&entity.Admin{}).
+		Where("id = ?", adminID).
+		Update("password", hashedPassword).Error
+}
+func (ar *authRepository) UsePasswordResetTokenByID(ctx context.Context, tx *gorm.DB, id string) (bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	result := tx.WithContext(ctx).
+		Model(&entity.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+func (ar *authRepository) UsePasswordResetTokensByAdminID(ctx context.Context, tx *gorm.DB, adminID string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).
+		Model(&entity.PasswordResetToken{}).
+		Where("admin_id = ? AND used_at IS NULL", adminID).
+		Update("used_at", time.Now()).Error
+}
+func (ar *authRepository) UpdateAdminTwoFactor(ctx context.Context, tx *gorm.DB, adminID string, secret string, enabled bool) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).
+		Model(&entity.Admin{}).
+		Where("id = ?", adminID).
+		Updates(map[string]any{
+			"two_factor_secret":    secret,
+			"two_factor_enabled":   enabled,
+			"two_factor_last_step": 0,
+		}).Error
+}
+func (ar *authRepository) UpdateAdminLastLogin(ctx context.Context, tx *gorm.DB, adminID string, ip string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).
+		Model(&entity.Admin{}).
+		Where("id = ?", adminID).
+		UpdateColumns(map[string]any{"last_login_at": time.Now(), "last_login_ip": ip}).Error
+}
+func (ar *authRepository) UpdateAdminTwoFactorLastStep(ctx context.Context, tx *gorm.DB, adminID string, step int64) (bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	// step harus naik, kalau ga berarti kodenya sudah pernah dipakai
+	result := tx.WithContext(ctx).
+		Model(&entity.Admin{}).
+		Where("id = ? AND two_factor_last_step < ?", adminID, step).
+		Update("two_factor_last_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+func (ar *authRepository) UseRecoveryCodeByID(ctx context.Context, tx *gorm.DB, id string) (bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	result := tx.WithContext(ctx).
+		Model(&entity.TwoFactorRecoveryCode{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+func (ar *authRepository) IncrementLoginThrottle(ctx context.Context, tx *gorm.DB, key string) (*entity.LoginThrottle, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	now := time.Now()
+	throttle := &entity.LoginThrottle{
+		ID:           uuid.New(),
+		Key:          key,
+		FailedCount:  1,
+		LastFailedAt: &now,
+	}
+
+	// upsert atomik biar percobaan paralel tetap kehitung semua
+	err := tx.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "key"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"failed_count":   gorm.Expr("login_throttles.failed_count + 1"),
+			"last_failed_at": now,
+			"updated_at":     now,
+		}),
+	}).Create(throttle).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var result *entity.LoginThrottle
+	if err := tx.WithContext(ctx).Where("key = ?", key).Take(&result).Error; err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+func (ar *authRepository) UpdateLoginThrottleLockedUntil(ctx context.Context, tx *gorm.DB, id string, lockedUntil time.Time) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).
+		Model(&entity.LoginThrottle{}).
+		Where("id = ?", id).
+		Update("locked_until", lockedUntil).Error
+}
+func (ar *authRepository) ResetLoginThrottleByKey(ctx context.Context, tx *gorm.DB, key string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).
+		Model(&entity.LoginThrottle{}).
+		Where("key = ?", key).
+		Updates(map[string]any{"failed_count": 0, "locked_until": nil}).Error
+}
+func (ar *authRepository) ResetLoginThrottleByID(ctx context.Context, tx *gorm.DB, id string) (bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	result := tx.WithContext(ctx).
+		Model(&entity.LoginThrottle{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"failed_count": 0, "locked_until": nil})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+func (ar *authRepository) UpdateAPIKeyLastUsed(ctx context.Context, tx *gorm.DB, id string, ip string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	// cukup dicatat sekali per interval, biar tiap request pakai api key ga selalu nulis ke db
+	now := time.Now()
+	threshold := now.Add(-constants.ENUM_API_KEY_LAST_USED_INTERVAL_SECONDS * time.Second)
+
+	return tx.WithContext(ctx).
+		Model(&entity.APIKey{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, threshold).
+		UpdateColumns(map[string]any{"last_used_at": now, "last_used_ip": ip}).Error
+}
+
+// DELETE / DELETE
+func (ar *authRepository) DeleteRecoveryCodesByAdminID(ctx context.Context, tx *gorm.DB, adminID string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Where("admin_id = ?", adminID).Delete(&entity.TwoFactorRecoveryCode{}).Error
+}
@@ -1,6 +1,11 @@
 package repository
 
-import "gorm.io/gorm"
+import (
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
 
 func Paginate(page, perPage int) func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
@@ -8,3 +13,36 @@ func Paginate(page, perPage int) func(db *gorm.DB) *gorm.DB {
 		return db.Offset(offset).Limit(perPage)
 	}
 }
+
+// PreloadAuthors memuat admin pembuat & pengubah terakhir, termasuk admin yang sudah dihapus
+func PreloadAuthors(db *gorm.DB) *gorm.DB {
+	unscoped := func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}
+
+	return db.Preload("CreatedBy", unscoped).Preload("UpdatedBy", unscoped)
+}
+
+// FilterCreatedBy membatasi hasil ke data yang dibuat oleh admin tertentu (?created_by=)
+func FilterCreatedBy(createdBy string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if createdBy == "" {
+			return db
+		}
+
+		if _, err := uuid.Parse(createdBy); err != nil {
+			db.AddError(dto.ErrParseUUID)
+			return db
+		}
+
+		return db.Where(clause.Eq{
+			Column: clause.Column{Table: clause.CurrentTable, Name: "created_by_id"},
+			Value:  createdBy,
+		})
+	}
+}
+
+// OrderGallery mengurutkan gambar galeri sesuai posisi, created_at untuk data lama yang posisinya sama
+func OrderGallery(db *gorm.DB) *gorm.DB {
+	return db.Order("position ASC").Order(`"created_at" ASC`)
+}
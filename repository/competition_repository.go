@@ -23,17 +23,19 @@ type (
 
 		// READ / GET
 		GetByNameAndDate(ctx context.Context, tx *gorm.DB, name string, date time.Time) (*entity.Competition, bool, error)
-		GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.Competition, error)
+		GetAll(ctx context.Context, tx *gorm.DB, createdBy string) ([]*entity.Competition, error)
 		GetAllWithPagination(ctx context.Context, tx *gorm.DB, req response.PaginationRequest) (dto.CompetitionPaginationRepositoryResponse, error)
 		GetByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Competition, bool, error)
 		GetImagesByID(ctx context.Context, tx *gorm.DB, id string) ([]*entity.CompetitionImage, error)
 
 		// UPDATE / PATCH
 		Update(ctx context.Context, tx *gorm.DB, competition *entity.Competition) error
+		UpdateImage(ctx context.Context, tx *gorm.DB, image *entity.CompetitionImage) error
 
 		// DELETE / DELETE
 		DeleteByID(ctx context.Context, tx *gorm.DB, id string) error
 		DeleteImagesByID(ctx context.Context, tx *gorm.DB, id string) error
+		DeleteImageByID(ctx context.Context, tx *gorm.DB, competitionID, imageID string) error
 	}
 
 	competitionRepository struct {
@@ -87,7 +89,7 @@ func (pr *competitionRepository) GetByNameAndDate(ctx context.Context, tx *gorm.
 
 	return competition, true, nil
 }
-func (pr *competitionRepository) GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.Competition, error) {
+func (pr *competitionRepository) GetAll(ctx context.Context, tx *gorm.DB, createdBy string) ([]*entity.Competition, error) {
 	if tx == nil {
 		tx = pr.db
 	}
@@ -97,7 +99,7 @@ func (pr *competitionRepository) GetAll(ctx context.Context, tx *gorm.DB) ([]*en
 		err          error
 	)
 
-	query := tx.WithContext(ctx).Model(&entity.Competition{}).Preload("Images")
+	query := tx.WithContext(ctx).Model(&entity.Competition{}).Preload("Images", OrderGallery).Scopes(PreloadAuthors, FilterCreatedBy(createdBy))
 	if err := query.Order(`"created_at" DESC`).Find(&competitions).Error; err != nil {
 		return []*entity.Competition{}, err
 	}
@@ -121,7 +123,7 @@ func (pr *competitionRepository) GetAllWithPagination(ctx context.Context, tx *g
 		req.Page = 1
 	}
 
-	query := tx.WithContext(ctx).Model(&entity.Competition{}).Preload("Images")
+	query := tx.WithContext(ctx).Model(&entity.Competition{}).Preload("Images", OrderGallery).Scopes(PreloadAuthors, FilterCreatedBy(req.CreatedBy))
 
 	if req.Search != "" {
 		searchValue := "%" + strings.ToLower(req.Search) + "%"
@@ -154,7 +156,7 @@ func (pr *competitionRepository) GetByID(ctx context.Context, tx *gorm.DB, id st
 	}
 
 	var competition *entity.Competition
-	err := tx.WithContext(ctx).Preload("Images").Where("id = ?", id).Take(&competition).Error
+	err := tx.WithContext(ctx).Scopes(PreloadAuthors).Preload("Images", OrderGallery).Where("id = ?", id).Take(&competition).Error
 	if err != nil {
 		return &entity.Competition{}, false, err
 	}
@@ -172,7 +174,8 @@ func (ar *competitionRepository) GetImagesByID(ctx context.Context, tx *gorm.DB,
 	query := tx.WithContext(ctx).
 		Model(&entity.CompetitionImage{}).
 		Preload("Competition").
-		Where("competition_id = ?", id)
+		Where("competition_id = ?", id).
+		Scopes(OrderGallery)
 
 	var competitionImages []*entity.CompetitionImage
 	if err := query.Find(&competitionImages).Error; err != nil {
@@ -190,6 +193,16 @@ func (pr *competitionRepository) Update(ctx context.Context, tx *gorm.DB, compet
 
 	return tx.WithContext(ctx).Where("id = ?", competition.ID).Updates(&competition).Error
 }
+func (ar *competitionRepository) UpdateImage(ctx context.Context, tx *gorm.DB, image *entity.CompetitionImage) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.CompetitionImage{}).
+		Where("id = ?", image.ID).
+		Select("Position", "Caption", "Alt", "IsCover").
+		Updates(image).Error
+}
 
 // DELETE / DELETE
 func (pr *competitionRepository) DeleteByID(ctx context.Context, tx *gorm.DB, id string) error {
@@ -206,3 +219,10 @@ func (ar *competitionRepository) DeleteImagesByID(ctx context.Context, tx *gorm.
 
 	return tx.WithContext(ctx).Where("competition_id = ?", id).Delete(&entity.CompetitionImage{}).Error
 }
+func (ar *competitionRepository) DeleteImageByID(ctx context.Context, tx *gorm.DB, competitionID, imageID string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Where("id = ? AND competition_id = ?", imageID, competitionID).Delete(&entity.CompetitionImage{}).Error
+}
@@ -21,7 +21,7 @@ type (
 
 		// READ / GET
 		GetByName(ctx context.Context, tx *gorm.DB, name string) (*entity.Flyer, bool, error)
-		GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.Flyer, error)
+		GetAll(ctx context.Context, tx *gorm.DB, createdBy string) ([]*entity.Flyer, error)
 		GetAllWithPagination(ctx context.Context, tx *gorm.DB, req response.PaginationRequest) (dto.FlyerPaginationRepositoryResponse, error)
 		GetByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Flyer, bool, error)
 
@@ -76,7 +76,7 @@ func (pr *flyerRepository) GetByName(ctx context.Context, tx *gorm.DB, name stri
 
 	return flyer, true, nil
 }
-func (pr *flyerRepository) GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.Flyer, error) {
+func (pr *flyerRepository) GetAll(ctx context.Context, tx *gorm.DB, createdBy string) ([]*entity.Flyer, error) {
 	if tx == nil {
 		tx = pr.db
 	}
@@ -86,7 +86,7 @@ func (pr *flyerRepository) GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.F
 		err    error
 	)
 
-	query := tx.WithContext(ctx).Model(&entity.Flyer{})
+	query := tx.WithContext(ctx).Model(&entity.Flyer{}).Scopes(PreloadAuthors, FilterCreatedBy(createdBy))
 	if err := query.Order(`"created_at" DESC`).Find(&flyers).Error; err != nil {
 		return []*entity.Flyer{}, err
 	}
@@ -110,7 +110,7 @@ func (pr *flyerRepository) GetAllWithPagination(ctx context.Context, tx *gorm.DB
 		req.Page = 1
 	}
 
-	query := tx.WithContext(ctx).Model(&entity.Flyer{})
+	query := tx.WithContext(ctx).Model(&entity.Flyer{}).Scopes(PreloadAuthors, FilterCreatedBy(req.CreatedBy))
 
 	if req.Search != "" {
 		searchValue := "%" + strings.ToLower(req.Search) + "%"
@@ -143,7 +143,7 @@ func (pr *flyerRepository) GetByID(ctx context.Context, tx *gorm.DB, id string)
 	}
 
 	var flyer *entity.Flyer
-	err := tx.WithContext(ctx).Where("id = ?", id).Take(&flyer).Error
+	err := tx.WithContext(ctx).Scopes(PreloadAuthors).Where("id = ?", id).Take(&flyer).Error
 	if err != nil {
 		return &entity.Flyer{}, false, err
 	}
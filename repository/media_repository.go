@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/entity"
+	"github.com/Amierza/nawasena-backend/response"
+	"gorm.io/gorm"
+)
+
+type (
+	IMediaRepository interface {
+		RunInTransaction(ctx context.Context, fn func(txRepo IMediaRepository) error) error
+
+		// CREATE / POST
+		Create(ctx context.Context, tx *gorm.DB, media *entity.Media) error
+
+		// READ / GET
+		GetAllWithPagination(ctx context.Context, tx *gorm.DB, req dto.MediaPaginationRequest) (dto.MediaPaginationRepositoryResponse, error)
+		GetByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Media, bool, error)
+		GetByName(ctx context.Context, tx *gorm.DB, name string) (*entity.Media, bool, error)
+		GetByHash(ctx context.Context, tx *gorm.DB, hash, visibility string) (*entity.Media, bool, error)
+		GetNamesUpdatedSince(ctx context.Context, tx *gorm.DB, since time.Time) ([]string, error)
+		GetAllAttachment(ctx context.Context, tx *gorm.DB) ([]entity.Media, error)
+
+		// UPDATE / PATCH
+		Update(ctx context.Context, tx *gorm.DB, media *entity.Media) error
+		Touch(ctx context.Context, tx *gorm.DB, id string) error
+		MarkAttached(ctx context.Context, tx *gorm.DB, ids []string) error
+		MarkDetached(ctx context.Context, tx *gorm.DB, ids []string) error
+
+		// DELETE / DELETE
+		DeleteByID(ctx context.Context, tx *gorm.DB, id string) error
+	}
+
+	mediaRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewMediaRepository(db *gorm.DB) *mediaRepository {
+	return &mediaRepository{
+		db: db,
+	}
+}
+
+func (mr *mediaRepository) RunInTransaction(ctx context.Context, fn func(txRepo IMediaRepository) error) error {
+	return mr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := &mediaRepository{db: tx}
+		return fn(txRepo)
+	})
+}
+
+// CREATE / POST
+func (mr *mediaRepository) Create(ctx context.Context, tx *gorm.DB, media *entity.Media) error {
+	if tx == nil {
+		tx = mr.db
+	}
+
+	return tx.WithContext(ctx).Create(&media).Error
+}
+
+// READ / GET
+func (mr *mediaRepository) GetAllWithPagination(ctx context.Context, tx *gorm.DB, req dto.MediaPaginationRequest) (dto.MediaPaginationRepositoryResponse, error) {
+	if tx == nil {
+		tx = mr.db
+	}
+
+	var medias []entity.Media
+	var err error
+	var count int64
+
+	if req.PerPage == 0 {
+		req.PerPage = 10
+	}
+
+	if req.Page == 0 {
+		req.Page = 1
+	}
+
+	query := tx.WithContext(ctx).Model(&entity.Media{}).Scopes(PreloadAuthors, FilterCreatedBy(req.CreatedBy))
+
+	if req.Search != "" {
+		searchValue := "%" + strings.ToLower(req.Search) + "%"
+		query = query.Where("LOWER(original_name) LIKE ? OR LOWER(alt) LIKE ? OR LOWER(caption) LIKE ?", searchValue, searchValue, searchValue)
+	}
+
+	if req.Hash != "" {
+		query = query.Where("hash = ?", strings.ToLower(req.Hash))
+	}
+
+	if req.Visibility != "" {
+		query = query.Where("visibility = ?", req.Visibility)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return dto.MediaPaginationRepositoryResponse{}, err
+	}
+
+	if err := query.Order(`"created_at" DESC`).Scopes(Paginate(req.Page, req.PerPage)).Find(&medias).Error; err != nil {
+		return dto.MediaPaginationRepositoryResponse{}, err
+	}
+
+	totalPage := int64(math.Ceil(float64(count) / float64(req.PerPage)))
+
+	return dto.MediaPaginationRepositoryResponse{
+		Medias: medias,
+		PaginationResponse: response.PaginationResponse{
+			Page:    req.Page,
+			PerPage: req.PerPage,
+			MaxPage: totalPage,
+			Count:   count,
+		},
+	}, err
+}
+func (mr *mediaRepository) GetByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Media, bool, error) {
+	if tx == nil {
+		tx = mr.db
+	}
+
+	var media *entity.Media
+	err := tx.WithContext(ctx).Scopes(PreloadAuthors).Where("id = ?", id).Take(&media).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.Media{}, false, nil
+	}
+	if err != nil {
+		return &entity.Media{}, false, err
+	}
+
+	return media, true, nil
+}
+func (mr *mediaRepository) GetByName(ctx context.Context, tx *gorm.DB, name string) (*entity.Media, bool, error) {
+	if tx == nil {
+		tx = mr.db
+	}
+
+	var media *entity.Media
+	err := tx.WithContext(ctx).Where("name = ?", name).Take(&media).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.Media{}, false, nil
+	}
+	if err != nil {
+		return &entity.Media{}, false, err
+	}
+
+	return media, true, nil
+}
+func (mr *mediaRepository) GetByHash(ctx context.Context, tx *gorm.DB, hash, visibility string) (*entity.Media, bool, error) {
+	if tx == nil {
+		tx = mr.db
+	}
+
+	var media *entity.Media
+	err := tx.WithContext(ctx).Where("hash = ? AND visibility = ?", hash, visibility).Order(`"created_at" DESC`).Take(&media).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.Media{}, false, nil
+	}
+	if err != nil {
+		return &entity.Media{}, false, err
+	}
+
+	return media, true, nil
+}
+func (mr *mediaRepository) GetNamesUpdatedSince(ctx context.Context, tx *gorm.DB, since time.Time) ([]string, error) {
+	if tx == nil {
+		tx = mr.db
+	}
+
+	var names []string
+	if err := tx.WithContext(ctx).Model(&entity.Media{}).Where("updated_at > ?", since).Pluck("name", &names).Error; err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+func (mr *mediaRepository) GetAllAttachment(ctx context.Context, tx *gorm.DB) ([]entity.Media, error) {
+	if tx == nil {
+		tx = mr.db
+	}
+
+	var medias []entity.Media
+	err := tx.WithContext(ctx).Model(&entity.Media{}).
+		Select("id", "name", "size", "visibility", "attached_at", "detached_at").
+		Find(&medias).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return medias, nil
+}
+
+// UPDATE / PATCH
+func (mr *mediaRepository) Update(ctx context.Context, tx *gorm.DB, media *entity.Media) error {
+	if tx == nil {
+		tx = mr.db
+	}
+
+	// alt & caption boleh dikosongkan, jadi kolomnya ditulis eksplisit
+	return tx.WithContext(ctx).Model(&entity.Media{}).
+		Where("id = ?", media.ID).
+		Select("Alt", "Caption").
+		Updates(media).Error
+}
+func (mr *mediaRepository) Touch(ctx context.Context, tx *gorm.DB, id string) error {
+	if tx == nil {
+		tx = mr.db
+	}
+
+	// file yang di-upload ulang dianggap masuk library lagi, jadi status detached ikut dihapus
+	return tx.WithContext(ctx).Model(&entity.Media{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]any{"updated_at": time.Now(), "attached_at": nil, "detached_at": nil}).Error
+}
+func (mr *mediaRepository) MarkAttached(ctx context.Context, tx *gorm.DB, ids []string) error {
+	if tx == nil {
+		tx = mr.db
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return tx.WithContext(ctx).Model(&entity.Media{}).
+		Where("id IN ?", ids).
+		UpdateColumns(map[string]any{"attached_at": gorm.Expr("COALESCE(attached_at, ?)", time.Now()), "detached_at": nil}).Error
+}
+func (mr *mediaRepository) MarkDetached(ctx context.Context, tx *gorm.DB, ids []string) error {
+	if tx == nil {
+		tx = mr.db
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return tx.WithContext(ctx).Model(&entity.Media{}).
+		Where("id IN ? AND attached_at IS NOT NULL AND detached_at IS NULL", ids).
+		UpdateColumn("detached_at", time.Now()).Error
+}
+
+// DELETE / DELETE
+func (mr *mediaRepository) DeleteByID(ctx context.Context, tx *gorm.DB, id string) error {
+	if tx == nil {
+		tx = mr.db
+	}
+
+	return tx.WithContext(ctx).Where("id = ?", id).Delete(&entity.Media{}).Error
+}
@@ -18,7 +18,7 @@ type (
 		Create(ctx context.Context, tx *gorm.DB, member *entity.Member) error
 
 		// READ / GET
-		GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.Member, error)
+		GetAll(ctx context.Context, tx *gorm.DB, createdBy string) ([]*entity.Member, error)
 		GetAllWithPagination(ctx context.Context, tx *gorm.DB, req response.PaginationRequest) (dto.MemberPaginationRepositoryResponse, error)
 		GetByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Member, bool, error)
 		GetByNameMajorGenerationAndPositionID(ctx context.Context, tx *gorm.DB, name, major, positionID string, generation int) (*entity.Member, bool, error)
@@ -52,7 +52,7 @@ func (mr *memberRepository) Create(ctx context.Context, tx *gorm.DB, member *ent
 }
 
 // READ / GET
-func (mr *memberRepository) GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.Member, error) {
+func (mr *memberRepository) GetAll(ctx context.Context, tx *gorm.DB, createdBy string) ([]*entity.Member, error) {
 	if tx == nil {
 		tx = mr.db
 	}
@@ -62,7 +62,7 @@ func (mr *memberRepository) GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.
 		err     error
 	)
 
-	query := tx.WithContext(ctx).Preload("Position").Model(&entity.Member{})
+	query := tx.WithContext(ctx).Preload("Position").Model(&entity.Member{}).Scopes(PreloadAuthors, FilterCreatedBy(createdBy))
 	if err := query.Order(`"created_at" DESC`).Find(&members).Error; err != nil {
 		return []*entity.Member{}, err
 	}
@@ -86,7 +86,7 @@ func (mr *memberRepository) GetAllWithPagination(ctx context.Context, tx *gorm.D
 		req.Page = 1
 	}
 
-	query := tx.WithContext(ctx).Model(&entity.Member{}).Preload("Position")
+	query := tx.WithContext(ctx).Model(&entity.Member{}).Preload("Position").Scopes(PreloadAuthors, FilterCreatedBy(req.CreatedBy))
 
 	if req.Search != "" {
 		searchValue := "%" + strings.ToLower(req.Search) + "%"
@@ -119,7 +119,7 @@ func (mr *memberRepository) GetByID(ctx context.Context, tx *gorm.DB, id string)
 	}
 
 	var member *entity.Member
-	err := tx.WithContext(ctx).Preload("Position").Where("id = ?", id).Take(&member).Error
+	err := tx.WithContext(ctx).Scopes(PreloadAuthors).Preload("Position").Where("id = ?", id).Take(&member).Error
 	if err != nil {
 		return &entity.Member{}, false, err
 	}
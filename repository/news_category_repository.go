@@ -17,7 +17,7 @@ type (
 
 		// READ / GET
 		GetByName(ctx context.Context, tx *gorm.DB, name string) (*entity.NewsCategory, bool, error)
-		GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.NewsCategory, error)
+		GetAll(ctx context.Context, tx *gorm.DB, createdBy string) ([]*entity.NewsCategory, error)
 		GetByID(ctx context.Context, tx *gorm.DB, id string) (*entity.NewsCategory, bool, error)
 
 		// UPDATE / PATCH
@@ -71,7 +71,7 @@ func (pr *newsCategoryRepository) GetByName(ctx context.Context, tx *gorm.DB, na
 
 	return newsCategory, true, nil
 }
-func (pr *newsCategoryRepository) GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.NewsCategory, error) {
+func (pr *newsCategoryRepository) GetAll(ctx context.Context, tx *gorm.DB, createdBy string) ([]*entity.NewsCategory, error) {
 	if tx == nil {
 		tx = pr.db
 	}
@@ -81,7 +81,7 @@ func (pr *newsCategoryRepository) GetAll(ctx context.Context, tx *gorm.DB) ([]*e
 		err           error
 	)
 
-	query := tx.WithContext(ctx).Model(&entity.NewsCategory{})
+	query := tx.WithContext(ctx).Model(&entity.NewsCategory{}).Scopes(PreloadAuthors, FilterCreatedBy(createdBy))
 	if err := query.Order(`"created_at" DESC`).Find(&newsCategorys).Error; err != nil {
 		return []*entity.NewsCategory{}, err
 	}
@@ -94,7 +94,7 @@ func (pr *newsCategoryRepository) GetByID(ctx context.Context, tx *gorm.DB, id s
 	}
 
 	var newsCategory *entity.NewsCategory
-	err := tx.WithContext(ctx).Where("id = ?", id).Take(&newsCategory).Error
+	err := tx.WithContext(ctx).Scopes(PreloadAuthors).Where("id = ?", id).Take(&newsCategory).Error
 	if err != nil {
 		return &entity.NewsCategory{}, false, err
 	}
@@ -5,11 +5,15 @@ import (
 	"errors"
 	"math"
 	"strings"
+	"time"
 
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/dto"
 	"github.com/Amierza/nawasena-backend/entity"
 	"github.com/Amierza/nawasena-backend/response"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type (
@@ -19,23 +23,41 @@ type (
 		// CREATE / POST
 		Create(ctx context.Context, tx *gorm.DB, news *entity.News) error
 		CreateImage(ctx context.Context, tx *gorm.DB, image *entity.NewsImage) error
+		CreateRevision(ctx context.Context, tx *gorm.DB, revision *entity.NewsRevision) error
+		CreateTags(ctx context.Context, tx *gorm.DB, tags []entity.NewsTag) error
 
 		// READ / GET
 		GetByName(ctx context.Context, tx *gorm.DB, name string) (*entity.News, bool, error)
-		GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.News, error)
-		GetAllWithPagination(ctx context.Context, tx *gorm.DB, req response.PaginationRequest) (dto.NewsPaginationRepositoryResponse, error)
+		GetAll(ctx context.Context, tx *gorm.DB, req dto.NewsPaginationRequest) ([]*entity.News, error)
+		GetAllWithPagination(ctx context.Context, tx *gorm.DB, req dto.NewsPaginationRequest) (dto.NewsPaginationRepositoryResponse, error)
 		GetFeatured(ctx context.Context, tx *gorm.DB, limit *int) ([]*entity.News, error)
 		GetByID(ctx context.Context, tx *gorm.DB, id string) (*entity.News, bool, error)
 		GetCategoryByCategoryID(ctx context.Context, tx *gorm.DB, categoryID string) (*entity.NewsCategory, bool, error)
 		GetImagesByID(ctx context.Context, tx *gorm.DB, id string) ([]*entity.NewsImage, error)
+		GetDueScheduled(ctx context.Context, tx *gorm.DB, now time.Time) ([]*entity.News, error)
+		GetRevisions(ctx context.Context, tx *gorm.DB, newsID string) ([]*entity.NewsRevision, error)
+		GetRevisionByNumber(ctx context.Context, tx *gorm.DB, newsID string, number int) (*entity.NewsRevision, bool, error)
+		GetLastRevisionNumber(ctx context.Context, tx *gorm.DB, newsID string) (int, error)
+		GetTagsBySlugs(ctx context.Context, tx *gorm.DB, slugs []string) ([]entity.NewsTag, error)
+		GetTagCounts(ctx context.Context, tx *gorm.DB) ([]dto.NewsTagResponse, error)
+		GetRelated(ctx context.Context, tx *gorm.DB, news *entity.News, limit int) ([]*entity.News, error)
 
 		// UPDATE / PATCH
 		Update(ctx context.Context, tx *gorm.DB, news *entity.News) error
+		UpdatePublication(ctx context.Context, tx *gorm.DB, news *entity.News) error
+		PublishIfDue(ctx context.Context, tx *gorm.DB, news *entity.News, now time.Time) (bool, error)
+		UpdateContent(ctx context.Context, tx *gorm.DB, news *entity.News) error
+		UpdateBody(ctx context.Context, tx *gorm.DB, news *entity.News) error
 		IncrementViews(ctx context.Context, tx *gorm.DB, id string) error
+		UpdateImage(ctx context.Context, tx *gorm.DB, image *entity.NewsImage) error
+		ReplaceTags(ctx context.Context, tx *gorm.DB, news *entity.News, tags []entity.NewsTag) error
 
 		// DELETE / DELETE
 		DeleteByID(ctx context.Context, tx *gorm.DB, id string) error
 		DeleteImagesByID(ctx context.Context, tx *gorm.DB, id string) error
+		DeleteImageByID(ctx context.Context, tx *gorm.DB, newsID, imageID string) error
+		DeleteRevisionsByNewsID(ctx context.Context, tx *gorm.DB, newsID string) error
+		ClearTags(ctx context.Context, tx *gorm.DB, news *entity.News) error
 	}
 
 	newsRepository struct {
@@ -49,6 +71,38 @@ func NewNewsRepository(db *gorm.DB) *newsRepository {
 	}
 }
 
+// filterPublication: list publik hanya melihat berita published, termasuk jadwal yang sudah lewat
+// tapi belum sempat diproses scheduler. Admin bisa memfilter per status
+func filterPublication(req dto.NewsPaginationRequest) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if req.PublishedOnly {
+			return db.Where("publication_status = ? OR (publication_status = ? AND publish_at <= ?)",
+				constants.ENUM_PUBLICATION_PUBLISHED, constants.ENUM_PUBLICATION_SCHEDULED, time.Now())
+		}
+		if req.PublicationStatus != "" {
+			return db.Where("publication_status = ?", req.PublicationStatus)
+		}
+
+		return db
+	}
+}
+
+// filterTag: req.Tag sudah berupa slug tag
+func filterTag(req dto.NewsPaginationRequest) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if req.Tag == "" {
+			return db
+		}
+
+		return db.Where(`id IN (SELECT news_tag_relations.news_id FROM news_tag_relations
+			JOIN news_tags ON news_tags.id = news_tag_relations.news_tag_id WHERE news_tags.slug = ?)`, req.Tag)
+	}
+}
+
+func orderNewsTags(db *gorm.DB) *gorm.DB {
+	return db.Order("name ASC")
+}
+
 func (nr *newsRepository) RunInTransaction(ctx context.Context, fn func(txRepo INewsRepository) error) error {
 	return nr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		txRepo := &newsRepository{db: tx}
@@ -71,6 +125,23 @@ func (nr *newsRepository) CreateImage(ctx context.Context, tx *gorm.DB, image *e
 
 	return tx.WithContext(ctx).Create(&image).Error
 }
+func (nr *newsRepository) CreateRevision(ctx context.Context, tx *gorm.DB, revision *entity.NewsRevision) error {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	return tx.WithContext(ctx).Create(revision).Error
+}
+func (nr *newsRepository) CreateTags(ctx context.Context, tx *gorm.DB, tags []entity.NewsTag) error {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	// tag yang slug-nya sudah ada dibiarkan, id-nya diambil ulang lewat GetTagsBySlugs
+	return tx.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "slug"}}, DoNothing: true}).
+		Create(&tags).Error
+}
 
 // READ / GET
 func (nr *newsRepository) GetByName(ctx context.Context, tx *gorm.DB, name string) (*entity.News, bool, error) {
@@ -89,7 +160,7 @@ func (nr *newsRepository) GetByName(ctx context.Context, tx *gorm.DB, name strin
 
 	return news, true, nil
 }
-func (nr *newsRepository) GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.News, error) {
+func (nr *newsRepository) GetAll(ctx context.Context, tx *gorm.DB, req dto.NewsPaginationRequest) ([]*entity.News, error) {
 	if tx == nil {
 		tx = nr.db
 	}
@@ -99,14 +170,14 @@ func (nr *newsRepository) GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.Ne
 		err   error
 	)
 
-	query := tx.WithContext(ctx).Model(&entity.News{}).Preload("Images").Preload("NewsCategory")
+	query := tx.WithContext(ctx).Model(&entity.News{}).Preload("Images", OrderGallery).Preload("Tags", orderNewsTags).Preload("NewsCategory").Scopes(PreloadAuthors, FilterCreatedBy(req.CreatedBy), filterPublication(req), filterTag(req))
 	if err := query.Order(`"created_at" DESC`).Find(&newss).Error; err != nil {
 		return []*entity.News{}, err
 	}
 
 	return newss, err
 }
-func (nr *newsRepository) GetAllWithPagination(ctx context.Context, tx *gorm.DB, req response.PaginationRequest) (dto.NewsPaginationRepositoryResponse, error) {
+func (nr *newsRepository) GetAllWithPagination(ctx context.Context, tx *gorm.DB, req dto.NewsPaginationRequest) (dto.NewsPaginationRepositoryResponse, error) {
 	if tx == nil {
 		tx = nr.db
 	}
@@ -123,11 +194,11 @@ func (nr *newsRepository) GetAllWithPagination(ctx context.Context, tx *gorm.DB,
 		req.Page = 1
 	}
 
-	query := tx.WithContext(ctx).Model(&entity.News{}).Preload("Images").Preload("NewsCategory")
+	query := tx.WithContext(ctx).Model(&entity.News{}).Preload("Images", OrderGallery).Preload("Tags", orderNewsTags).Preload("NewsCategory").Scopes(PreloadAuthors, FilterCreatedBy(req.CreatedBy), filterPublication(req), filterTag(req))
 
 	if req.Search != "" {
 		searchValue := "%" + strings.ToLower(req.Search) + "%"
-		query = query.Where("LOWER(name) LIKE ? OR LOWER(description) LIKE ?", searchValue, searchValue)
+		query = query.Where("LOWER(name) LIKE ? OR LOWER(description) LIKE ? OR LOWER(summary) LIKE ? OR LOWER(body) LIKE ?", searchValue, searchValue, searchValue, searchValue)
 	}
 
 	if err := query.Count(&count).Error; err != nil {
@@ -157,7 +228,9 @@ func (nr *newsRepository) GetFeatured(ctx context.Context, tx *gorm.DB, limit *i
 
 	var news []*entity.News
 	query := tx.WithContext(ctx).Model(&entity.News{}).
-		Preload("Images").
+		Preload("Images", OrderGallery).
+		Preload("Tags", orderNewsTags).
+		Scopes(PreloadAuthors, filterPublication(dto.NewsPaginationRequest{PublishedOnly: true})).
 		Preload("NewsCategory").
 		Where("featured = ?", true).
 		Order("published_at DESC")
@@ -176,7 +249,9 @@ func (nr *newsRepository) GetFeatured(ctx context.Context, tx *gorm.DB, limit *i
 
 		var fallback []*entity.News
 		err := tx.WithContext(ctx).Model(&entity.News{}).
-			Preload("Images").
+			Preload("Images", OrderGallery).
+			Preload("Tags", orderNewsTags).
+			Scopes(PreloadAuthors, filterPublication(dto.NewsPaginationRequest{PublishedOnly: true})).
 			Preload("NewsCategory").
 			Where("featured = ?", false). // jangan ambil yang udah featured
 			Order("views DESC").
@@ -197,7 +272,7 @@ func (nr *newsRepository) GetByID(ctx context.Context, tx *gorm.DB, id string) (
 	}
 
 	var news *entity.News
-	err := tx.WithContext(ctx).Preload("Images").Preload("NewsCategory").Where("id = ?", id).Take(&news).Error
+	err := tx.WithContext(ctx).Scopes(PreloadAuthors).Preload("Images", OrderGallery).Preload("Tags", orderNewsTags).Preload("NewsCategory").Where("id = ?", id).Take(&news).Error
 	if err != nil {
 		return &entity.News{}, false, err
 	}
@@ -231,7 +306,8 @@ func (nr *newsRepository) GetImagesByID(ctx context.Context, tx *gorm.DB, id str
 	query := tx.WithContext(ctx).
 		Model(&entity.NewsImage{}).
 		Preload("News").
-		Where("news_id = ?", id)
+		Where("news_id = ?", id).
+		Scopes(OrderGallery)
 
 	var newsImages []*entity.NewsImage
 	if err := query.Find(&newsImages).Error; err != nil {
@@ -240,6 +316,142 @@ func (nr *newsRepository) GetImagesByID(ctx context.Context, tx *gorm.DB, id str
 
 	return newsImages, nil
 }
+func (nr *newsRepository) GetDueScheduled(ctx context.Context, tx *gorm.DB, now time.Time) ([]*entity.News, error) {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	var newss []*entity.News
+	err := tx.WithContext(ctx).
+		Where("publication_status = ? AND publish_at <= ?", constants.ENUM_PUBLICATION_SCHEDULED, now).
+		Order("publish_at ASC").
+		Find(&newss).Error
+	if err != nil {
+		return []*entity.News{}, err
+	}
+
+	return newss, nil
+}
+func (nr *newsRepository) GetRevisions(ctx context.Context, tx *gorm.DB, newsID string) ([]*entity.NewsRevision, error) {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	var revisions []*entity.NewsRevision
+	err := tx.WithContext(ctx).
+		Preload("Images", OrderGallery).
+		Scopes(PreloadAuthors).
+		Where("news_id = ?", newsID).
+		Order("number DESC").
+		Find(&revisions).Error
+	if err != nil {
+		return []*entity.NewsRevision{}, err
+	}
+
+	return revisions, nil
+}
+func (nr *newsRepository) GetRevisionByNumber(ctx context.Context, tx *gorm.DB, newsID string, number int) (*entity.NewsRevision, bool, error) {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	var revision *entity.NewsRevision
+	err := tx.WithContext(ctx).
+		Preload("Images", OrderGallery).
+		Scopes(PreloadAuthors).
+		Where("news_id = ? AND number = ?", newsID, number).
+		Take(&revision).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.NewsRevision{}, false, nil
+	}
+	if err != nil {
+		return &entity.NewsRevision{}, false, err
+	}
+
+	return revision, true, nil
+}
+func (nr *newsRepository) GetLastRevisionNumber(ctx context.Context, tx *gorm.DB, newsID string) (int, error) {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	var number int
+	err := tx.WithContext(ctx).Model(&entity.NewsRevision{}).
+		Where("news_id = ?", newsID).
+		Select("COALESCE(MAX(number), 0)").
+		Scan(&number).Error
+
+	return number, err
+}
+func (nr *newsRepository) GetTagsBySlugs(ctx context.Context, tx *gorm.DB, slugs []string) ([]entity.NewsTag, error) {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	var tags []entity.NewsTag
+	if err := tx.WithContext(ctx).Where("slug IN ?", slugs).Find(&tags).Error; err != nil {
+		return []entity.NewsTag{}, err
+	}
+
+	return tags, nil
+}
+func (nr *newsRepository) GetTagCounts(ctx context.Context, tx *gorm.DB) ([]dto.NewsTagResponse, error) {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	// hanya berita yang tampil di publik yang dihitung, tag tanpa berita published tidak muncul
+	tags := []dto.NewsTagResponse{}
+	err := tx.WithContext(ctx).Model(&entity.NewsTag{}).
+		Select("news_tags.name, news_tags.slug, COUNT(news.id) AS count").
+		Joins("JOIN news_tag_relations ON news_tag_relations.news_tag_id = news_tags.id").
+		Joins("JOIN news ON news.id = news_tag_relations.news_id AND news.deleted_at IS NULL").
+		Scopes(filterPublication(dto.NewsPaginationRequest{PublishedOnly: true})).
+		Group("news_tags.id, news_tags.name, news_tags.slug").
+		Order("count DESC, news_tags.name ASC").
+		Scan(&tags).Error
+	if err != nil {
+		return []dto.NewsTagResponse{}, err
+	}
+
+	return tags, nil
+}
+func (nr *newsRepository) GetRelated(ctx context.Context, tx *gorm.DB, news *entity.News, limit int) ([]*entity.News, error) {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	tagIDs := []uuid.UUID{}
+	for _, tag := range news.Tags {
+		tagIDs = append(tagIDs, tag.ID)
+	}
+
+	// urutan: jumlah tag yang sama, kategori yang sama, lalu yang paling baru. Berita tanpa kesamaan
+	// tetap ikut di belakang supaya daftar terkait tidak kosong
+	ranking := clause.OrderBy{Expression: clause.Expr{
+		SQL: `(SELECT COUNT(*) FROM news_tag_relations WHERE news_tag_relations.news_id = news.id AND news_tag_relations.news_tag_id IN (?)) DESC,
+			CASE WHEN news_category_id = ? THEN 1 ELSE 0 END DESC,
+			published_at DESC`,
+		Vars:               []any{tagIDs, news.NewsCategoryID},
+		WithoutParentheses: true,
+	}}
+
+	var newss []*entity.News
+	err := tx.WithContext(ctx).Model(&entity.News{}).
+		Preload("Images", OrderGallery).
+		Preload("Tags", orderNewsTags).
+		Preload("NewsCategory").
+		Scopes(PreloadAuthors, filterPublication(dto.NewsPaginationRequest{PublishedOnly: true})).
+		Where("id <> ?", news.ID).
+		Order(ranking).
+		Limit(limit).
+		Find(&newss).Error
+	if err != nil {
+		return []*entity.News{}, err
+	}
+
+	return newss, nil
+}
 
 // UPDATE / PATCH
 func (nr *newsRepository) Update(ctx context.Context, tx *gorm.DB, news *entity.News) error {
@@ -249,6 +461,55 @@ func (nr *newsRepository) Update(ctx context.Context, tx *gorm.DB, news *entity.
 
 	return tx.WithContext(ctx).Model(&entity.News{}).Where("id = ?", news.ID).Updates(news).Error
 }
+func (nr *newsRepository) UpdatePublication(ctx context.Context, tx *gorm.DB, news *entity.News) error {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	// ditulis terpisah karena Updates(news) melewati PublishAt yang dikosongkan
+	return tx.WithContext(ctx).Model(&entity.News{}).
+		Where("id = ?", news.ID).
+		Select("PublicationStatus", "PublishAt", "PublishedAt").
+		Updates(news).Error
+}
+func (nr *newsRepository) PublishIfDue(ctx context.Context, tx *gorm.DB, news *entity.News, now time.Time) (bool, error) {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	// status & jadwal dicek ulang saat update, berita yang keburu diubah admin sejak dibaca scheduler tidak ikut terbit
+	result := tx.WithContext(ctx).Model(&entity.News{}).
+		Where("id = ? AND publication_status = ? AND publish_at <= ?", news.ID, constants.ENUM_PUBLICATION_SCHEDULED, now).
+		Select("PublicationStatus", "PublishAt", "PublishedAt").
+		Updates(news)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+func (nr *newsRepository) UpdateContent(ctx context.Context, tx *gorm.DB, news *entity.News) error {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	// dipakai restore revisi, nilai kosong (mis. featured false) tetap harus ditulis
+	return tx.WithContext(ctx).Model(&entity.News{}).
+		Where("id = ?", news.ID).
+		Select("Name", "Slug", "Description", "Location", "URL", "Status", "Featured", "NewsCategoryID").
+		Updates(news).Error
+}
+func (nr *newsRepository) UpdateBody(ctx context.Context, tx *gorm.DB, news *entity.News) error {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	// hasil render ditulis ulang semua, excerpt / daftar isi yang jadi kosong juga harus tersimpan
+	return tx.WithContext(ctx).Model(&entity.News{}).
+		Where("id = ?", news.ID).
+		Select("Summary", "Body", "BodyHTML", "Excerpt", "ReadingTime", "TableOfContents", "BodyImages").
+		Updates(news).Error
+}
 func (nr *newsRepository) IncrementViews(ctx context.Context, tx *gorm.DB, id string) error {
 	if tx == nil {
 		tx = nr.db
@@ -259,6 +520,23 @@ func (nr *newsRepository) IncrementViews(ctx context.Context, tx *gorm.DB, id st
 		Where("id = ?", id).
 		UpdateColumn("views", gorm.Expr("views + ?", 1)).Error
 }
+func (nr *newsRepository) UpdateImage(ctx context.Context, tx *gorm.DB, image *entity.NewsImage) error {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.NewsImage{}).
+		Where("id = ?", image.ID).
+		Select("Position", "Caption", "Alt", "IsCover").
+		Updates(image).Error
+}
+func (nr *newsRepository) ReplaceTags(ctx context.Context, tx *gorm.DB, news *entity.News, tags []entity.NewsTag) error {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	return tx.WithContext(ctx).Model(news).Association("Tags").Replace(tags)
+}
 
 // DELETE / DELETE
 func (nr *newsRepository) DeleteByID(ctx context.Context, tx *gorm.DB, id string) error {
@@ -275,3 +553,29 @@ func (nr *newsRepository) DeleteImagesByID(ctx context.Context, tx *gorm.DB, id
 
 	return tx.WithContext(ctx).Where("news_id = ?", id).Delete(&entity.NewsImage{}).Error
 }
+func (nr *newsRepository) DeleteImageByID(ctx context.Context, tx *gorm.DB, newsID, imageID string) error {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	return tx.WithContext(ctx).Where("id = ? AND news_id = ?", imageID, newsID).Delete(&entity.NewsImage{}).Error
+}
+func (nr *newsRepository) DeleteRevisionsByNewsID(ctx context.Context, tx *gorm.DB, newsID string) error {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	revisionIDs := tx.Model(&entity.NewsRevision{}).Select("id").Where("news_id = ?", newsID)
+	if err := tx.WithContext(ctx).Where("news_revision_id IN (?)", revisionIDs).Delete(&entity.NewsRevisionImage{}).Error; err != nil {
+		return err
+	}
+
+	return tx.WithContext(ctx).Where("news_id = ?", newsID).Delete(&entity.NewsRevision{}).Error
+}
+func (nr *newsRepository) ClearTags(ctx context.Context, tx *gorm.DB, news *entity.News) error {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	return tx.WithContext(ctx).Model(news).Association("Tags").Clear()
+}
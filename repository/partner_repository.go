@@ -21,7 +21,7 @@ type (
 
 		// READ / GET
 		GetByName(ctx context.Context, tx *gorm.DB, name string) (*entity.Partner, bool, error)
-		GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.Partner, error)
+		GetAll(ctx context.Context, tx *gorm.DB, createdBy string) ([]*entity.Partner, error)
 		GetAllWithPagination(ctx context.Context, tx *gorm.DB, req response.PaginationRequest) (dto.PartnerPaginationRepositoryResponse, error)
 		GetByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Partner, bool, error)
 
@@ -76,7 +76,7 @@ func (pr *partnerRepository) GetByName(ctx context.Context, tx *gorm.DB, name st
 
 	return partner, true, nil
 }
-func (pr *partnerRepository) GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.Partner, error) {
+func (pr *partnerRepository) GetAll(ctx context.Context, tx *gorm.DB, createdBy string) ([]*entity.Partner, error) {
 	if tx == nil {
 		tx = pr.db
 	}
@@ -86,7 +86,7 @@ func (pr *partnerRepository) GetAll(ctx context.Context, tx *gorm.DB) ([]*entity
 		err      error
 	)
 
-	query := tx.WithContext(ctx).Model(&entity.Partner{})
+	query := tx.WithContext(ctx).Model(&entity.Partner{}).Scopes(PreloadAuthors, FilterCreatedBy(createdBy))
 	if err := query.Order(`"created_at" DESC`).Find(&partners).Error; err != nil {
 		return []*entity.Partner{}, err
 	}
@@ -110,7 +110,7 @@ func (pr *partnerRepository) GetAllWithPagination(ctx context.Context, tx *gorm.
 		req.Page = 1
 	}
 
-	query := tx.WithContext(ctx).Model(&entity.Partner{})
+	query := tx.WithContext(ctx).Model(&entity.Partner{}).Scopes(PreloadAuthors, FilterCreatedBy(req.CreatedBy))
 
 	if req.Search != "" {
 		searchValue := "%" + strings.ToLower(req.Search) + "%"
@@ -143,7 +143,7 @@ func (pr *partnerRepository) GetByID(ctx context.Context, tx *gorm.DB, id string
 	}
 
 	var partner *entity.Partner
-	err := tx.WithContext(ctx).Where("id = ?", id).Take(&partner).Error
+	err := tx.WithContext(ctx).Scopes(PreloadAuthors).Where("id = ?", id).Take(&partner).Error
 	if err != nil {
 		return &entity.Partner{}, false, err
 	}
@@ -19,7 +19,7 @@ type (
 
 		// READ / GET
 		GetByName(ctx context.Context, tx *gorm.DB, name string) (*entity.Position, bool, error)
-		GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.Position, error)
+		GetAll(ctx context.Context, tx *gorm.DB, createdBy string) ([]*entity.Position, error)
 		GetAllWithPagination(ctx context.Context, tx *gorm.DB, req response.PaginationRequest) (dto.PositionPaginationRepositoryResponse, error)
 		GetByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Position, bool, error)
 
@@ -67,7 +67,7 @@ func (pr *positionRepository) GetByName(ctx context.Context, tx *gorm.DB, name s
 
 	return position, true, nil
 }
-func (pr *positionRepository) GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.Position, error) {
+func (pr *positionRepository) GetAll(ctx context.Context, tx *gorm.DB, createdBy string) ([]*entity.Position, error) {
 	if tx == nil {
 		tx = pr.db
 	}
@@ -77,7 +77,7 @@ func (pr *positionRepository) GetAll(ctx context.Context, tx *gorm.DB) ([]*entit
 		err       error
 	)
 
-	query := tx.WithContext(ctx).Model(&entity.Position{})
+	query := tx.WithContext(ctx).Model(&entity.Position{}).Scopes(PreloadAuthors, FilterCreatedBy(createdBy))
 	if err := query.Order(`"created_at" DESC`).Find(&positions).Error; err != nil {
 		return []*entity.Position{}, err
 	}
@@ -101,7 +101,7 @@ func (pr *positionRepository) GetAllWithPagination(ctx context.Context, tx *gorm
 		req.Page = 1
 	}
 
-	query := tx.WithContext(ctx).Model(&entity.Position{})
+	query := tx.WithContext(ctx).Model(&entity.Position{}).Scopes(PreloadAuthors, FilterCreatedBy(req.CreatedBy))
 
 	if req.Search != "" {
 		searchValue := "%" + strings.ToLower(req.Search) + "%"
@@ -134,7 +134,7 @@ func (pr *positionRepository) GetByID(ctx context.Context, tx *gorm.DB, id strin
 	}
 
 	var position *entity.Position
-	err := tx.WithContext(ctx).Where("id = ?", id).Take(&position).Error
+	err := tx.WithContext(ctx).Scopes(PreloadAuthors).Where("id = ?", id).Take(&position).Error
 	if err != nil {
 		return &entity.Position{}, false, err
 	}
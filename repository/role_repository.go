@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Amierza/nawasena-backend/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	IRoleRepository interface {
+		RunInTransaction(ctx context.Context, fn func(txRepo IRoleRepository) error) error
+
+		// CREATE / POST
+		Create(ctx context.Context, tx *gorm.DB, role *entity.AccessRole) error
+
+		// READ / GET
+		GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.AccessRole, error)
+		GetByID(ctx context.Context, tx *gorm.DB, id string) (*entity.AccessRole, bool, error)
+		GetByName(ctx context.Context, tx *gorm.DB, name string) (*entity.AccessRole, bool, error)
+		GetAllPermissions(ctx context.Context, tx *gorm.DB) ([]*entity.Permission, error)
+		GetPermissionsByNames(ctx context.Context, tx *gorm.DB, names []string) ([]entity.Permission, error)
+		CountAdminsByRoleName(ctx context.Context, tx *gorm.DB, name string) (int64, error)
+		LockAdminIDsByRoleName(ctx context.Context, tx *gorm.DB, name string) ([]string, error)
+
+		// UPDATE / PATCH
+		Update(ctx context.Context, tx *gorm.DB, role *entity.AccessRole) error
+		ReplacePermissions(ctx context.Context, tx *gorm.DB, role *entity.AccessRole, permissions []entity.Permission) error
+		UpdateAdminRole(ctx context.Context, tx *gorm.DB, adminID string, roleName string) (bool, error)
+
+		// DELETE / DELETE
+		Delete(ctx context.Context, tx *gorm.DB, role *entity.AccessRole) error
+	}
+
+	roleRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewRoleRepository(db *gorm.DB) *roleRepository {
+	return &roleRepository{
+		db: db,
+	}
+}
+
+func (rr *roleRepository) RunInTransaction(ctx context.Context, fn func(txRepo IRoleRepository) error) error {
+	return rr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := &roleRepository{db: tx}
+		return fn(txRepo)
+	})
+}
+
+// CREATE / POST
+func (rr *roleRepository) Create(ctx context.Context, tx *gorm.DB, role *entity.AccessRole) error {
+	if tx == nil {
+		tx = rr.db
+	}
+
+	return tx.WithContext(ctx).Create(&role).Error
+}
+
+// READ / GET
+func (rr *roleRepository) GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.AccessRole, error) {
+	if tx == nil {
+		tx = rr.db
+	}
+
+	var roles []*entity.AccessRole
+	if err := tx.WithContext(ctx).Preload("Permissions").Order(`"created_at" ASC`).Find(&roles).Error; err != nil {
+		return []*entity.AccessRole{}, err
+	}
+
+	return roles, nil
+}
+func (rr *roleRepository) GetByID(ctx context.Context, tx *gorm.DB, id string) (*entity.AccessRole, bool, error) {
+	if tx == nil {
+		tx = rr.db
+	}
+
+	var role *entity.AccessRole
+	err := tx.WithContext(ctx).Preload("Permissions").Where("id = ?", id).Take(&role).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.AccessRole{}, false, nil
+	}
+	if err != nil {
+		return &entity.AccessRole{}, false, err
+	}
+
+	return role, true, nil
+}
+func (rr *roleRepository) GetByName(ctx context.Context, tx *gorm.DB, name string) (*entity.AccessRole, bool, error) {
+	if tx == nil {
+		tx = rr.db
+	}
+
+	var role *entity.AccessRole
+	err := tx.WithContext(ctx).Preload("Permissions").Where("name = ?", name).Take(&role).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.AccessRole{}, false, nil
+	}
+	if err != nil {
+		return &entity.AccessRole{}, false, err
+	}
+
+	return role, true, nil
+}
+func (rr *roleRepository) GetAllPermissions(ctx context.Context, tx *gorm.DB) ([]*entity.Permission, error) {
+	if tx == nil {
+		tx = rr.db
+	}
+
+	var permissions []*entity.Permission
+	if err := tx.WithContext(ctx).Order(`"name" ASC`).Find(&permissions).Error; err != nil {
+		return []*entity.Permission{}, err
+	}
+
+	return permissions, nil
+}
+func (rr *roleRepository) GetPermissionsByNames(ctx context.Context, tx *gorm.DB, names []string) ([]entity.Permission, error) {
+	if tx == nil {
+		tx = rr.db
+	}
+
+	var permissions []entity.Permission
+	if len(names) == 0 {
+		return permissions, nil
+	}
+
+	if err := tx.WithContext(ctx).Where("name IN ?", names).Find(&permissions).Error; err != nil {
+		return []entity.Permission{}, err
+	}
+
+	return permissions, nil
+}
+func (rr *roleRepository) CountAdminsByRoleName(ctx context.Context, tx *gorm.DB, name string) (int64, error) {
+	if tx == nil {
+		tx = rr.db
+	}
+
+	var count int64
+	err := tx.WithContext(ctx).Model(&entity.Admin{}).Where("role = ?", name).Count(&count).Error
+
+	return count, err
+}
+func (rr *roleRepository) LockAdminIDsByRoleName(ctx context.Context, tx *gorm.DB, name string) ([]string, error) {
+	if tx == nil {
+		tx = rr.db
+	}
+
+	return lockAdminIDsByRoleName(ctx, tx, name)
+}
+
+// UPDATE / PATCH
+func (rr *roleRepository) Update(ctx context.Context, tx *gorm.DB, role *entity.AccessRole) error {
+	if tx == nil {
+		tx = rr.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.AccessRole{}).
+		Where("id = ?", role.ID).
+		Select("Description").
+		Updates(role).Error
+}
+func (rr *roleRepository) ReplacePermissions(ctx context.Context, tx *gorm.DB, role *entity.AccessRole, permissions []entity.Permission) error {
+	if tx == nil {
+		tx = rr.db
+	}
+
+	return tx.WithContext(ctx).Model(role).Association("Permissions").Replace(permissions)
+}
+func (rr *roleRepository) UpdateAdminRole(ctx context.Context, tx *gorm.DB, adminID string, roleName string) (bool, error) {
+	if tx == nil {
+		tx = rr.db
+	}
+
+	result := tx.WithContext(ctx).Model(&entity.Admin{}).
+		Where("id = ?", adminID).
+		Update("role", roleName)
+
+	return result.RowsAffected > 0, result.Error
+}
+
+// DELETE / DELETE
+func (rr *roleRepository) Delete(ctx context.Context, tx *gorm.DB, role *entity.AccessRole) error {
+	if tx == nil {
+		tx = rr.db
+	}
+
+	if err := tx.WithContext(ctx).Model(role).Association("Permissions").Clear(); err != nil {
+		return err
+	}
+
+	// hard delete supaya nama role bisa dipakai lagi (kolom name unique)
+	return tx.WithContext(ctx).Unscoped().Where("id = ?", role.ID).Delete(&entity.AccessRole{}).Error
+}
@@ -22,17 +22,19 @@ type (
 
 		// READ / GET
 		GetByName(ctx context.Context, tx *gorm.DB, name string) (*entity.Ship, bool, error)
-		GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.Ship, error)
+		GetAll(ctx context.Context, tx *gorm.DB, createdBy string) ([]*entity.Ship, error)
 		GetAllWithPagination(ctx context.Context, tx *gorm.DB, req response.PaginationRequest) (dto.ShipPaginationRepositoryResponse, error)
 		GetByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Ship, bool, error)
 		GetImagesByID(ctx context.Context, tx *gorm.DB, id string) ([]*entity.ShipImage, error)
 
 		// UPDATE / PATCH
 		Update(ctx context.Context, tx *gorm.DB, ship *entity.Ship) error
+		UpdateImage(ctx context.Context, tx *gorm.DB, image *entity.ShipImage) error
 
 		// DELETE / DELETE
 		DeleteByID(ctx context.Context, tx *gorm.DB, id string) error
 		DeleteImagesByID(ctx context.Context, tx *gorm.DB, id string) error
+		DeleteImageByID(ctx context.Context, tx *gorm.DB, shipID, imageID string) error
 	}
 
 	shipRepository struct {
@@ -86,7 +88,7 @@ func (pr *shipRepository) GetByName(ctx context.Context, tx *gorm.DB, name strin
 
 	return ship, true, nil
 }
-func (pr *shipRepository) GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.Ship, error) {
+func (pr *shipRepository) GetAll(ctx context.Context, tx *gorm.DB, createdBy string) ([]*entity.Ship, error) {
 	if tx == nil {
 		tx = pr.db
 	}
@@ -96,7 +98,7 @@ func (pr *shipRepository) GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.Sh
 		err   error
 	)
 
-	query := tx.WithContext(ctx).Model(&entity.Ship{}).Preload("Images")
+	query := tx.WithContext(ctx).Model(&entity.Ship{}).Preload("Images", OrderGallery).Scopes(PreloadAuthors, FilterCreatedBy(createdBy))
 	if err := query.Order(`"created_at" DESC`).Find(&ships).Error; err != nil {
 		return []*entity.Ship{}, err
 	}
@@ -120,7 +122,7 @@ func (pr *shipRepository) GetAllWithPagination(ctx context.Context, tx *gorm.DB,
 		req.Page = 1
 	}
 
-	query := tx.WithContext(ctx).Model(&entity.Ship{}).Preload("Images")
+	query := tx.WithContext(ctx).Model(&entity.Ship{}).Preload("Images", OrderGallery).Scopes(PreloadAuthors, FilterCreatedBy(req.CreatedBy))
 
 	if req.Search != "" {
 		searchValue := "%" + strings.ToLower(req.Search) + "%"
@@ -153,7 +155,7 @@ func (pr *shipRepository) GetByID(ctx context.Context, tx *gorm.DB, id string) (
 	}
 
 	var ship *entity.Ship
-	err := tx.WithContext(ctx).Preload("Images").Where("id = ?", id).Take(&ship).Error
+	err := tx.WithContext(ctx).Scopes(PreloadAuthors).Preload("Images", OrderGallery).Where("id = ?", id).Take(&ship).Error
 	if err != nil {
 		return &entity.Ship{}, false, err
 	}
@@ -171,7 +173,8 @@ func (ar *shipRepository) GetImagesByID(ctx context.Context, tx *gorm.DB, id str
 	query := tx.WithContext(ctx).
 		Model(&entity.ShipImage{}).
 		Preload("Ship").
-		Where("ship_id = ?", id)
+		Where("ship_id = ?", id).
+		Scopes(OrderGallery)
 
 	var shipImages []*entity.ShipImage
 	if err := query.Find(&shipImages).Error; err != nil {
@@ -189,6 +192,16 @@ func (pr *shipRepository) Update(ctx context.Context, tx *gorm.DB, ship *entity.
 
 	return tx.WithContext(ctx).Where("id = ?", ship.ID).Updates(&ship).Error
 }
+func (ar *shipRepository) UpdateImage(ctx context.Context, tx *gorm.DB, image *entity.ShipImage) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.ShipImage{}).
+		Where("id = ?", image.ID).
+		Select("Position", "Caption", "Alt", "IsCover").
+		Updates(image).Error
+}
 
 // DELETE / DELETE
 func (pr *shipRepository) DeleteByID(ctx context.Context, tx *gorm.DB, id string) error {
@@ -205,3 +218,10 @@ func (ar *shipRepository) DeleteImagesByID(ctx context.Context, tx *gorm.DB, id
 
 	return tx.WithContext(ctx).Where("ship_id = ?", id).Delete(&entity.ShipImage{}).Error
 }
+func (ar *shipRepository) DeleteImageByID(ctx context.Context, tx *gorm.DB, shipID, imageID string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Where("id = ? AND ship_id = ?", imageID, shipID).Delete(&entity.ShipImage{}).Error
+}
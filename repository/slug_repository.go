@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Amierza/nawasena-backend/constants"
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	ISlugRepository interface {
+		// CREATE / POST
+		CreateRedirect(ctx context.Context, tx *gorm.DB, redirect *entity.SlugRedirect) error
+
+		// READ / GET
+		GetIDBySlug(ctx context.Context, tx *gorm.DB, entityType, slug string) (string, bool, error)
+		GetSlugByID(ctx context.Context, tx *gorm.DB, entityType, id string) (string, bool, error)
+		GetRedirect(ctx context.Context, tx *gorm.DB, entityType, slug string) (*entity.SlugRedirect, bool, error)
+		IsSlugTaken(ctx context.Context, tx *gorm.DB, entityType, slug, excludeID string) (bool, error)
+		GetWithoutSlug(ctx context.Context, tx *gorm.DB, entityType string) ([]dto.SlugTarget, error)
+
+		// UPDATE / PATCH
+		UpdateSlug(ctx context.Context, tx *gorm.DB, entityType, id, slug string) error
+
+		// DELETE / DELETE
+		DeleteRedirect(ctx context.Context, tx *gorm.DB, entityType, slug string) error
+		DeleteRedirectsByTargetID(ctx context.Context, tx *gorm.DB, entityType, targetID string) error
+	}
+
+	slugRepository struct {
+		db *gorm.DB
+	}
+)
+
+// sluggables adalah entity yang punya kolom slug, key-nya sama dengan entity_type di slug_redirects
+var sluggables = map[string]any{
+	constants.ENUM_AUDIT_ENTITY_NEWS:        &entity.News{},
+	constants.ENUM_AUDIT_ENTITY_ACHIEVEMENT: &entity.Achievement{},
+	constants.ENUM_AUDIT_ENTITY_SHIP:        &entity.Ship{},
+	constants.ENUM_AUDIT_ENTITY_COMPETITION: &entity.Competition{},
+}
+
+func NewSlugRepository(db *gorm.DB) *slugRepository {
+	return &slugRepository{
+		db: db,
+	}
+}
+
+func sluggableModel(entityType string) (any, error) {
+	model, ok := sluggables[entityType]
+	if !ok {
+		return nil, fmt.Errorf("entity %q has no slug", entityType)
+	}
+
+	return model, nil
+}
+
+// CREATE / POST
+func (sr *slugRepository) CreateRedirect(ctx context.Context, tx *gorm.DB, redirect *entity.SlugRedirect) error {
+	if tx == nil {
+		tx = sr.db
+	}
+
+	// slug lama yang sudah pernah jadi redirect cukup diarahkan ulang ke target terbaru
+	return tx.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "entity_type"}, {Name: "slug"}},
+		DoUpdates: clause.AssignmentColumns([]string{"target_id"}),
+	}).Create(redirect).Error
+}
+
+// READ / GET
+func (sr *slugRepository) GetIDBySlug(ctx context.Context, tx *gorm.DB, entityType, slug string) (string, bool, error) {
+	if tx == nil {
+		tx = sr.db
+	}
+
+	model, err := sluggableModel(entityType)
+	if err != nil {
+		return "", false, err
+	}
+
+	var ids []string
+	err = tx.WithContext(ctx).Model(model).Where("slug = ?", slug).Limit(1).Pluck("id", &ids).Error
+	if err != nil {
+		return "", false, err
+	}
+	if len(ids) == 0 {
+		return "", false, nil
+	}
+
+	return ids[0], true, nil
+}
+func (sr *slugRepository) GetSlugByID(ctx context.Context, tx *gorm.DB, entityType, id string) (string, bool, error) {
+	if tx == nil {
+		tx = sr.db
+	}
+
+	model, err := sluggableModel(entityType)
+	if err != nil {
+		return "", false, err
+	}
+
+	var slugs []string
+	err = tx.WithContext(ctx).Model(model).Where("id = ? AND slug IS NOT NULL", id).Limit(1).Pluck("slug", &slugs).Error
+	if err != nil {
+		return "", false, err
+	}
+	if len(slugs) == 0 {
+		return "", false, nil
+	}
+
+	return slugs[0], true, nil
+}
+func (sr *slugRepository) GetRedirect(ctx context.Context, tx *gorm.DB, entityType, slug string) (*entity.SlugRedirect, bool, error) {
+	if tx == nil {
+		tx = sr.db
+	}
+
+	var redirect *entity.SlugRedirect
+	err := tx.WithContext(ctx).Where("entity_type = ? AND slug = ?", entityType, slug).Take(&redirect).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.SlugRedirect{}, false, nil
+	}
+	if err != nil {
+		return &entity.SlugRedirect{}, false, err
+	}
+
+	return redirect, true, nil
+}
+func (sr *slugRepository) IsSlugTaken(ctx context.Context, tx *gorm.DB, entityType, slug, excludeID string) (bool, error) {
+	if tx == nil {
+		tx = sr.db
+	}
+
+	model, err := sluggableModel(entityType)
+	if err != nil {
+		return false, err
+	}
+
+	// unscoped karena unique index tetap berlaku untuk row yang sudah di-soft delete
+	query := tx.WithContext(ctx).Unscoped().Model(model).Where("slug = ?", slug)
+	if excludeID != "" {
+		query = query.Where("id <> ?", excludeID)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	// slug lama milik data lain juga tidak boleh dipakai, supaya redirect-nya tidak tertutup
+	query = tx.WithContext(ctx).Model(&entity.SlugRedirect{}).Where("entity_type = ? AND slug = ?", entityType, slug)
+	if excludeID != "" {
+		query = query.Where("target_id <> ?", excludeID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+func (sr *slugRepository) GetWithoutSlug(ctx context.Context, tx *gorm.DB, entityType string) ([]dto.SlugTarget, error) {
+	if tx == nil {
+		tx = sr.db
+	}
+
+	model, err := sluggableModel(entityType)
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []dto.SlugTarget
+	err = tx.WithContext(ctx).Model(model).
+		Where("slug IS NULL OR slug = ''").
+		Order(`"created_at" ASC`).
+		Select("id", "name").
+		Scan(&targets).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return targets, nil
+}
+
+// UPDATE / PATCH
+func (sr *slugRepository) UpdateSlug(ctx context.Context, tx *gorm.DB, entityType, id, slug string) error {
+	if tx == nil {
+		tx = sr.db
+	}
+
+	model, err := sluggableModel(entityType)
+	if err != nil {
+		return err
+	}
+
+	// UpdateColumn supaya updated_at & updated_by tidak berubah, ini bukan editan admin
+	return tx.WithContext(ctx).Model(model).Where("id = ?", id).UpdateColumn("slug", slug).Error
+}
+
+// DELETE / DELETE
+func (sr *slugRepository) DeleteRedirect(ctx context.Context, tx *gorm.DB, entityType, slug string) error {
+	if tx == nil {
+		tx = sr.db
+	}
+
+	return tx.WithContext(ctx).Where("entity_type = ? AND slug = ?", entityType, slug).Delete(&entity.SlugRedirect{}).Error
+}
+func (sr *slugRepository) DeleteRedirectsByTargetID(ctx context.Context, tx *gorm.DB, entityType, targetID string) error {
+	if tx == nil {
+		tx = sr.db
+	}
+
+	return tx.WithContext(ctx).Where("entity_type = ? AND target_id = ?", entityType, targetID).Delete(&entity.SlugRedirect{}).Error
+}
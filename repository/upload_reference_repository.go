@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Amierza/nawasena-backend/entity"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+type (
+	IUploadReferenceRepository interface {
+		// READ / GET
+		GetReferencedNames(ctx context.Context, tx *gorm.DB) ([]string, error)
+	}
+
+	uploadReferenceRepository struct {
+		db *gorm.DB
+	}
+
+	uploadReference struct {
+		model  any
+		column string
+		// array untuk kolom text[] yang berisi banyak nama file (mis. gambar di body Markdown)
+		array bool
+	}
+)
+
+// uploadReferences adalah semua kolom yang menyimpan nama / url file upload.
+// Entity baru yang punya field gambar wajib didaftarkan di sini, kalau tidak filenya ikut terhapus GC
+var uploadReferences = []uploadReference{
+	{model: &entity.NewsImage{}, column: "name"},
+	{model: &entity.NewsRevisionImage{}, column: "name"},
+	{model: &entity.News{}, column: "body_images", array: true},
+	{model: &entity.NewsRevision{}, column: "body_images", array: true},
+	{model: &entity.AchievementImage{}, column: "name"},
+	{model: &entity.ShipImage{}, column: "name"},
+	{model: &entity.CompetitionImage{}, column: "name"},
+	{model: &entity.Partner{}, column: "image"},
+	{model: &entity.Flyer{}, column: "image"},
+	{model: &entity.Member{}, column: "image"},
+	{model: &entity.Admin{}, column: "avatar"},
+}
+
+func NewUploadReferenceRepository(db *gorm.DB) *uploadReferenceRepository {
+	return &uploadReferenceRepository{
+		db: db,
+	}
+}
+
+// READ / GET
+func (urr *uploadReferenceRepository) GetReferencedNames(ctx context.Context, tx *gorm.DB) ([]string, error) {
+	if tx == nil {
+		tx = urr.db
+	}
+
+	// row yang sudah di-soft delete tidak dihitung, filenya dianggap tidak terpakai lagi
+	var names []string
+	for _, ref := range uploadReferences {
+		if ref.array {
+			var arrays []pq.StringArray
+			err := tx.WithContext(ctx).Model(ref.model).
+				Where(ref.column+" IS NOT NULL").
+				Pluck(ref.column, &arrays).Error
+			if err != nil {
+				return nil, err
+			}
+
+			for _, values := range arrays {
+				names = append(names, values...)
+			}
+			continue
+		}
+
+		var values []string
+		err := tx.WithContext(ctx).Model(ref.model).
+			Where(ref.column+" <> ''").
+			Distinct().
+			Pluck(ref.column, &values).Error
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, values...)
+	}
+
+	return names, nil
+}
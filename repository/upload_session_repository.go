@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Amierza/nawasena-backend/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	IUploadSessionRepository interface {
+		// CREATE / POST
+		Create(ctx context.Context, tx *gorm.DB, session *entity.UploadSession) error
+
+		// READ / GET
+		GetByID(ctx context.Context, tx *gorm.DB, id string) (*entity.UploadSession, bool, error)
+		GetExpired(ctx context.Context, tx *gorm.DB, now time.Time) ([]*entity.UploadSession, error)
+
+		// UPDATE / PATCH
+		UpdateOffset(ctx context.Context, tx *gorm.DB, id string, from, to int64, expiresAt time.Time) (bool, error)
+
+		// DELETE / DELETE
+		DeleteByID(ctx context.Context, tx *gorm.DB, id string) error
+	}
+
+	uploadSessionRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewUploadSessionRepository(db *gorm.DB) *uploadSessionRepository {
+	return &uploadSessionRepository{
+		db: db,
+	}
+}
+
+// CREATE / POST
+func (usr *uploadSessionRepository) Create(ctx context.Context, tx *gorm.DB, session *entity.UploadSession) error {
+	if tx == nil {
+		tx = usr.db
+	}
+
+	return tx.WithContext(ctx).Create(&session).Error
+}
+
+// READ / GET
+func (usr *uploadSessionRepository) GetByID(ctx context.Context, tx *gorm.DB, id string) (*entity.UploadSession, bool, error) {
+	if tx == nil {
+		tx = usr.db
+	}
+
+	var session *entity.UploadSession
+	err := tx.WithContext(ctx).Where("id = ?", id).Take(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.UploadSession{}, false, nil
+	}
+	if err != nil {
+		return &entity.UploadSession{}, false, err
+	}
+
+	return session, true, nil
+}
+func (usr *uploadSessionRepository) GetExpired(ctx context.Context, tx *gorm.DB, now time.Time) ([]*entity.UploadSession, error) {
+	if tx == nil {
+		tx = usr.db
+	}
+
+	var sessions []*entity.UploadSession
+	if err := tx.WithContext(ctx).Where("expires_at < ?", now).Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// UPDATE / PATCH
+// UpdateOffset hanya berhasil kalau offset di database masih sama dengan from,
+// jadi dua request chunk yang balapan tidak bisa sama-sama maju
+func (usr *uploadSessionRepository) UpdateOffset(ctx context.Context, tx *gorm.DB, id string, from, to int64, expiresAt time.Time) (bool, error) {
+	if tx == nil {
+		tx = usr.db
+	}
+
+	result := tx.WithContext(ctx).
+		Model(&entity.UploadSession{}).
+		Where("id = ? AND \"offset\" = ?", id, from).
+		Updates(map[string]any{"offset": to, "expires_at": expiresAt})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// DELETE / DELETE
+func (usr *uploadSessionRepository) DeleteByID(ctx context.Context, tx *gorm.DB, id string) error {
+	if tx == nil {
+		tx = usr.db
+	}
+
+	// session hanya data sementara, tidak perlu soft delete
+	return tx.WithContext(ctx).Unscoped().Where("id = ?", id).Delete(&entity.UploadSession{}).Error
+}
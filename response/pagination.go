@@ -2,9 +2,10 @@ package response
 
 type (
 	PaginationRequest struct {
-		Search  string `form:"search"`
-		Page    int    `form:"page"`
-		PerPage int    `form:"per_page"`
+		Search    string `form:"search"`
+		Page      int    `form:"page"`
+		PerPage   int    `form:"per_page"`
+		CreatedBy string `form:"created_by"`
 	}
 
 	PaginationResponse struct {
@@ -1,23 +1,25 @@
 package routes
 
 import (
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/handler"
 	"github.com/Amierza/nawasena-backend/jwt"
 	"github.com/Amierza/nawasena-backend/middleware"
+	"github.com/Amierza/nawasena-backend/service"
 	"github.com/gin-gonic/gin"
 )
 
-func AchievementCategory(route *gin.Engine, achievementCategoryHandler handler.IAchievementCategoryHandler, jwtService jwt.IJWT) {
+func AchievementCategory(route *gin.Engine, achievementCategoryHandler handler.IAchievementCategoryHandler, jwtService jwt.IJWT, authService service.IAuthService) {
 	routes := route.Group("/api/v1/achievement-categories")
 	{
 		routes.GET("", achievementCategoryHandler.GetAll)
 		routes.GET("/:id", achievementCategoryHandler.GetDetail)
 
-		routes.Use(middleware.Authentication(jwtService))
+		routes.Use(middleware.Authentication(jwtService, authService))
 		{
-			routes.POST("", achievementCategoryHandler.Create)
-			routes.PATCH("/:id", achievementCategoryHandler.Update)
-			routes.DELETE("/:id", achievementCategoryHandler.Delete)
+			routes.POST("", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_ACHIEVEMENT_CATEGORIES_WRITE), achievementCategoryHandler.Create)
+			routes.PATCH("/:id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_ACHIEVEMENT_CATEGORIES_WRITE), achievementCategoryHandler.Update)
+			routes.DELETE("/:id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_ACHIEVEMENT_CATEGORIES_DELETE), achievementCategoryHandler.Delete)
 		}
 	}
 }
@@ -1,24 +1,31 @@
 package routes
 
 import (
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/handler"
 	"github.com/Amierza/nawasena-backend/jwt"
 	"github.com/Amierza/nawasena-backend/middleware"
+	"github.com/Amierza/nawasena-backend/service"
 	"github.com/gin-gonic/gin"
 )
 
-func Achievement(route *gin.Engine, achievementHandler handler.IAchievementHandler, jwtService jwt.IJWT) {
+func Achievement(route *gin.Engine, achievementHandler handler.IAchievementHandler, jwtService jwt.IJWT, authService service.IAuthService) {
 	routes := route.Group("/api/v1/achievements")
 	{
 		routes.GET("", achievementHandler.GetAll)
 		routes.GET("/featured", achievementHandler.GetFeatured)
 		routes.GET("/:id", achievementHandler.GetDetail)
 
-		routes.Use(middleware.Authentication(jwtService))
+		routes.Use(middleware.Authentication(jwtService, authService))
 		{
-			routes.POST("", achievementHandler.Create)
-			routes.PATCH("/:id", achievementHandler.Update)
-			routes.DELETE("/:id", achievementHandler.Delete)
+			routes.POST("", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_ACHIEVEMENTS_WRITE), achievementHandler.Create)
+			routes.PATCH("/:id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_ACHIEVEMENTS_WRITE), achievementHandler.Update)
+			routes.DELETE("/:id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_ACHIEVEMENTS_DELETE), achievementHandler.Delete)
+
+			routes.POST("/:id/images", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_ACHIEVEMENTS_WRITE), achievementHandler.AddImage)
+			routes.PUT("/:id/images/order", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_ACHIEVEMENTS_WRITE), achievementHandler.ReorderImages)
+			routes.PATCH("/:id/images/:image_id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_ACHIEVEMENTS_WRITE), achievementHandler.UpdateImage)
+			routes.DELETE("/:id/images/:image_id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_ACHIEVEMENTS_WRITE), achievementHandler.RemoveImage)
 		}
 	}
 }
@@ -1,14 +1,16 @@
 package routes
 
 import (
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/handler"
 	"github.com/Amierza/nawasena-backend/jwt"
 	"github.com/Amierza/nawasena-backend/middleware"
+	"github.com/Amierza/nawasena-backend/service"
 	"github.com/gin-gonic/gin"
 )
 
-func Admin(route *gin.Engine, adminHandler handler.IAdminHandler, jwtService jwt.IJWT) {
-	routes := route.Group("/api/v1/admins").Use(middleware.Authentication(jwtService), middleware.RouteAccessControl(jwtService))
+func Admin(route *gin.Engine, adminHandler handler.IAdminHandler, jwtService jwt.IJWT, authService service.IAuthService) {
+	routes := route.Group("/api/v1/admins").Use(middleware.Authentication(jwtService, authService), middleware.RequirePermission(authService, constants.ENUM_PERMISSION_ADMINS_MANAGE))
 	{
 		routes.POST("", adminHandler.Create)
 		routes.GET("", adminHandler.GetAll)
@@ -16,4 +18,10 @@ func Admin(route *gin.Engine, adminHandler handler.IAdminHandler, jwtService jwt
 		routes.PATCH("/:id", adminHandler.Update)
 		routes.DELETE("/:id", adminHandler.Delete)
 	}
+
+	meRoutes := route.Group("/api/v1/me").Use(middleware.Authentication(jwtService, authService), middleware.RequireSession())
+	{
+		meRoutes.GET("", adminHandler.GetMe)
+		meRoutes.PATCH("", adminHandler.UpdateMe)
+	}
 }
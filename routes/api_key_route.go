@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/Amierza/nawasena-backend/constants"
+	"github.com/Amierza/nawasena-backend/handler"
+	"github.com/Amierza/nawasena-backend/jwt"
+	"github.com/Amierza/nawasena-backend/middleware"
+	"github.com/Amierza/nawasena-backend/service"
+	"github.com/gin-gonic/gin"
+)
+
+func APIKey(route *gin.Engine, apiKeyHandler handler.IAPIKeyHandler, jwtService jwt.IJWT, authService service.IAuthService) {
+	routes := route.Group("/api/v1/api-keys").Use(middleware.Authentication(jwtService, authService), middleware.RequireSession())
+	{
+		routes.POST("", apiKeyHandler.Create)
+		routes.GET("", apiKeyHandler.GetAll)
+		routes.DELETE("/:id", apiKeyHandler.Revoke)
+	}
+
+	adminRoutes := route.Group("/api/v1/admins").Use(middleware.Authentication(jwtService, authService), middleware.RequireSession(), middleware.RequirePermission(authService, constants.ENUM_PERMISSION_ADMINS_MANAGE))
+	{
+		adminRoutes.GET("/:id/api-keys", apiKeyHandler.GetAllByAdmin)
+	}
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/Amierza/nawasena-backend/constants"
+	"github.com/Amierza/nawasena-backend/handler"
+	"github.com/Amierza/nawasena-backend/jwt"
+	"github.com/Amierza/nawasena-backend/middleware"
+	"github.com/Amierza/nawasena-backend/service"
+	"github.com/gin-gonic/gin"
+)
+
+func AuditLog(route *gin.Engine, auditLogHandler handler.IAuditLogHandler, jwtService jwt.IJWT, authService service.IAuthService) {
+	routes := route.Group("/api/v1/audit-logs").Use(middleware.Authentication(jwtService, authService), middleware.RequirePermission(authService, constants.ENUM_PERMISSION_AUDIT_LOGS_READ))
+	{
+		routes.GET("", auditLogHandler.GetAll)
+	}
+}
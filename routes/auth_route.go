@@ -1,15 +1,38 @@
 package routes
 
 import (
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/handler"
 	"github.com/Amierza/nawasena-backend/jwt"
+	"github.com/Amierza/nawasena-backend/middleware"
+	"github.com/Amierza/nawasena-backend/service"
 	"github.com/gin-gonic/gin"
 )
 
-func Auth(route *gin.Engine, authHandler handler.IAuthHandler, jwtService jwt.IJWT) {
+func Auth(route *gin.Engine, authHandler handler.IAuthHandler, jwtService jwt.IJWT, authService service.IAuthService) {
 	routes := route.Group("/api/v1")
 	{
 		routes.POST("/login", authHandler.Login)
+		routes.POST("/login/2fa", authHandler.LoginTwoFactor)
 		routes.POST("/refresh-token", authHandler.RefreshToken)
+		routes.POST("/forgot-password", authHandler.ForgotPassword)
+		routes.POST("/reset-password", authHandler.ResetPassword)
+
+		routes.Use(middleware.Authentication(jwtService, authService), middleware.RequireSession())
+		{
+			routes.POST("/logout", authHandler.Logout)
+			routes.POST("/logout/all", authHandler.LogoutAll)
+			routes.PATCH("/me/password", authHandler.ChangePassword)
+			routes.POST("/me/2fa/enroll", authHandler.EnrollTwoFactor)
+			routes.POST("/me/2fa/confirm", authHandler.ConfirmTwoFactor)
+		}
+	}
+
+	adminRoutes := route.Group("/api/v1/admins").Use(middleware.Authentication(jwtService, authService), middleware.RequirePermission(authService, constants.ENUM_PERMISSION_ADMINS_MANAGE))
+	{
+		adminRoutes.POST("/:id/logout", authHandler.LogoutAdmin)
+		adminRoutes.DELETE("/:id/2fa", authHandler.ResetTwoFactor)
+		adminRoutes.GET("/lockouts", authHandler.GetLoginThrottles)
+		adminRoutes.DELETE("/lockouts/:id", authHandler.ClearLoginThrottle)
 	}
 }
@@ -1,23 +1,30 @@
 package routes
 
 import (
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/handler"
 	"github.com/Amierza/nawasena-backend/jwt"
 	"github.com/Amierza/nawasena-backend/middleware"
+	"github.com/Amierza/nawasena-backend/service"
 	"github.com/gin-gonic/gin"
 )
 
-func Competition(route *gin.Engine, competitionHandler handler.ICompetitionHandler, jwtService jwt.IJWT) {
+func Competition(route *gin.Engine, competitionHandler handler.ICompetitionHandler, jwtService jwt.IJWT, authService service.IAuthService) {
 	routes := route.Group("/api/v1/competitions")
 	{
 		routes.GET("", competitionHandler.GetAll)
 		routes.GET("/:id", competitionHandler.GetDetail)
 
-		routes.Use(middleware.Authentication(jwtService))
+		routes.Use(middleware.Authentication(jwtService, authService))
 		{
-			routes.POST("", competitionHandler.Create)
-			routes.PATCH("/:id", competitionHandler.Update)
-			routes.DELETE("/:id", competitionHandler.Delete)
+			routes.POST("", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_COMPETITIONS_WRITE), competitionHandler.Create)
+			routes.PATCH("/:id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_COMPETITIONS_WRITE), competitionHandler.Update)
+			routes.DELETE("/:id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_COMPETITIONS_DELETE), competitionHandler.Delete)
+
+			routes.POST("/:id/images", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_COMPETITIONS_WRITE), competitionHandler.AddImage)
+			routes.PUT("/:id/images/order", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_COMPETITIONS_WRITE), competitionHandler.ReorderImages)
+			routes.PATCH("/:id/images/:image_id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_COMPETITIONS_WRITE), competitionHandler.UpdateImage)
+			routes.DELETE("/:id/images/:image_id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_COMPETITIONS_WRITE), competitionHandler.RemoveImage)
 		}
 	}
 }
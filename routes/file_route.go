@@ -1,15 +1,20 @@
 package routes
 
 import (
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/handler"
 	"github.com/Amierza/nawasena-backend/jwt"
 	"github.com/Amierza/nawasena-backend/middleware"
+	"github.com/Amierza/nawasena-backend/service"
 	"github.com/gin-gonic/gin"
 )
 
-func File(route *gin.Engine, fileHandler handler.IFileHandler, jwtService jwt.IJWT) {
-	routes := route.Group("/api/v1/uploads", middleware.Authentication(jwtService))
+func File(route *gin.Engine, fileHandler handler.IFileHandler, jwtService jwt.IJWT, authService service.IAuthService) {
+	routes := route.Group("/api/v1/uploads", middleware.Authentication(jwtService, authService))
 	{
-		routes.POST("", fileHandler.Upload)
+		routes.POST("", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_UPLOADS_WRITE), fileHandler.Upload)
 	}
+
+	route.GET("/uploads/*filepath", fileHandler.Serve)
+	route.HEAD("/uploads/*filepath", fileHandler.Serve)
 }
@@ -1,23 +1,25 @@
 package routes
 
 import (
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/handler"
 	"github.com/Amierza/nawasena-backend/jwt"
 	"github.com/Amierza/nawasena-backend/middleware"
+	"github.com/Amierza/nawasena-backend/service"
 	"github.com/gin-gonic/gin"
 )
 
-func Flyer(route *gin.Engine, flyerHandler handler.IFlyerHandler, jwtService jwt.IJWT) {
+func Flyer(route *gin.Engine, flyerHandler handler.IFlyerHandler, jwtService jwt.IJWT, authService service.IAuthService) {
 	routes := route.Group("/api/v1/flyers")
 	{
 		routes.GET("", flyerHandler.GetAll)
 		routes.GET("/:id", flyerHandler.GetDetail)
 
-		routes.Use(middleware.Authentication(jwtService))
+		routes.Use(middleware.Authentication(jwtService, authService))
 		{
-			routes.POST("", flyerHandler.Create)
-			routes.PATCH("/:id", flyerHandler.Update)
-			routes.DELETE("/:id", flyerHandler.Delete)
+			routes.POST("", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_FLYERS_WRITE), flyerHandler.Create)
+			routes.PATCH("/:id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_FLYERS_WRITE), flyerHandler.Update)
+			routes.DELETE("/:id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_FLYERS_DELETE), flyerHandler.Delete)
 		}
 	}
 }
@@ -0,0 +1,10 @@
+package routes
+
+import (
+	"github.com/Amierza/nawasena-backend/handler"
+	"github.com/gin-gonic/gin"
+)
+
+func JWKS(route *gin.Engine, jwksHandler handler.IJWKSHandler) {
+	route.GET("/.well-known/jwks.json", jwksHandler.GetJWKS)
+}
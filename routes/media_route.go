@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/Amierza/nawasena-backend/constants"
+	"github.com/Amierza/nawasena-backend/handler"
+	"github.com/Amierza/nawasena-backend/jwt"
+	"github.com/Amierza/nawasena-backend/middleware"
+	"github.com/Amierza/nawasena-backend/service"
+	"github.com/gin-gonic/gin"
+)
+
+func Media(route *gin.Engine, mediaHandler handler.IMediaHandler, jwtService jwt.IJWT, authService service.IAuthService) {
+	routes := route.Group("/api/v1/media", middleware.Authentication(jwtService, authService))
+	{
+		routes.GET("", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_UPLOADS_WRITE), mediaHandler.GetAll)
+		routes.GET("/:id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_UPLOADS_WRITE), mediaHandler.GetDetail)
+		routes.PATCH("/:id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_UPLOADS_WRITE), mediaHandler.Update)
+		routes.DELETE("/:id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_MEDIA_DELETE), mediaHandler.Delete)
+		routes.POST("/:id/signed-url", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_UPLOADS_WRITE), mediaHandler.SignURL)
+	}
+
+	// unduhan lewat signed url, tanpa login
+	route.GET("/files/:name", mediaHandler.Download)
+	route.HEAD("/files/:name", mediaHandler.Download)
+}
@@ -1,23 +1,25 @@
 package routes
 
 import (
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/handler"
 	"github.com/Amierza/nawasena-backend/jwt"
 	"github.com/Amierza/nawasena-backend/middleware"
+	"github.com/Amierza/nawasena-backend/service"
 	"github.com/gin-gonic/gin"
 )
 
-func Member(route *gin.Engine, memberHandler handler.IMemberHandler, jwt jwt.IJWT) {
+func Member(route *gin.Engine, memberHandler handler.IMemberHandler, jwt jwt.IJWT, authService service.IAuthService) {
 	routes := route.Group("/api/v1/members")
 	{
 		routes.GET("", memberHandler.GetAll)
 		routes.GET("/:id", memberHandler.GetDetail)
 
-		routes.Use(middleware.Authentication(jwt), middleware.RouteAccessControl(jwt))
+		routes.Use(middleware.Authentication(jwt, authService))
 		{
-			routes.POST("", memberHandler.Create)
-			routes.PATCH("/:id", memberHandler.Update)
-			routes.DELETE("/:id", memberHandler.Delete)
+			routes.POST("", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_MEMBERS_WRITE), memberHandler.Create)
+			routes.PATCH("/:id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_MEMBERS_WRITE), memberHandler.Update)
+			routes.DELETE("/:id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_MEMBERS_DELETE), memberHandler.Delete)
 		}
 	}
 }
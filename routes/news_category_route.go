@@ -1,23 +1,25 @@
 package routes
 
 import (
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/handler"
 	"github.com/Amierza/nawasena-backend/jwt"
 	"github.com/Amierza/nawasena-backend/middleware"
+	"github.com/Amierza/nawasena-backend/service"
 	"github.com/gin-gonic/gin"
 )
 
-func NewsCategory(route *gin.Engine, newsCategoryHandler handler.INewsCategoryHandler, jwtService jwt.IJWT) {
+func NewsCategory(route *gin.Engine, newsCategoryHandler handler.INewsCategoryHandler, jwtService jwt.IJWT, authService service.IAuthService) {
 	routes := route.Group("/api/v1/news-categories")
 	{
 		routes.GET("", newsCategoryHandler.GetAll)
 		routes.GET("/:id", newsCategoryHandler.GetDetail)
 
-		routes.Use(middleware.Authentication(jwtService))
+		routes.Use(middleware.Authentication(jwtService, authService))
 		{
-			routes.POST("", newsCategoryHandler.Create)
-			routes.PATCH("/:id", newsCategoryHandler.Update)
-			routes.DELETE("/:id", newsCategoryHandler.Delete)
+			routes.POST("", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_NEWS_CATEGORIES_WRITE), newsCategoryHandler.Create)
+			routes.PATCH("/:id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_NEWS_CATEGORIES_WRITE), newsCategoryHandler.Update)
+			routes.DELETE("/:id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_NEWS_CATEGORIES_DELETE), newsCategoryHandler.Delete)
 		}
 	}
 }
@@ -1,24 +1,42 @@
 package routes
 
 import (
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/handler"
 	"github.com/Amierza/nawasena-backend/jwt"
 	"github.com/Amierza/nawasena-backend/middleware"
+	"github.com/Amierza/nawasena-backend/service"
 	"github.com/gin-gonic/gin"
 )
 
-func News(route *gin.Engine, newsHandler handler.INewsHandler, jwtService jwt.IJWT) {
+func News(route *gin.Engine, newsHandler handler.INewsHandler, jwtService jwt.IJWT, authService service.IAuthService) {
 	routes := route.Group("/api/v1/news")
 	{
 		routes.GET("", newsHandler.GetAll)
 		routes.GET("/featured", newsHandler.GetFeatured)
+		routes.GET("/tags", newsHandler.GetTags)
 		routes.GET("/:id", newsHandler.GetDetail)
+		routes.GET("/:id/related", newsHandler.GetRelated)
 
-		routes.Use(middleware.Authentication(jwtService))
+		routes.Use(middleware.Authentication(jwtService, authService))
 		{
-			routes.POST("", newsHandler.Create)
-			routes.PATCH("/:id", newsHandler.Update)
-			routes.DELETE("/:id", newsHandler.Delete)
+			// versi admin dari list & detail, ikut menampilkan draft, scheduled dan archived
+			routes.GET("/admin", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_NEWS_WRITE), newsHandler.GetAdminAll)
+			routes.GET("/admin/:id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_NEWS_WRITE), newsHandler.GetAdminDetail)
+
+			routes.POST("", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_NEWS_WRITE), newsHandler.Create)
+			routes.PATCH("/:id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_NEWS_WRITE), newsHandler.Update)
+			routes.DELETE("/:id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_NEWS_DELETE), newsHandler.Delete)
+
+			routes.POST("/:id/images", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_NEWS_WRITE), newsHandler.AddImage)
+			routes.PUT("/:id/images/order", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_NEWS_WRITE), newsHandler.ReorderImages)
+			routes.PATCH("/:id/images/:image_id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_NEWS_WRITE), newsHandler.UpdateImage)
+			routes.DELETE("/:id/images/:image_id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_NEWS_WRITE), newsHandler.RemoveImage)
+
+			routes.GET("/:id/revisions", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_NEWS_WRITE), newsHandler.GetRevisions)
+			routes.GET("/:id/revisions/diff", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_NEWS_WRITE), newsHandler.DiffRevisions)
+			routes.GET("/:id/revisions/:rev", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_NEWS_WRITE), newsHandler.GetRevision)
+			routes.POST("/:id/revisions/:rev/restore", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_NEWS_WRITE), newsHandler.RestoreRevision)
 		}
 	}
 }
@@ -1,23 +1,25 @@
 package routes
 
 import (
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/handler"
 	"github.com/Amierza/nawasena-backend/jwt"
 	"github.com/Amierza/nawasena-backend/middleware"
+	"github.com/Amierza/nawasena-backend/service"
 	"github.com/gin-gonic/gin"
 )
 
-func Partner(route *gin.Engine, partnerHandler handler.IPartnerHandler, jwtService jwt.IJWT) {
+func Partner(route *gin.Engine, partnerHandler handler.IPartnerHandler, jwtService jwt.IJWT, authService service.IAuthService) {
 	routes := route.Group("/api/v1/partners")
 	{
 		routes.GET("", partnerHandler.GetAll)
 		routes.GET("/:id", partnerHandler.GetDetail)
 
-		routes.Use(middleware.Authentication(jwtService))
+		routes.Use(middleware.Authentication(jwtService, authService))
 		{
-			routes.POST("", partnerHandler.Create)
-			routes.PATCH("/:id", partnerHandler.Update)
-			routes.DELETE("/:id", partnerHandler.Delete)
+			routes.POST("", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_PARTNERS_WRITE), partnerHandler.Create)
+			routes.PATCH("/:id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_PARTNERS_WRITE), partnerHandler.Update)
+			routes.DELETE("/:id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_PARTNERS_DELETE), partnerHandler.Delete)
 		}
 	}
 }
@@ -1,23 +1,25 @@
 package routes
 
 import (
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/handler"
 	"github.com/Amierza/nawasena-backend/jwt"
 	"github.com/Amierza/nawasena-backend/middleware"
+	"github.com/Amierza/nawasena-backend/service"
 	"github.com/gin-gonic/gin"
 )
 
-func Position(route *gin.Engine, positionHandler handler.IPositionHandler, jwtService jwt.IJWT) {
+func Position(route *gin.Engine, positionHandler handler.IPositionHandler, jwtService jwt.IJWT, authService service.IAuthService) {
 	routes := route.Group("/api/v1/positions")
 	{
 		routes.GET("", positionHandler.GetAll)
 		routes.GET("/:id", positionHandler.GetDetail)
 
-		routes.Use(middleware.Authentication(jwtService), middleware.RouteAccessControl(jwtService))
+		routes.Use(middleware.Authentication(jwtService, authService))
 		{
-			routes.POST("", positionHandler.Create)
-			routes.PATCH("/:id", positionHandler.Update)
-			routes.DELETE("/:id", positionHandler.Delete)
+			routes.POST("", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_POSITIONS_WRITE), positionHandler.Create)
+			routes.PATCH("/:id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_POSITIONS_WRITE), positionHandler.Update)
+			routes.DELETE("/:id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_POSITIONS_DELETE), positionHandler.Delete)
 		}
 	}
 }
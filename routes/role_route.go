@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"github.com/Amierza/nawasena-backend/constants"
+	"github.com/Amierza/nawasena-backend/handler"
+	"github.com/Amierza/nawasena-backend/jwt"
+	"github.com/Amierza/nawasena-backend/middleware"
+	"github.com/Amierza/nawasena-backend/service"
+	"github.com/gin-gonic/gin"
+)
+
+func Role(route *gin.Engine, roleHandler handler.IRoleHandler, jwtService jwt.IJWT, authService service.IAuthService) {
+	routes := route.Group("/api/v1/roles").Use(middleware.Authentication(jwtService, authService), middleware.RequirePermission(authService, constants.ENUM_PERMISSION_ADMINS_MANAGE))
+	{
+		routes.POST("", roleHandler.Create)
+		routes.GET("", roleHandler.GetAll)
+		routes.GET("/:id", roleHandler.GetDetail)
+		routes.PATCH("/:id", roleHandler.Update)
+		routes.DELETE("/:id", roleHandler.Delete)
+	}
+
+	permissionRoutes := route.Group("/api/v1/permissions").Use(middleware.Authentication(jwtService, authService), middleware.RequirePermission(authService, constants.ENUM_PERMISSION_ADMINS_MANAGE))
+	{
+		permissionRoutes.GET("", roleHandler.GetAllPermissions)
+	}
+
+	adminRoutes := route.Group("/api/v1/admins").Use(middleware.Authentication(jwtService, authService), middleware.RequirePermission(authService, constants.ENUM_PERMISSION_ADMINS_MANAGE))
+	{
+		adminRoutes.PATCH("/:id/role", roleHandler.AssignRole)
+	}
+}
@@ -1,23 +1,30 @@
 package routes
 
 import (
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/handler"
 	"github.com/Amierza/nawasena-backend/jwt"
 	"github.com/Amierza/nawasena-backend/middleware"
+	"github.com/Amierza/nawasena-backend/service"
 	"github.com/gin-gonic/gin"
 )
 
-func Ship(route *gin.Engine, shipHandler handler.IShipHandler, jwtService jwt.IJWT) {
+func Ship(route *gin.Engine, shipHandler handler.IShipHandler, jwtService jwt.IJWT, authService service.IAuthService) {
 	routes := route.Group("/api/v1/ships")
 	{
 		routes.GET("", shipHandler.GetAll)
 		routes.GET("/:id", shipHandler.GetDetail)
 
-		routes.Use(middleware.Authentication(jwtService))
+		routes.Use(middleware.Authentication(jwtService, authService))
 		{
-			routes.POST("", shipHandler.Create)
-			routes.PATCH("/:id", shipHandler.Update)
-			routes.DELETE("/:id", shipHandler.Delete)
+			routes.POST("", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_SHIPS_WRITE), shipHandler.Create)
+			routes.PATCH("/:id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_SHIPS_WRITE), shipHandler.Update)
+			routes.DELETE("/:id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_SHIPS_DELETE), shipHandler.Delete)
+
+			routes.POST("/:id/images", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_SHIPS_WRITE), shipHandler.AddImage)
+			routes.PUT("/:id/images/order", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_SHIPS_WRITE), shipHandler.ReorderImages)
+			routes.PATCH("/:id/images/:image_id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_SHIPS_WRITE), shipHandler.UpdateImage)
+			routes.DELETE("/:id/images/:image_id", middleware.RequirePermission(authService, constants.ENUM_PERMISSION_SHIPS_WRITE), shipHandler.RemoveImage)
 		}
 	}
 }
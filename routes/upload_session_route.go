@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/Amierza/nawasena-backend/constants"
+	"github.com/Amierza/nawasena-backend/handler"
+	"github.com/Amierza/nawasena-backend/jwt"
+	"github.com/Amierza/nawasena-backend/middleware"
+	"github.com/Amierza/nawasena-backend/service"
+	"github.com/gin-gonic/gin"
+)
+
+func UploadSession(route *gin.Engine, uploadSessionHandler handler.IUploadSessionHandler, jwtService jwt.IJWT, authService service.IAuthService) {
+	routes := route.Group("/api/v1/uploads/sessions", middleware.Authentication(jwtService, authService), middleware.RequirePermission(authService, constants.ENUM_PERMISSION_UPLOADS_WRITE))
+	{
+		routes.POST("", uploadSessionHandler.Create)
+		routes.GET("/:id", uploadSessionHandler.GetDetail)
+		routes.HEAD("/:id", uploadSessionHandler.GetDetail)
+		routes.PATCH("/:id", uploadSessionHandler.Append)
+		routes.POST("/:id/complete", uploadSessionHandler.Complete)
+		routes.DELETE("/:id", uploadSessionHandler.Abort)
+	}
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/dto"
 	"github.com/Amierza/nawasena-backend/entity"
 	"github.com/Amierza/nawasena-backend/jwt"
@@ -13,7 +14,7 @@ import (
 type (
 	IAchievementCategoryService interface {
 		Create(ctx context.Context, req dto.CreateAchievementCategoryRequest) (dto.AchievementCategoryResponse, error)
-		GetAll(ctx context.Context) ([]dto.AchievementCategoryResponse, error)
+		GetAll(ctx context.Context, createdBy string) ([]dto.AchievementCategoryResponse, error)
 		GetDetail(ctx context.Context, id string) (dto.AchievementCategoryResponse, error)
 		Update(ctx context.Context, req dto.UpdateAchievementCategoryRequest) (dto.AchievementCategoryResponse, error)
 		Delete(ctx context.Context, id string) (dto.AchievementCategoryResponse, error)
@@ -21,13 +22,15 @@ type (
 
 	achievementCategoryService struct {
 		achievementCategoryRepo repository.IAchievementCategoryRepository
+		auditLog                IAuditLogService
 		jwt                     jwt.IJWT
 	}
 )
 
-func NewAchievementCategoryService(achievementCategoryRepo repository.IAchievementCategoryRepository, jwt jwt.IJWT) *achievementCategoryService {
+func NewAchievementCategoryService(achievementCategoryRepo repository.IAchievementCategoryRepository, auditLog IAuditLogService, jwt jwt.IJWT) *achievementCategoryService {
 	return &achievementCategoryService{
 		achievementCategoryRepo: achievementCategoryRepo,
+		auditLog:                auditLog,
 		jwt:                     jwt,
 	}
 }
@@ -58,14 +61,20 @@ func (as *achievementCategoryService) Create(ctx context.Context, req dto.Create
 		return dto.AchievementCategoryResponse{}, dto.ErrCreateAchievementCategory
 	}
 
-	return dto.AchievementCategoryResponse{
-		ID:   achievementCategory.ID.String(),
-		Name: achievementCategory.Name,
-	}, nil
+	res := dto.AchievementCategoryResponse{
+		ID:        achievementCategory.ID.String(),
+		Name:      achievementCategory.Name,
+		CreatedBy: mapAuthor(achievementCategory.CreatedByID, achievementCategory.CreatedBy),
+		UpdatedBy: mapAuthor(achievementCategory.UpdatedByID, achievementCategory.UpdatedBy),
+	}
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_CREATE, constants.ENUM_AUDIT_ENTITY_ACHIEVEMENT_CATEGORY, achievementCategory.ID.String(), nil, res)
+
+	return res, nil
 }
 
-func (as *achievementCategoryService) GetAll(ctx context.Context) ([]dto.AchievementCategoryResponse, error) {
-	achievementCategorys, err := as.achievementCategoryRepo.GetAll(ctx, nil)
+func (as *achievementCategoryService) GetAll(ctx context.Context, createdBy string) ([]dto.AchievementCategoryResponse, error) {
+	achievementCategorys, err := as.achievementCategoryRepo.GetAll(ctx, nil, createdBy)
 	if err != nil {
 		return nil, dto.ErrGetAllAchievementCategory
 	}
@@ -73,8 +82,10 @@ func (as *achievementCategoryService) GetAll(ctx context.Context) ([]dto.Achieve
 	var datas []dto.AchievementCategoryResponse
 	for _, achievementCategory := range achievementCategorys {
 		data := dto.AchievementCategoryResponse{
-			ID:   achievementCategory.ID.String(),
-			Name: achievementCategory.Name,
+			ID:        achievementCategory.ID.String(),
+			Name:      achievementCategory.Name,
+			CreatedBy: mapAuthor(achievementCategory.CreatedByID, achievementCategory.CreatedBy),
+			UpdatedBy: mapAuthor(achievementCategory.UpdatedByID, achievementCategory.UpdatedBy),
 		}
 
 		datas = append(datas, data)
@@ -90,8 +101,10 @@ func (as *achievementCategoryService) GetDetail(ctx context.Context, id string)
 	}
 
 	res := dto.AchievementCategoryResponse{
-		ID:   achievementCategory.ID.String(),
-		Name: achievementCategory.Name,
+		ID:        achievementCategory.ID.String(),
+		Name:      achievementCategory.Name,
+		CreatedBy: mapAuthor(achievementCategory.CreatedByID, achievementCategory.CreatedBy),
+		UpdatedBy: mapAuthor(achievementCategory.UpdatedByID, achievementCategory.UpdatedBy),
 	}
 
 	return res, nil
@@ -107,6 +120,13 @@ func (as *achievementCategoryService) Update(ctx context.Context, req dto.Update
 		return dto.AchievementCategoryResponse{}, dto.ErrAchievementCategoryNotFound
 	}
 
+	before := dto.AchievementCategoryResponse{
+		ID:        achievementCategory.ID.String(),
+		Name:      achievementCategory.Name,
+		CreatedBy: mapAuthor(achievementCategory.CreatedByID, achievementCategory.CreatedBy),
+		UpdatedBy: mapAuthor(achievementCategory.UpdatedByID, achievementCategory.UpdatedBy),
+	}
+
 	// handle name request
 	if req.Name != "" && req.Name != achievementCategory.Name {
 		if len(req.Name) < 3 {
@@ -121,10 +141,16 @@ func (as *achievementCategoryService) Update(ctx context.Context, req dto.Update
 		return dto.AchievementCategoryResponse{}, dto.ErrUpdateAchievementCategory
 	}
 
-	return dto.AchievementCategoryResponse{
-		ID:   achievementCategory.ID.String(),
-		Name: achievementCategory.Name,
-	}, nil
+	res := dto.AchievementCategoryResponse{
+		ID:        achievementCategory.ID.String(),
+		Name:      achievementCategory.Name,
+		CreatedBy: mapAuthor(achievementCategory.CreatedByID, achievementCategory.CreatedBy),
+		UpdatedBy: mapAuthor(achievementCategory.UpdatedByID, achievementCategory.UpdatedBy),
+	}
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_ACHIEVEMENT_CATEGORY, achievementCategory.ID.String(), before, res)
+
+	return res, nil
 }
 
 func (as *achievementCategoryService) Delete(ctx context.Context, id string) (dto.AchievementCategoryResponse, error) {
@@ -143,9 +169,13 @@ func (as *achievementCategoryService) Delete(ctx context.Context, id string) (dt
 	}
 
 	res := dto.AchievementCategoryResponse{
-		ID:   deletedAchievementCategory.ID.String(),
-		Name: deletedAchievementCategory.Name,
+		ID:        deletedAchievementCategory.ID.String(),
+		Name:      deletedAchievementCategory.Name,
+		CreatedBy: mapAuthor(deletedAchievementCategory.CreatedByID, deletedAchievementCategory.CreatedBy),
+		UpdatedBy: mapAuthor(deletedAchievementCategory.UpdatedByID, deletedAchievementCategory.UpdatedBy),
 	}
 
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_DELETE, constants.ENUM_AUDIT_ENTITY_ACHIEVEMENT_CATEGORY, deletedAchievementCategory.ID.String(), res, nil)
+
 	return res, nil
 }
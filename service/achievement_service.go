@@ -2,8 +2,12 @@ package service
 
 import (
 	"context"
+	"log"
+	"slices"
 	"strconv"
+	"strings"
 
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/dto"
 	"github.com/Amierza/nawasena-backend/entity"
 	"github.com/Amierza/nawasena-backend/jwt"
@@ -15,23 +19,33 @@ import (
 type (
 	IAchievementService interface {
 		Create(ctx context.Context, req dto.CreateAchievementRequest) (dto.AchievementResponse, error)
-		GetAll(ctx context.Context) ([]dto.AchievementResponse, error)
+		GetAll(ctx context.Context, createdBy string) ([]dto.AchievementResponse, error)
 		GetAllWithPagination(ctx context.Context, req response.PaginationRequest) (dto.AchievementPaginationResponse, error)
 		GetDetail(ctx context.Context, id string) (dto.AchievementResponse, error)
 		GetFeatured(ctx context.Context, limit string) ([]dto.AchievementResponse, error)
 		Update(ctx context.Context, req dto.UpdateAchievementRequest) (dto.AchievementResponse, error)
 		Delete(ctx context.Context, id string) (dto.AchievementResponse, error)
+		AddImage(ctx context.Context, req dto.AddImageRequest) (dto.AchievementResponse, error)
+		UpdateImage(ctx context.Context, req dto.UpdateImageRequest) (dto.AchievementResponse, error)
+		RemoveImage(ctx context.Context, id, imageID string) (dto.AchievementResponse, error)
+		ReorderImages(ctx context.Context, req dto.ReorderImagesRequest) (dto.AchievementResponse, error)
 	}
 
 	achievementService struct {
 		achievementRepo repository.IAchievementRepository
+		fileService     IFileService
+		slugService     ISlugService
+		auditLog        IAuditLogService
 		jwt             jwt.IJWT
 	}
 )
 
-func NewAchievementService(achievementRepo repository.IAchievementRepository, jwt jwt.IJWT) *achievementService {
+func NewAchievementService(achievementRepo repository.IAchievementRepository, fileService IFileService, slugService ISlugService, auditLog IAuditLogService, jwt jwt.IJWT) *achievementService {
 	return &achievementService{
 		achievementRepo: achievementRepo,
+		fileService:     fileService,
+		slugService:     slugService,
+		auditLog:        auditLog,
 		jwt:             jwt,
 	}
 }
@@ -61,10 +75,16 @@ func (as *achievementService) Create(ctx context.Context, req dto.CreateAchievem
 		return dto.AchievementResponse{}, dto.ErrAchievementAlreadyExists
 	}
 
+	slug, err := as.slugService.Generate(ctx, constants.ENUM_AUDIT_ENTITY_ACHIEVEMENT, req.Name, "")
+	if err != nil {
+		return dto.AchievementResponse{}, err
+	}
+
 	achievementID := uuid.New()
 	achievement := &entity.Achievement{
 		ID:          achievementID,
 		Name:        req.Name,
+		Slug:        slug,
 		Year:        req.Year,
 		Description: req.Description,
 		Location:    req.Location,
@@ -89,28 +109,23 @@ func (as *achievementService) Create(ctx context.Context, req dto.CreateAchievem
 	achievement.AchievementCategoryID = &categoryUUID
 
 	// handle image url
-	var (
-		achievementImages         []*entity.AchievementImage
-		achievementImageResponses []dto.AchievementImageResponse
-	)
+	var achievementImages []entity.AchievementImage
 	if len(req.Images) == 0 {
 		return dto.AchievementResponse{}, dto.ErrEmptyImages
 	}
-	for _, imgName := range req.Images {
-		imgID := uuid.New()
-		// handle entity
-		achievementImages = append(achievementImages, &entity.AchievementImage{
-			ID:            imgID,
+	for _, value := range req.Images {
+		imgName, err := as.fileService.ResolveImage(ctx, value)
+		if err != nil {
+			return dto.AchievementResponse{}, err
+		}
+
+		achievementImages = append(achievementImages, entity.AchievementImage{
+			ID:            uuid.New(),
 			Name:          imgName,
 			AchievementID: &achievementID,
 		})
-
-		// handle response
-		achievementImageResponses = append(achievementImageResponses, dto.AchievementImageResponse{
-			ID:   imgID.String(),
-			Name: imgName,
-		})
 	}
+	arrangeGallery(achievementGalleries(achievementImages))
 
 	err = as.achievementRepo.RunInTransaction(ctx, func(txRepo repository.IAchievementRepository) error {
 		// create achievement
@@ -119,8 +134,8 @@ func (as *achievementService) Create(ctx context.Context, req dto.CreateAchievem
 		}
 
 		// create achievement images
-		for _, img := range achievementImages {
-			if err := txRepo.CreateImage(ctx, nil, img); err != nil {
+		for i := range achievementImages {
+			if err := txRepo.CreateImage(ctx, nil, &achievementImages[i]); err != nil {
 				return dto.ErrCreateAchievementImage
 			}
 		}
@@ -131,9 +146,11 @@ func (as *achievementService) Create(ctx context.Context, req dto.CreateAchievem
 		return dto.AchievementResponse{}, err
 	}
 
-	return dto.AchievementResponse{
+	achievementImageResponses, cover := as.mapAchievementImages(achievementImages)
+	res := dto.AchievementResponse{
 		ID:          achievement.ID.String(),
 		Name:        achievement.Name,
+		Slug:        achievement.Slug,
 		Year:        achievement.Year,
 		Description: achievement.Description,
 		Location:    achievement.Location,
@@ -145,15 +162,22 @@ func (as *achievementService) Create(ctx context.Context, req dto.CreateAchievem
 		Featured:    achievement.Featured,
 		Tags:        achievement.Tags,
 		Images:      achievementImageResponses,
+		Cover:       cover,
 		Category: dto.AchievementCategoryResponse{
 			ID:   achievement.AchievementCategoryID.String(),
 			Name: category.Name,
 		},
-	}, nil
+		CreatedBy: mapAuthor(achievement.CreatedByID, achievement.CreatedBy),
+		UpdatedBy: mapAuthor(achievement.UpdatedByID, achievement.UpdatedBy),
+	}
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_CREATE, constants.ENUM_AUDIT_ENTITY_ACHIEVEMENT, achievement.ID.String(), nil, res)
+
+	return res, nil
 }
 
-func (as *achievementService) GetAll(ctx context.Context) ([]dto.AchievementResponse, error) {
-	achievements, err := as.achievementRepo.GetAll(ctx, nil)
+func (as *achievementService) GetAll(ctx context.Context, createdBy string) ([]dto.AchievementResponse, error) {
+	achievements, err := as.achievementRepo.GetAll(ctx, nil, createdBy)
 	if err != nil {
 		return nil, dto.ErrGetAllAchievementNoPagination
 	}
@@ -163,6 +187,7 @@ func (as *achievementService) GetAll(ctx context.Context) ([]dto.AchievementResp
 		data := dto.AchievementResponse{
 			ID:          achievement.ID.String(),
 			Name:        achievement.Name,
+			Slug:        achievement.Slug,
 			Year:        achievement.Year,
 			Description: achievement.Description,
 			Location:    achievement.Location,
@@ -177,14 +202,11 @@ func (as *achievementService) GetAll(ctx context.Context) ([]dto.AchievementResp
 				ID:   achievement.AchievementCategoryID.String(),
 				Name: achievement.AchievementCategory.Name,
 			},
+			CreatedBy: mapAuthor(achievement.CreatedByID, achievement.CreatedBy),
+			UpdatedBy: mapAuthor(achievement.UpdatedByID, achievement.UpdatedBy),
 		}
 
-		for _, a := range achievement.Images {
-			data.Images = append(data.Images, dto.AchievementImageResponse{
-				ID:   a.ID.String(),
-				Name: a.Name,
-			})
-		}
+		data.Images, data.Cover = as.mapAchievementImages(achievement.Images)
 
 		datas = append(datas, data)
 	}
@@ -203,6 +225,7 @@ func (as *achievementService) GetAllWithPagination(ctx context.Context, req resp
 		data := dto.AchievementResponse{
 			ID:          achievement.ID.String(),
 			Name:        achievement.Name,
+			Slug:        achievement.Slug,
 			Year:        achievement.Year,
 			Description: achievement.Description,
 			Location:    achievement.Location,
@@ -217,14 +240,11 @@ func (as *achievementService) GetAllWithPagination(ctx context.Context, req resp
 				ID:   achievement.AchievementCategoryID.String(),
 				Name: achievement.AchievementCategory.Name,
 			},
+			CreatedBy: mapAuthor(achievement.CreatedByID, achievement.CreatedBy),
+			UpdatedBy: mapAuthor(achievement.UpdatedByID, achievement.UpdatedBy),
 		}
 
-		for _, a := range achievement.Images {
-			data.Images = append(data.Images, dto.AchievementImageResponse{
-				ID:   a.ID.String(),
-				Name: a.Name,
-			})
-		}
+		data.Images, data.Cover = as.mapAchievementImages(achievement.Images)
 
 		datas = append(datas, data)
 	}
@@ -241,6 +261,14 @@ func (as *achievementService) GetAllWithPagination(ctx context.Context, req resp
 }
 
 func (as *achievementService) GetDetail(ctx context.Context, id string) (dto.AchievementResponse, error) {
+	id, err := as.slugService.Resolve(ctx, constants.ENUM_AUDIT_ENTITY_ACHIEVEMENT, id)
+	if err != nil {
+		return dto.AchievementResponse{}, err
+	}
+	if id == "" {
+		return dto.AchievementResponse{}, dto.ErrAchievementNotFound
+	}
+
 	achievement, _, err := as.achievementRepo.GetByID(ctx, nil, id)
 	if err != nil {
 		return dto.AchievementResponse{}, dto.ErrAchievementNotFound
@@ -249,6 +277,7 @@ func (as *achievementService) GetDetail(ctx context.Context, id string) (dto.Ach
 	res := dto.AchievementResponse{
 		ID:          achievement.ID.String(),
 		Name:        achievement.Name,
+		Slug:        achievement.Slug,
 		Year:        achievement.Year,
 		Description: achievement.Description,
 		Location:    achievement.Location,
@@ -263,14 +292,11 @@ func (as *achievementService) GetDetail(ctx context.Context, id string) (dto.Ach
 			ID:   achievement.AchievementCategoryID.String(),
 			Name: achievement.AchievementCategory.Name,
 		},
+		CreatedBy: mapAuthor(achievement.CreatedByID, achievement.CreatedBy),
+		UpdatedBy: mapAuthor(achievement.UpdatedByID, achievement.UpdatedBy),
 	}
 
-	for _, a := range achievement.Images {
-		res.Images = append(res.Images, dto.AchievementImageResponse{
-			ID:   a.ID.String(),
-			Name: a.Name,
-		})
-	}
+	res.Images, res.Cover = as.mapAchievementImages(achievement.Images)
 
 	return res, nil
 }
@@ -295,6 +321,7 @@ func (ns *achievementService) GetFeatured(ctx context.Context, limit string) ([]
 		data := dto.AchievementResponse{
 			ID:          achievement.ID.String(),
 			Name:        achievement.Name,
+			Slug:        achievement.Slug,
 			Year:        achievement.Year,
 			Description: achievement.Description,
 			Location:    achievement.Location,
@@ -309,14 +336,11 @@ func (ns *achievementService) GetFeatured(ctx context.Context, limit string) ([]
 				ID:   achievement.AchievementCategoryID.String(),
 				Name: achievement.AchievementCategory.Name,
 			},
+			CreatedBy: mapAuthor(achievement.CreatedByID, achievement.CreatedBy),
+			UpdatedBy: mapAuthor(achievement.UpdatedByID, achievement.UpdatedBy),
 		}
 
-		for _, a := range achievement.Images {
-			data.Images = append(data.Images, dto.AchievementImageResponse{
-				ID:   a.ID.String(),
-				Name: a.Name,
-			})
-		}
+		data.Images, data.Cover = ns.mapAchievementImages(achievement.Images)
 
 		datas = append(datas, data)
 	}
@@ -334,6 +358,30 @@ func (as *achievementService) Update(ctx context.Context, req dto.UpdateAchievem
 		return dto.AchievementResponse{}, dto.ErrAchievementNotFound
 	}
 
+	before := dto.AchievementResponse{
+		ID:          achievement.ID.String(),
+		Name:        achievement.Name,
+		Slug:        achievement.Slug,
+		Year:        achievement.Year,
+		Description: achievement.Description,
+		Location:    achievement.Location,
+		Rank:        achievement.Rank,
+		Competition: achievement.Competition,
+		Team:        achievement.Team,
+		Impact:      achievement.Impact,
+		VideoURL:    achievement.VideoURL,
+		Featured:    achievement.Featured,
+		Tags:        achievement.Tags,
+		Category: dto.AchievementCategoryResponse{
+			ID:   achievement.AchievementCategoryID.String(),
+			Name: achievement.AchievementCategory.Name,
+		},
+		CreatedBy: mapAuthor(achievement.CreatedByID, achievement.CreatedBy),
+		UpdatedBy: mapAuthor(achievement.UpdatedByID, achievement.UpdatedBy),
+	}
+
+	before.Images, before.Cover = as.mapAchievementImages(achievement.Images)
+
 	// handle name request
 	if req.Name != "" {
 		achievement.Name = req.Name
@@ -394,27 +442,50 @@ func (as *achievementService) Update(ctx context.Context, req dto.UpdateAchievem
 		achievement.AchievementCategoryID = &categoryUUID
 	}
 
+	// slug ikut judul, slug lama disimpan sebagai redirect setelah update berhasil
+	oldSlug := achievement.Slug
+	if achievement.Name != before.Name || achievement.Slug == "" {
+		achievement.Slug, err = as.slugService.Generate(ctx, constants.ENUM_AUDIT_ENTITY_ACHIEVEMENT, achievement.Name, achievement.ID.String())
+		if err != nil {
+			return dto.AchievementResponse{}, err
+		}
+	}
+
 	// handle image url
 	var (
-		achievementImages         []*entity.AchievementImage
-		achievementImageResponses []dto.AchievementImageResponse
+		achievementImages []entity.AchievementImage
+		removedImages     []entity.AchievementImage
 	)
 	if len(req.Images) > 0 {
-		for _, imgName := range req.Images {
-			imgID := uuid.New()
-			// handle entity
-			achievementImages = append(achievementImages, &entity.AchievementImage{
-				ID:            imgID,
-				Name:          imgName,
-				AchievementID: &achievement.ID,
-			})
+		var names, existing []string
+		for _, value := range req.Images {
+			imgName, err := as.fileService.ResolveImage(ctx, value)
+			if err != nil {
+				return dto.AchievementResponse{}, err
+			}
+			names = append(names, imgName)
+		}
+		for _, img := range achievement.Images {
+			existing = append(existing, img.Name)
+		}
 
-			// handle response
-			achievementImageResponses = append(achievementImageResponses, dto.AchievementImageResponse{
-				ID:   imgID.String(),
-				Name: imgName,
+		reuse, removed := planGallerySync(existing, names)
+		for i, name := range names {
+			if reuse[i] >= 0 {
+				achievementImages = append(achievementImages, achievement.Images[reuse[i]])
+				continue
+			}
+
+			achievementImages = append(achievementImages, entity.AchievementImage{
+				ID:            uuid.New(),
+				Name:          name,
+				AchievementID: &achievement.ID,
 			})
 		}
+		for _, index := range removed {
+			removedImages = append(removedImages, achievement.Images[index])
+		}
+		arrangeGallery(achievementGalleries(achievementImages))
 	}
 
 	err = as.achievementRepo.RunInTransaction(ctx, func(txRepo repository.IAchievementRepository) error {
@@ -424,18 +495,15 @@ func (as *achievementService) Update(ctx context.Context, req dto.UpdateAchievem
 		}
 
 		// handle new image
-		if len(req.Name) > 0 {
-			// Delete Existing Achievement Image
-			// in db
-			if err := txRepo.DeleteImagesByID(ctx, nil, achievement.ID.String()); err != nil {
-				return dto.ErrDeleteAchievementImageByAchievementID
+		if len(req.Images) > 0 {
+			for _, img := range removedImages {
+				if err := txRepo.DeleteImageByID(ctx, nil, achievement.ID.String(), img.ID.String()); err != nil {
+					return dto.ErrDeleteImage
+				}
 			}
 
-			// Create new achievement images
-			for _, img := range achievementImages {
-				if err := txRepo.CreateImage(ctx, nil, img); err != nil {
-					return dto.ErrCreateAchievementImage
-				}
+			if err := as.saveAchievementImages(ctx, txRepo, achievementImages); err != nil {
+				return err
 			}
 		}
 
@@ -445,25 +513,18 @@ func (as *achievementService) Update(ctx context.Context, req dto.UpdateAchievem
 		return dto.AchievementResponse{}, err
 	}
 
-	return dto.AchievementResponse{
-		ID:          achievement.ID.String(),
-		Name:        achievement.Name,
-		Year:        achievement.Year,
-		Description: achievement.Description,
-		Location:    achievement.Location,
-		Rank:        achievement.Rank,
-		Competition: achievement.Competition,
-		Team:        achievement.Team,
-		Impact:      achievement.Impact,
-		VideoURL:    achievement.VideoURL,
-		Featured:    achievement.Featured,
-		Tags:        achievement.Tags,
-		Images:      achievementImageResponses,
-		Category: dto.AchievementCategoryResponse{
-			ID:   achievement.AchievementCategoryID.String(),
-			Name: achievement.AchievementCategory.Name,
-		},
-	}, nil
+	if err := as.slugService.Rename(ctx, constants.ENUM_AUDIT_ENTITY_ACHIEVEMENT, achievement.ID.String(), oldSlug, achievement.Slug); err != nil {
+		log.Printf("slug redirect achievement %s: %v", achievement.ID, err)
+	}
+
+	if len(req.Images) > 0 {
+		achievement.Images = achievementImages
+	}
+	res := as.mapAchievementToResponse(achievement)
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_ACHIEVEMENT, achievement.ID.String(), before, res)
+
+	return res, nil
 }
 
 func (as *achievementService) Delete(ctx context.Context, id string) (dto.AchievementResponse, error) {
@@ -493,9 +554,14 @@ func (as *achievementService) Delete(ctx context.Context, id string) (dto.Achiev
 		return dto.AchievementResponse{}, err
 	}
 
+	if err := as.slugService.DeleteRedirects(ctx, constants.ENUM_AUDIT_ENTITY_ACHIEVEMENT, id); err != nil {
+		log.Printf("slug redirect achievement %s: %v", id, err)
+	}
+
 	res := dto.AchievementResponse{
 		ID:          deletedAchievement.ID.String(),
 		Name:        deletedAchievement.Name,
+		Slug:        deletedAchievement.Slug,
 		Year:        deletedAchievement.Year,
 		Description: deletedAchievement.Description,
 		Location:    deletedAchievement.Location,
@@ -510,14 +576,282 @@ func (as *achievementService) Delete(ctx context.Context, id string) (dto.Achiev
 			ID:   deletedAchievement.AchievementCategoryID.String(),
 			Name: deletedAchievement.AchievementCategory.Name,
 		},
+		CreatedBy: mapAuthor(deletedAchievement.CreatedByID, deletedAchievement.CreatedBy),
+		UpdatedBy: mapAuthor(deletedAchievement.UpdatedByID, deletedAchievement.UpdatedBy),
 	}
 
-	for _, a := range deletedAchievement.Images {
-		res.Images = append(res.Images, dto.AchievementImageResponse{
-			ID:   a.ID.String(),
-			Name: a.Name,
-		})
+	res.Images, res.Cover = as.mapAchievementImages(deletedAchievement.Images)
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_DELETE, constants.ENUM_AUDIT_ENTITY_ACHIEVEMENT, deletedAchievement.ID.String(), res, nil)
+
+	return res, nil
+}
+
+func (as *achievementService) AddImage(ctx context.Context, req dto.AddImageRequest) (dto.AchievementResponse, error) {
+	achievement, found, err := as.achievementRepo.GetByID(ctx, nil, req.ParentID)
+	if err != nil {
+		return dto.AchievementResponse{}, dto.ErrGetAchievementByID
+	}
+	if !found {
+		return dto.AchievementResponse{}, dto.ErrAchievementNotFound
+	}
+
+	before := as.mapAchievementToResponse(achievement)
+
+	// handle image request
+	if strings.TrimSpace(req.Image) == "" {
+		return dto.AchievementResponse{}, dto.ErrEmptyImage
+	}
+	imgName, err := as.fileService.ResolveImage(ctx, strings.TrimSpace(req.Image))
+	if err != nil {
+		return dto.AchievementResponse{}, err
+	}
+
+	caption, alt, err := validateGalleryText(req.Caption, req.Alt)
+	if err != nil {
+		return dto.AchievementResponse{}, err
+	}
+
+	index, err := galleryInsertIndex(req.Position, len(achievement.Images))
+	if err != nil {
+		return dto.AchievementResponse{}, err
+	}
+
+	achievement.Images = slices.Insert(achievement.Images, index, entity.AchievementImage{
+		ID:            uuid.New(),
+		Name:          imgName,
+		AchievementID: &achievement.ID,
+		Gallery:       entity.Gallery{Caption: caption, Alt: alt},
+	})
+	galleries := achievementGalleries(achievement.Images)
+	if req.IsCover {
+		setGalleryCover(galleries, index)
+	}
+	arrangeGallery(galleries)
+
+	err = as.achievementRepo.RunInTransaction(ctx, func(txRepo repository.IAchievementRepository) error {
+		return as.saveAchievementImages(ctx, txRepo, achievement.Images)
+	})
+	if err != nil {
+		return dto.AchievementResponse{}, err
 	}
 
+	res := as.mapAchievementToResponse(achievement)
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_ACHIEVEMENT, achievement.ID.String(), before, res)
+
 	return res, nil
 }
+
+func (as *achievementService) UpdateImage(ctx context.Context, req dto.UpdateImageRequest) (dto.AchievementResponse, error) {
+	achievement, found, err := as.achievementRepo.GetByID(ctx, nil, req.ParentID)
+	if err != nil {
+		return dto.AchievementResponse{}, dto.ErrGetAchievementByID
+	}
+	if !found {
+		return dto.AchievementResponse{}, dto.ErrAchievementNotFound
+	}
+
+	index := slices.IndexFunc(achievement.Images, func(img entity.AchievementImage) bool { return img.ID.String() == req.ImageID })
+	if index == -1 {
+		return dto.AchievementResponse{}, dto.ErrImageNotFound
+	}
+
+	before := as.mapAchievementToResponse(achievement)
+	image := &achievement.Images[index]
+
+	// handle caption & alt request, string kosong berarti dihapus
+	caption, alt := image.Caption, image.Alt
+	if req.Caption != nil {
+		caption = *req.Caption
+	}
+	if req.Alt != nil {
+		alt = *req.Alt
+	}
+	image.Caption, image.Alt, err = validateGalleryText(caption, alt)
+	if err != nil {
+		return dto.AchievementResponse{}, err
+	}
+
+	// handle cover request
+	galleries := achievementGalleries(achievement.Images)
+	if req.IsCover != nil {
+		if *req.IsCover {
+			setGalleryCover(galleries, index)
+		} else {
+			unsetGalleryCover(galleries, index)
+		}
+	}
+	arrangeGallery(galleries)
+
+	err = as.achievementRepo.RunInTransaction(ctx, func(txRepo repository.IAchievementRepository) error {
+		return as.saveAchievementImages(ctx, txRepo, achievement.Images)
+	})
+	if err != nil {
+		return dto.AchievementResponse{}, err
+	}
+
+	res := as.mapAchievementToResponse(achievement)
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_ACHIEVEMENT, achievement.ID.String(), before, res)
+
+	return res, nil
+}
+
+func (as *achievementService) RemoveImage(ctx context.Context, id, imageID string) (dto.AchievementResponse, error) {
+	achievement, found, err := as.achievementRepo.GetByID(ctx, nil, id)
+	if err != nil {
+		return dto.AchievementResponse{}, dto.ErrGetAchievementByID
+	}
+	if !found {
+		return dto.AchievementResponse{}, dto.ErrAchievementNotFound
+	}
+
+	index := slices.IndexFunc(achievement.Images, func(img entity.AchievementImage) bool { return img.ID.String() == imageID })
+	if index == -1 {
+		return dto.AchievementResponse{}, dto.ErrImageNotFound
+	}
+	if len(achievement.Images) == 1 {
+		return dto.AchievementResponse{}, dto.ErrLastImage
+	}
+
+	before := as.mapAchievementToResponse(achievement)
+
+	achievement.Images = slices.Delete(achievement.Images, index, index+1)
+	arrangeGallery(achievementGalleries(achievement.Images))
+
+	err = as.achievementRepo.RunInTransaction(ctx, func(txRepo repository.IAchievementRepository) error {
+		if err := txRepo.DeleteImageByID(ctx, nil, achievement.ID.String(), imageID); err != nil {
+			return dto.ErrDeleteImage
+		}
+
+		return as.saveAchievementImages(ctx, txRepo, achievement.Images)
+	})
+	if err != nil {
+		return dto.AchievementResponse{}, err
+	}
+
+	res := as.mapAchievementToResponse(achievement)
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_ACHIEVEMENT, achievement.ID.String(), before, res)
+
+	return res, nil
+}
+
+func (as *achievementService) ReorderImages(ctx context.Context, req dto.ReorderImagesRequest) (dto.AchievementResponse, error) {
+	achievement, found, err := as.achievementRepo.GetByID(ctx, nil, req.ParentID)
+	if err != nil {
+		return dto.AchievementResponse{}, dto.ErrGetAchievementByID
+	}
+	if !found {
+		return dto.AchievementResponse{}, dto.ErrAchievementNotFound
+	}
+
+	var ids []uuid.UUID
+	for _, img := range achievement.Images {
+		ids = append(ids, img.ID)
+	}
+	order, err := galleryOrder(ids, req.ImageIDs)
+	if err != nil {
+		return dto.AchievementResponse{}, err
+	}
+
+	before := as.mapAchievementToResponse(achievement)
+
+	var images []entity.AchievementImage
+	for _, index := range order {
+		images = append(images, achievement.Images[index])
+	}
+	achievement.Images = images
+	arrangeGallery(achievementGalleries(achievement.Images))
+
+	err = as.achievementRepo.RunInTransaction(ctx, func(txRepo repository.IAchievementRepository) error {
+		return as.saveAchievementImages(ctx, txRepo, achievement.Images)
+	})
+	if err != nil {
+		return dto.AchievementResponse{}, err
+	}
+
+	res := as.mapAchievementToResponse(achievement)
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_ACHIEVEMENT, achievement.ID.String(), before, res)
+
+	return res, nil
+}
+
+func (as *achievementService) saveAchievementImages(ctx context.Context, txRepo repository.IAchievementRepository, images []entity.AchievementImage) error {
+	for i := range images {
+		if images[i].CreatedAt.IsZero() {
+			if err := txRepo.CreateImage(ctx, nil, &images[i]); err != nil {
+				return dto.ErrCreateAchievementImage
+			}
+			continue
+		}
+
+		if err := txRepo.UpdateImage(ctx, nil, &images[i]); err != nil {
+			return dto.ErrUpdateImage
+		}
+	}
+
+	return nil
+}
+
+func (as *achievementService) mapAchievementToResponse(achievement *entity.Achievement) dto.AchievementResponse {
+	res := dto.AchievementResponse{
+		ID:          achievement.ID.String(),
+		Name:        achievement.Name,
+		Slug:        achievement.Slug,
+		Year:        achievement.Year,
+		Description: achievement.Description,
+		Location:    achievement.Location,
+		Rank:        achievement.Rank,
+		Competition: achievement.Competition,
+		Team:        achievement.Team,
+		Impact:      achievement.Impact,
+		VideoURL:    achievement.VideoURL,
+		Featured:    achievement.Featured,
+		Tags:        achievement.Tags,
+		Category: dto.AchievementCategoryResponse{
+			ID:   achievement.AchievementCategoryID.String(),
+			Name: achievement.AchievementCategory.Name,
+		},
+		CreatedBy: mapAuthor(achievement.CreatedByID, achievement.CreatedBy),
+		UpdatedBy: mapAuthor(achievement.UpdatedByID, achievement.UpdatedBy),
+	}
+	res.Images, res.Cover = as.mapAchievementImages(achievement.Images)
+
+	return res
+}
+
+func (as *achievementService) mapAchievementImages(images []entity.AchievementImage) ([]dto.AchievementImageResponse, *dto.AchievementImageResponse) {
+	datas := []dto.AchievementImageResponse{}
+	for _, img := range images {
+		datas = append(datas, dto.AchievementImageResponse{
+			ID:       img.ID.String(),
+			Name:     img.Name,
+			Position: img.Position,
+			Caption:  img.Caption,
+			Alt:      img.Alt,
+			IsCover:  img.IsCover,
+			Sources:  as.fileService.ImageSources(img.Name),
+		})
+	}
+
+	cover := galleryCoverIndex(achievementGalleries(images))
+	if cover == -1 {
+		return datas, nil
+	}
+	datas[cover].IsCover = true
+	res := datas[cover]
+
+	return datas, &res
+}
+
+func achievementGalleries(images []entity.AchievementImage) []*entity.Gallery {
+	galleries := make([]*entity.Gallery, len(images))
+	for i := range images {
+		galleries[i] = &images[i].Gallery
+	}
+
+	return galleries
+}
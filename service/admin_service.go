@@ -2,7 +2,11 @@ package service
 
 import (
 	"context"
+	"mime/multipart"
+	"net/url"
+	"strings"
 
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/dto"
 	"github.com/Amierza/nawasena-backend/entity"
 	"github.com/Amierza/nawasena-backend/helper"
@@ -19,19 +23,29 @@ type (
 		GetAllWithPagination(ctx context.Context, req response.PaginationRequest) (dto.AdminPaginationResponse, error)
 		GetDetail(ctx context.Context, id string) (dto.AdminResponse, error)
 		Update(ctx context.Context, req dto.UpdateAdminRequest) (dto.AdminResponse, error)
-		Delete(ctx context.Context, id string) (dto.AdminResponse, error)
+		Delete(ctx context.Context, id, actorID string) (dto.AdminResponse, error)
+		GetMe(ctx context.Context, adminID string) (dto.MeResponse, error)
+		UpdateMe(ctx context.Context, req dto.UpdateMeRequest) (dto.MeResponse, error)
 	}
 
 	adminService struct {
-		adminRepo repository.IAdminRepository
-		jwt       jwt.IJWT
+		adminRepo   repository.IAdminRepository
+		mediaRepo   repository.IMediaRepository
+		fileService IFileService
+		authService IAuthService
+		auditLog    IAuditLogService
+		jwt         jwt.IJWT
 	}
 )
 
-func NewAdminService(adminRepo repository.IAdminRepository, jwt jwt.IJWT) *adminService {
+func NewAdminService(adminRepo repository.IAdminRepository, mediaRepo repository.IMediaRepository, fileService IFileService, authService IAuthService, auditLog IAuditLogService, jwt jwt.IJWT) *adminService {
 	return &adminService{
-		adminRepo: adminRepo,
-		jwt:       jwt,
+		adminRepo:   adminRepo,
+		mediaRepo:   mediaRepo,
+		fileService: fileService,
+		authService: authService,
+		auditLog:    auditLog,
+		jwt:         jwt,
 	}
 }
 
@@ -85,14 +99,19 @@ func (as *adminService) Create(ctx context.Context, req dto.CreateAdminRequest)
 		return dto.AdminResponse{}, dto.ErrCreateAdmin
 	}
 
-	return dto.AdminResponse{
+	res := dto.AdminResponse{
 		ID:          admin.ID.String(),
 		Name:        admin.Name,
 		Email:       admin.Email,
 		Password:    admin.Password,
 		Role:        admin.Role,
 		PhoneNumber: admin.PhoneNumber,
-	}, nil
+		Avatar:      admin.Avatar,
+	}
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_CREATE, constants.ENUM_AUDIT_ENTITY_ADMIN, admin.ID.String(), nil, res)
+
+	return res, nil
 }
 
 func (as *adminService) GetAll(ctx context.Context) ([]dto.AdminResponse, error) {
@@ -110,6 +129,7 @@ func (as *adminService) GetAll(ctx context.Context) ([]dto.AdminResponse, error)
 			Password:    admin.Password,
 			Role:        admin.Role,
 			PhoneNumber: admin.PhoneNumber,
+			Avatar:      admin.Avatar,
 		}
 
 		datas = append(datas, data)
@@ -133,6 +153,7 @@ func (as *adminService) GetAllWithPagination(ctx context.Context, req response.P
 			Password:    admin.Password,
 			Role:        admin.Role,
 			PhoneNumber: admin.PhoneNumber,
+			Avatar:      admin.Avatar,
 		}
 
 		datas = append(datas, data)
@@ -162,6 +183,7 @@ func (as *adminService) GetDetail(ctx context.Context, id string) (dto.AdminResp
 		Password:    admin.Password,
 		Role:        admin.Role,
 		PhoneNumber: admin.PhoneNumber,
+		Avatar:      admin.Avatar,
 	}, nil
 }
 
@@ -172,6 +194,16 @@ func (as *adminService) Update(ctx context.Context, req dto.UpdateAdminRequest)
 		return dto.AdminResponse{}, dto.ErrAdminNotFound
 	}
 
+	before := dto.AdminResponse{
+		ID:          admin.ID.String(),
+		Name:        admin.Name,
+		Email:       admin.Email,
+		Password:    admin.Password,
+		Role:        admin.Role,
+		PhoneNumber: admin.PhoneNumber,
+		Avatar:      admin.Avatar,
+	}
+
 	// handle name request
 	if req.Name != "" && req.Name != admin.Name {
 		if len(req.Name) < 3 {
@@ -195,18 +227,9 @@ func (as *adminService) Update(ctx context.Context, req dto.UpdateAdminRequest)
 		admin.Email = req.Email
 	}
 
-	// handle password request
-	if req.Password != "" {
-		if len(req.Password) < 8 {
-			return dto.AdminResponse{}, dto.ErrInvalidPassword
-		}
-
-		hashP, err := helper.HashPassword(req.Password)
-		if err != nil {
-			return dto.AdminResponse{}, dto.ErrHashPassword
-		}
-
-		admin.Password = hashP
+	// handle password request, disimpan lewat auth service setelah update supaya session ikut dicabut
+	if req.Password != "" && len(req.Password) < 8 {
+		return dto.AdminResponse{}, dto.ErrInvalidPassword
 	}
 
 	// handle phone number request
@@ -224,6 +247,15 @@ func (as *adminService) Update(ctx context.Context, req dto.UpdateAdminRequest)
 		return dto.AdminResponse{}, dto.ErrUpdateAdmin
 	}
 
+	if req.Password != "" {
+		if err := as.authService.SetPassword(ctx, admin.ID.String(), req.Password); err != nil {
+			return dto.AdminResponse{}, err
+		}
+		if updated, found, err := as.adminRepo.GetByID(ctx, nil, admin.ID.String()); err == nil && found {
+			admin.Password = updated.Password
+		}
+	}
+
 	res := dto.AdminResponse{
 		ID:          admin.ID.String(),
 		Name:        admin.Name,
@@ -231,22 +263,46 @@ func (as *adminService) Update(ctx context.Context, req dto.UpdateAdminRequest)
 		Password:    admin.Password,
 		Role:        admin.Role,
 		PhoneNumber: admin.PhoneNumber,
+		Avatar:      admin.Avatar,
 	}
 
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_ADMIN, admin.ID.String(), before, res)
+
 	return res, nil
 }
-func (as *adminService) Delete(ctx context.Context, id string) (dto.AdminResponse, error) {
-	deletedAdmin, found, err := as.adminRepo.GetByID(ctx, nil, id)
-	if err != nil {
-		return dto.AdminResponse{}, dto.ErrAdminNotFound
-	}
-	if !found {
-		return dto.AdminResponse{}, dto.ErrAdminNotFound
+func (as *adminService) Delete(ctx context.Context, id, actorID string) (dto.AdminResponse, error) {
+	// admin tidak boleh menghapus akunnya sendiri
+	if id == actorID {
+		return dto.AdminResponse{}, dto.ErrDeleteOwnAccount
 	}
 
-	err = as.adminRepo.DeleteByID(ctx, nil, id)
+	var deletedAdmin *entity.Admin
+	err := as.adminRepo.RunInTransaction(ctx, func(txRepo repository.IAdminRepository) error {
+		admin, found, err := txRepo.GetByID(ctx, nil, id)
+		if err != nil || !found {
+			return dto.ErrAdminNotFound
+		}
+
+		// super admin terakhir tidak boleh dihapus, kalau tidak tidak ada lagi yang bisa mengatur role
+		if admin.Role == constants.ENUM_ROLE_SUPER_ADMIN {
+			superAdmins, err := txRepo.LockAdminIDsByRoleName(ctx, nil, constants.ENUM_ROLE_SUPER_ADMIN)
+			if err != nil {
+				return dto.ErrDeleteAdminByID
+			}
+			if len(superAdmins) == 1 && superAdmins[0] == admin.ID.String() {
+				return dto.ErrDeleteLastSuperAdmin
+			}
+		}
+
+		if err := txRepo.DeleteByID(ctx, nil, id); err != nil {
+			return dto.ErrDeleteAdminByID
+		}
+
+		deletedAdmin = admin
+		return nil
+	})
 	if err != nil {
-		return dto.AdminResponse{}, dto.ErrDeleteAdminByID
+		return dto.AdminResponse{}, err
 	}
 
 	res := dto.AdminResponse{
@@ -256,6 +312,144 @@ func (as *adminService) Delete(ctx context.Context, id string) (dto.AdminRespons
 		Password:    deletedAdmin.Password,
 		Role:        deletedAdmin.Role,
 		PhoneNumber: deletedAdmin.PhoneNumber,
+		Avatar:      deletedAdmin.Avatar,
+	}
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_DELETE, constants.ENUM_AUDIT_ENTITY_ADMIN, deletedAdmin.ID.String(), res, nil)
+
+	return res, nil
+}
+
+func (as *adminService) GetMe(ctx context.Context, adminID string) (dto.MeResponse, error) {
+	admin, found, err := as.adminRepo.GetByID(ctx, nil, adminID)
+	if err != nil || !found {
+		return dto.MeResponse{}, dto.ErrAdminNotFound
+	}
+
+	return as.mapMeResponse(ctx, admin)
+}
+
+func (as *adminService) UpdateMe(ctx context.Context, req dto.UpdateMeRequest) (dto.MeResponse, error) {
+	admin, found, err := as.adminRepo.GetByID(ctx, nil, req.ID)
+	if err != nil || !found {
+		return dto.MeResponse{}, dto.ErrAdminNotFound
+	}
+
+	before := dto.AdminResponse{
+		ID:          admin.ID.String(),
+		Name:        admin.Name,
+		Email:       admin.Email,
+		Role:        admin.Role,
+		PhoneNumber: admin.PhoneNumber,
+		Avatar:      admin.Avatar,
+	}
+
+	// handle name request
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name != "" && req.Name != admin.Name {
+		if len(req.Name) < 3 {
+			return dto.MeResponse{}, dto.ErrNameTooShort
+		}
+
+		admin.Name = req.Name
+	}
+
+	// handle phone number request
+	if req.PhoneNumber != "" {
+		formattedPhoneNumber, err := helper.StandardizePhoneNumber(req.PhoneNumber)
+		if err != nil {
+			return dto.MeResponse{}, dto.ErrFormatPhoneNumber
+		}
+
+		admin.PhoneNumber = formattedPhoneNumber
+	}
+
+	// handle avatar request, file baru di-upload lewat file service, atau nama file hasil /api/v1/uploads
+	if req.AvatarFile != nil {
+		uploaded, err := as.fileService.Upload(ctx, []*multipart.FileHeader{req.AvatarFile}, constants.ENUM_VISIBILITY_PUBLIC)
+		if err != nil {
+			return dto.MeResponse{}, err
+		}
+		if len(uploaded.Errors) > 0 {
+			return dto.MeResponse{}, uploaded.Errors[0]
+		}
+
+		admin.Avatar = uploaded.Files[0].Name
+	} else if req.Avatar != "" && req.Avatar != admin.Avatar {
+		avatar, err := as.resolveAvatar(ctx, req.Avatar)
+		if err != nil {
+			return dto.MeResponse{}, err
+		}
+
+		admin.Avatar = avatar
+	}
+
+	if err := as.adminRepo.Update(ctx, nil, admin); err != nil {
+		return dto.MeResponse{}, dto.ErrUpdateAdmin
+	}
+
+	after := before
+	after.Name = admin.Name
+	after.PhoneNumber = admin.PhoneNumber
+	after.Avatar = admin.Avatar
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_ADMIN, admin.ID.String(), before, after)
+
+	return as.mapMeResponse(ctx, admin)
+}
+
+// resolveAvatar: avatar hanya boleh id / nama media public yang ada di tabel media, atau url http(s)
+func (as *adminService) resolveAvatar(ctx context.Context, value string) (string, error) {
+	name, err := as.fileService.ResolveImage(ctx, value)
+	if err != nil {
+		return "", err
+	}
+	if name != value {
+		return name, nil
+	}
+
+	// javascript:, data: dan skema lain ditolak
+	if strings.Contains(value, ":") {
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "", dto.ErrFormatAvatar
+		}
+
+		return value, nil
+	}
+
+	media, found, err := as.mediaRepo.GetByName(ctx, nil, value)
+	if err != nil {
+		return "", dto.ErrGetMediaByName
+	}
+	if !found {
+		return "", dto.ErrFormatAvatar
+	}
+	if media.Visibility == constants.ENUM_VISIBILITY_PRIVATE {
+		return "", dto.ErrPrivateMedia
+	}
+
+	return media.Name, nil
+}
+
+func (as *adminService) mapMeResponse(ctx context.Context, admin *entity.Admin) (dto.MeResponse, error) {
+	permissions, err := as.authService.GetPermissions(ctx, admin.ID.String())
+	if err != nil {
+		return dto.MeResponse{}, err
+	}
+
+	res := dto.MeResponse{
+		ID:               admin.ID.String(),
+		Name:             admin.Name,
+		Email:            admin.Email,
+		PhoneNumber:      admin.PhoneNumber,
+		Avatar:           admin.Avatar,
+		Role:             admin.Role,
+		Permissions:      permissions,
+		TwoFactorEnabled: admin.TwoFactorEnabled,
+		LastLoginIP:      admin.LastLoginIP,
+	}
+	if admin.LastLoginAt != nil {
+		res.LastLoginAt = admin.LastLoginAt.String()
 	}
 
 	return res, nil
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Amierza/nawasena-backend/constants"
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/entity"
+	"github.com/Amierza/nawasena-backend/helper"
+	"github.com/Amierza/nawasena-backend/repository"
+	"github.com/google/uuid"
+)
+
+type (
+	IAPIKeyService interface {
+		Create(ctx context.Context, req dto.CreateAPIKeyRequest) (dto.CreateAPIKeyResponse, error)
+		GetAll(ctx context.Context, adminID string) ([]dto.APIKeyResponse, error)
+		Revoke(ctx context.Context, req dto.RevokeAPIKeyRequest) (dto.APIKeyResponse, error)
+	}
+
+	apiKeyService struct {
+		apiKeyRepo  repository.IAPIKeyRepository
+		authService IAuthService
+		auditLog    IAuditLogService
+	}
+)
+
+func NewAPIKeyService(apiKeyRepo repository.IAPIKeyRepository, authService IAuthService, auditLog IAuditLogService) *apiKeyService {
+	return &apiKeyService{
+		apiKeyRepo:  apiKeyRepo,
+		authService: authService,
+		auditLog:    auditLog,
+	}
+}
+
+func (aks *apiKeyService) Create(ctx context.Context, req dto.CreateAPIKeyRequest) (dto.CreateAPIKeyResponse, error) {
+	adminID, err := uuid.Parse(req.AdminID)
+	if err != nil {
+		return dto.CreateAPIKeyResponse{}, dto.ErrParseUUID
+	}
+
+	// handle name request
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return dto.CreateAPIKeyResponse{}, dto.ErrEmptyName
+	}
+	if len(req.Name) < 3 {
+		return dto.CreateAPIKeyResponse{}, dto.ErrNameTooShort
+	}
+
+	// handle scopes request, api key tidak boleh punya akses melebihi pemiliknya
+	if len(req.Scopes) == 0 {
+		return dto.CreateAPIKeyResponse{}, dto.ErrEmptyAPIKeyScopes
+	}
+	owned, err := aks.authService.GetPermissions(ctx, req.AdminID)
+	if err != nil {
+		return dto.CreateAPIKeyResponse{}, err
+	}
+	var scopes []string
+	for _, scope := range req.Scopes {
+		scope = strings.TrimSpace(scope)
+		if !slices.Contains(owned, scope) {
+			return dto.CreateAPIKeyResponse{}, fmt.Errorf("%w: %s", dto.ErrInvalidAPIKeyScope, scope)
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	// handle expires at request
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return dto.CreateAPIKeyResponse{}, dto.ErrInvalidAPIKeyExpiry
+	}
+
+	prefixID, err := helper.GenerateRandomToken(4)
+	if err != nil {
+		return dto.CreateAPIKeyResponse{}, dto.ErrGenerateAPIKey
+	}
+	secret, err := helper.GenerateRandomToken(24)
+	if err != nil {
+		return dto.CreateAPIKeyResponse{}, dto.ErrGenerateAPIKey
+	}
+
+	prefix := constants.ENUM_API_KEY_PREFIX + prefixID
+	key := prefix + "_" + secret
+
+	apiKey := &entity.APIKey{
+		ID:        uuid.New(),
+		Name:      req.Name,
+		Prefix:    prefix,
+		KeyHash:   helper.HashToken(key),
+		Scopes:    scopes,
+		ExpiresAt: req.ExpiresAt,
+		AdminID:   &adminID,
+	}
+
+	if err := aks.apiKeyRepo.Create(ctx, nil, apiKey); err != nil {
+		return dto.CreateAPIKeyResponse{}, dto.ErrCreateAPIKey
+	}
+
+	res := mapAPIKeyToResponse(apiKey)
+	aks.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_CREATE, constants.ENUM_AUDIT_ENTITY_API_KEY, apiKey.ID.String(), nil, res)
+
+	// key asli cuma dikembalikan sekali ini, yang disimpan hanya hash-nya
+	return dto.CreateAPIKeyResponse{
+		APIKeyResponse: res,
+		Key:            key,
+	}, nil
+}
+
+func (aks *apiKeyService) GetAll(ctx context.Context, adminID string) ([]dto.APIKeyResponse, error) {
+	if _, err := uuid.Parse(adminID); err != nil {
+		return nil, dto.ErrParseUUID
+	}
+
+	apiKeys, err := aks.apiKeyRepo.GetAllByAdminID(ctx, nil, adminID)
+	if err != nil {
+		return nil, dto.ErrGetAllAPIKey
+	}
+
+	datas := []dto.APIKeyResponse{}
+	for _, apiKey := range apiKeys {
+		datas = append(datas, mapAPIKeyToResponse(apiKey))
+	}
+
+	return datas, nil
+}
+
+func (aks *apiKeyService) Revoke(ctx context.Context, req dto.RevokeAPIKeyRequest) (dto.APIKeyResponse, error) {
+	if _, err := uuid.Parse(req.ID); err != nil {
+		return dto.APIKeyResponse{}, dto.ErrParseUUID
+	}
+
+	apiKey, found, err := aks.apiKeyRepo.GetByID(ctx, nil, req.ID)
+	if err != nil {
+		return dto.APIKeyResponse{}, dto.ErrGetAPIKey
+	}
+	if !found {
+		return dto.APIKeyResponse{}, dto.ErrAPIKeyNotFound
+	}
+
+	// key milik admin lain hanya bisa di-revoke oleh yang punya admins:manage
+	if apiKey.AdminID == nil || apiKey.AdminID.String() != req.ActorID {
+		permissions, err := aks.authService.GetPermissions(ctx, req.ActorID)
+		if err != nil {
+			return dto.APIKeyResponse{}, err
+		}
+		if !slices.Contains(permissions, constants.ENUM_PERMISSION_ADMINS_MANAGE) {
+			return dto.APIKeyResponse{}, dto.ErrAPIKeyNotFound
+		}
+	}
+
+	revoked, err := aks.apiKeyRepo.RevokeByID(ctx, nil, req.ID)
+	if err != nil {
+		return dto.APIKeyResponse{}, dto.ErrRevokeAPIKey
+	}
+	if !revoked {
+		return dto.APIKeyResponse{}, dto.ErrAPIKeyRevoked
+	}
+
+	before := mapAPIKeyToResponse(apiKey)
+	now := time.Now()
+	apiKey.RevokedAt = &now
+	res := mapAPIKeyToResponse(apiKey)
+
+	aks.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_REVOKE, constants.ENUM_AUDIT_ENTITY_API_KEY, apiKey.ID.String(), before, res)
+
+	return res, nil
+}
+
+func mapAPIKeyToResponse(apiKey *entity.APIKey) dto.APIKeyResponse {
+	res := dto.APIKeyResponse{
+		ID:         apiKey.ID.String(),
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Scopes:     apiKey.Scopes,
+		LastUsedIP: apiKey.LastUsedIP,
+		CreatedAt:  apiKey.CreatedAt.String(),
+	}
+	if apiKey.ExpiresAt != nil {
+		res.ExpiresAt = apiKey.ExpiresAt.String()
+	}
+	if apiKey.LastUsedAt != nil {
+		res.LastUsedAt = apiKey.LastUsedAt.String()
+	}
+	if apiKey.RevokedAt != nil {
+		res.RevokedAt = apiKey.RevokedAt.String()
+	}
+
+	return res
+}
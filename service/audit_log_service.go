@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"reflect"
+	"time"
+
+	"github.com/Amierza/nawasena-backend/constants"
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/entity"
+	"github.com/Amierza/nawasena-backend/repository"
+	"github.com/Amierza/nawasena-backend/response"
+	"github.com/google/uuid"
+)
+
+type (
+	IAuditLogService interface {
+		Record(ctx context.Context, action, entityType, entityID string, before, after any)
+		GetAllWithPagination(ctx context.Context, req dto.AuditLogPaginationRequest) (dto.AuditLogPaginationResponse, error)
+	}
+
+	auditLogService struct {
+		auditLogRepo repository.IAuditLogRepository
+	}
+)
+
+// field yang tidak boleh ikut tersimpan di audit log
+var auditRedactedFields = []string{"password"}
+
+func NewAuditLogService(auditLogRepo repository.IAuditLogRepository) *auditLogService {
+	return &auditLogService{
+		auditLogRepo: auditLogRepo,
+	}
+}
+
+// Record menyimpan jejak perubahan. Actor, IP dan user agent diambil dari context yang diisi
+// middleware.Authentication. Gagal menulis audit log hanya di-log supaya operasi utamanya tetap jalan.
+func (als *auditLogService) Record(ctx context.Context, action, entityType, entityID string, before, after any) {
+	beforeMap := toAuditMap(before)
+	afterMap := toAuditMap(after)
+
+	// untuk update hanya simpan field yang berubah
+	if action == constants.ENUM_AUDIT_ACTION_UPDATE && beforeMap != nil && afterMap != nil {
+		for key, value := range beforeMap {
+			if afterValue, ok := afterMap[key]; ok && reflect.DeepEqual(value, afterValue) {
+				delete(beforeMap, key)
+				delete(afterMap, key)
+			}
+		}
+
+		if len(beforeMap) == 0 && len(afterMap) == 0 {
+			return
+		}
+	}
+
+	auditLog := &entity.AuditLog{
+		ID:         uuid.New(),
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Before:     beforeMap,
+		After:      afterMap,
+		IPAddress:  contextString(ctx, "ip_address"),
+		UserAgent:  contextString(ctx, "user_agent"),
+	}
+
+	if actorID, err := uuid.Parse(contextString(ctx, "admin_id")); err == nil {
+		auditLog.ActorID = &actorID
+	}
+
+	if err := als.auditLogRepo.Create(ctx, nil, auditLog); err != nil {
+		log.Printf("failed record audit log %s %s %s: %v", action, entityType, entityID, err)
+	}
+}
+
+func (als *auditLogService) GetAllWithPagination(ctx context.Context, req dto.AuditLogPaginationRequest) (dto.AuditLogPaginationResponse, error) {
+	filter := dto.AuditLogFilter{
+		ActorID:    req.ActorID,
+		Action:     req.Action,
+		EntityType: req.EntityType,
+		EntityID:   req.EntityID,
+	}
+
+	if req.ActorID != "" {
+		if _, err := uuid.Parse(req.ActorID); err != nil {
+			return dto.AuditLogPaginationResponse{}, dto.ErrParseUUID
+		}
+	}
+
+	// handle date range request, tanggal "to" ikut dihitung sampai akhir hari
+	if req.From != "" {
+		from, _, err := parseAuditDate(req.From)
+		if err != nil {
+			return dto.AuditLogPaginationResponse{}, dto.ErrInvalidDateRange
+		}
+
+		filter.From = &from
+	}
+	if req.To != "" {
+		to, dateOnly, err := parseAuditDate(req.To)
+		if err != nil {
+			return dto.AuditLogPaginationResponse{}, dto.ErrInvalidDateRange
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return dto.AuditLogPaginationResponse{}, dto.ErrInvalidDateRange
+	}
+
+	dataWithPaginate, err := als.auditLogRepo.GetAllWithPagination(ctx, nil, req.PaginationRequest, filter)
+	if err != nil {
+		return dto.AuditLogPaginationResponse{}, dto.ErrGetAllAuditLog
+	}
+
+	var datas []dto.AuditLogResponse
+	for _, auditLog := range dataWithPaginate.AuditLogs {
+		data := dto.AuditLogResponse{
+			ID:         auditLog.ID.String(),
+			Action:     auditLog.Action,
+			EntityType: auditLog.EntityType,
+			EntityID:   auditLog.EntityID,
+			Before:     auditLog.Before,
+			After:      auditLog.After,
+			IPAddress:  auditLog.IPAddress,
+			UserAgent:  auditLog.UserAgent,
+			CreatedAt:  auditLog.CreatedAt.String(),
+		}
+		if auditLog.ActorID != nil {
+			data.ActorID = auditLog.ActorID.String()
+			data.ActorName = auditLog.Actor.Name
+		}
+
+		datas = append(datas, data)
+	}
+
+	return dto.AuditLogPaginationResponse{
+		Data: datas,
+		PaginationResponse: response.PaginationResponse{
+			Page:    dataWithPaginate.Page,
+			PerPage: dataWithPaginate.PerPage,
+			MaxPage: dataWithPaginate.MaxPage,
+			Count:   dataWithPaginate.Count,
+		},
+	}, nil
+}
+
+func toAuditMap(value any) map[string]any {
+	if value == nil {
+		return nil
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil
+	}
+
+	for _, field := range auditRedactedFields {
+		delete(result, field)
+	}
+
+	return result
+}
+
+func contextString(ctx context.Context, key string) string {
+	value, _ := ctx.Value(key).(string)
+	return value
+}
+
+func parseAuditDate(value string) (time.Time, bool, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, true, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	return t, false, err
+}
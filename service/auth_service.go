@@ -2,28 +2,57 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/entity"
 	"github.com/Amierza/nawasena-backend/helper"
 	"github.com/Amierza/nawasena-backend/jwt"
+	"github.com/Amierza/nawasena-backend/mailer"
 	"github.com/Amierza/nawasena-backend/repository"
+	"github.com/google/uuid"
 )
 
 type (
 	IAuthService interface {
 		Login(ctx context.Context, req dto.LoginRequest) (dto.LoginResponse, error)
 		RefreshToken(ctx context.Context, req dto.RefreshTokenRequest) (dto.RefreshTokenResponse, error)
+		Logout(ctx context.Context, sessionID string) error
+		LogoutAll(ctx context.Context, adminID string) error
+		ValidateSession(ctx context.Context, sessionID string) error
+		GetPermissions(ctx context.Context, adminID string) ([]string, error)
+		AuthenticateAPIKey(ctx context.Context, key string, ip string) (dto.APIKeyPrincipal, error)
+		ChangePassword(ctx context.Context, req dto.ChangePasswordRequest) error
+		SetPassword(ctx context.Context, adminID, password string) error
+		ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest) error
+		ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error
+		LoginTwoFactor(ctx context.Context, req dto.LoginTwoFactorRequest) (dto.LoginResponse, error)
+		EnrollTwoFactor(ctx context.Context, adminID string) (dto.TwoFactorEnrollResponse, error)
+		ConfirmTwoFactor(ctx context.Context, req dto.ConfirmTwoFactorRequest) (dto.TwoFactorRecoveryCodesResponse, error)
+		ResetTwoFactor(ctx context.Context, adminID string) error
+		GetLoginThrottles(ctx context.Context) ([]dto.LoginThrottleResponse, error)
+		ClearLoginThrottle(ctx context.Context, id string) error
 	}
 
 	authService struct {
 		authRepo repository.IAuthRepository
+		mailer   mailer.IMailer
+		auditLog IAuditLogService
 		jwt      jwt.IJWT
 	}
 )
 
-func NewAuthService(authRepo repository.IAuthRepository, jwt jwt.IJWT) *authService {
+func NewAuthService(authRepo repository.IAuthRepository, mailer mailer.IMailer, auditLog IAuditLogService, jwt jwt.IJWT) *authService {
 	return &authService{
 		authRepo: authRepo,
+		mailer:   mailer,
+		auditLog: auditLog,
 		jwt:      jwt,
 	}
 }
@@ -37,24 +66,61 @@ func (as *authService) Login(ctx context.Context, req dto.LoginRequest) (dto.Log
 		return dto.LoginResponse{}, dto.ErrInvalidPassword
 	}
 
+	emailKey, ipKey := loginThrottleKeys(req.Email, req.IP)
+	if err := as.checkLoginThrottle(ctx, emailKey, ipKey); err != nil {
+		return dto.LoginResponse{}, err
+	}
+
 	admin, found, err := as.authRepo.GetAdminByEmail(ctx, nil, req.Email)
 	if err != nil {
 		return dto.LoginResponse{}, dto.ErrGetAdminByEmail
 	}
 	if !found {
+		as.registerLoginFailure(ctx, emailKey, ipKey)
 		return dto.LoginResponse{}, dto.ErrAdminNotFound
 	}
 
 	checkPassword, err := helper.CheckPassword(admin.Password, []byte(req.Password))
 	if err != nil || !checkPassword {
+		as.registerLoginFailure(ctx, emailKey, ipKey)
 		return dto.LoginResponse{}, dto.ErrIncorrectPassword
 	}
 
-	accessToken, refreshToken, err := as.jwt.GenerateToken(admin.ID.String(), string(admin.Role))
+	// hash lama (cost rendah) diganti diam-diam selagi password plain-nya ada
+	if helper.PasswordNeedsRehash(admin.Password) {
+		if hashed, err := helper.HashPassword(req.Password); err == nil {
+			if err := as.authRepo.UpdateAdminPassword(ctx, nil, admin.ID.String(), hashed); err != nil {
+				log.Printf("failed rehash password for admin %s: %v", admin.ID, err)
+			}
+		}
+	}
+
+	// kalau 2FA aktif, token baru dikasih setelah kode TOTP / recovery code valid
+	if admin.TwoFactorEnabled {
+		challengeToken, err := as.jwt.GenerateChallengeToken(admin.ID.String())
+		if err != nil {
+			return dto.LoginResponse{}, err
+		}
+
+		return dto.LoginResponse{
+			TwoFactorRequired: true,
+			ChallengeToken:    challengeToken,
+		}, nil
+	}
+
+	// setiap login membuka session baru, semua refresh token hasil rotasi ikut session ini
+	accessToken, refreshToken, err := as.issueTokens(ctx, nil, admin, uuid.New())
 	if err != nil {
 		return dto.LoginResponse{}, err
 	}
 
+	if err := as.authRepo.ResetLoginThrottleByKey(ctx, nil, emailKey); err != nil {
+		log.Printf("failed reset login throttle %s: %v", emailKey, err)
+	}
+	if err := as.authRepo.UpdateAdminLastLogin(ctx, nil, admin.ID.String(), req.IP); err != nil {
+		log.Printf("failed update last login %s: %v", admin.ID, err)
+	}
+
 	return dto.LoginResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
@@ -62,25 +128,651 @@ func (as *authService) Login(ctx context.Context, req dto.LoginRequest) (dto.Log
 }
 
 func (as *authService) RefreshToken(ctx context.Context, req dto.RefreshTokenRequest) (dto.RefreshTokenResponse, error) {
-	_, err := as.jwt.ValidateToken(req.RefreshToken)
+	claims, err := as.jwt.ValidateToken(req.RefreshToken)
 	if err != nil {
 		return dto.RefreshTokenResponse{}, dto.ErrValidateToken
 	}
+	if claims.TokenType != constants.ENUM_TOKEN_REFRESH {
+		return dto.RefreshTokenResponse{}, dto.ErrWrongTokenType
+	}
+
+	stored, found, err := as.authRepo.GetRefreshTokenByHash(ctx, nil, helper.HashToken(req.RefreshToken))
+	if err != nil {
+		return dto.RefreshTokenResponse{}, dto.ErrGetRefreshToken
+	}
+	if !found {
+		return dto.RefreshTokenResponse{}, dto.ErrRefreshTokenNotFound
+	}
+	if stored.Admin.ID == uuid.Nil {
+		return dto.RefreshTokenResponse{}, dto.ErrAdminNotFound
+	}
+
+	var accessToken, refreshToken string
+	err = as.authRepo.RunInTransaction(ctx, func(txRepo repository.IAuthRepository) error {
+		rotated, err := txRepo.RevokeRefreshTokenByID(ctx, nil, stored.ID.String())
+		if err != nil {
+			return dto.ErrRevokeRefreshToken
+		}
+
+		// token yang sudah pernah dirotasi dipakai lagi, anggap dicuri
+		if !rotated {
+			return dto.ErrRefreshTokenReused
+		}
+
+		accessToken, refreshToken, err = as.issueTokens(ctx, txRepo, &stored.Admin, stored.SessionID)
+		return err
+	})
+	if errors.Is(err, dto.ErrRefreshTokenReused) {
+		if err := as.authRepo.RevokeRefreshTokensBySessionID(ctx, nil, stored.SessionID.String()); err != nil {
+			return dto.RefreshTokenResponse{}, dto.ErrRevokeSession
+		}
+
+		return dto.RefreshTokenResponse{}, dto.ErrRefreshTokenReused
+	}
+	if err != nil {
+		return dto.RefreshTokenResponse{}, err
+	}
+
+	return dto.RefreshTokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+func (as *authService) Logout(ctx context.Context, sessionID string) error {
+	if sessionID == "" {
+		return dto.ErrGetSessionIDFromToken
+	}
+
+	if err := as.authRepo.RevokeRefreshTokensBySessionID(ctx, nil, sessionID); err != nil {
+		return dto.ErrRevokeSession
+	}
+
+	return nil
+}
+
+func (as *authService) LogoutAll(ctx context.Context, adminID string) error {
+	if _, err := uuid.Parse(adminID); err != nil {
+		return dto.ErrParseUUID
+	}
+
+	if err := as.authRepo.RevokeRefreshTokensByAdminID(ctx, nil, adminID); err != nil {
+		return dto.ErrRevokeSession
+	}
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_REVOKE_SESSIONS, constants.ENUM_AUDIT_ENTITY_ADMIN, adminID, nil, nil)
+
+	return nil
+}
+
+func (as *authService) ValidateSession(ctx context.Context, sessionID string) error {
+	active, err := as.authRepo.IsSessionActive(ctx, nil, sessionID)
+	if err != nil {
+		return dto.ErrGetSession
+	}
+	if !active {
+		return dto.ErrSessionRevoked
+	}
+
+	return nil
+}
 
-	adminID, err := as.jwt.GetAdminIDByToken(req.RefreshToken)
+func (as *authService) GetPermissions(ctx context.Context, adminID string) ([]string, error) {
+	permissions, err := as.authRepo.GetPermissionNamesByAdminID(ctx, nil, adminID)
 	if err != nil {
-		return dto.RefreshTokenResponse{}, dto.ErrGetAdminIDFromToken
+		return nil, dto.ErrGetPermissions
+	}
+
+	return permissions, nil
+}
+
+func (as *authService) AuthenticateAPIKey(ctx context.Context, key string, ip string) (dto.APIKeyPrincipal, error) {
+	if !strings.HasPrefix(key, constants.ENUM_API_KEY_PREFIX) {
+		return dto.APIKeyPrincipal{}, dto.ErrAPIKeyInvalid
 	}
 
-	adminRole, err := as.jwt.GetAdminRoleNameByToken(req.RefreshToken)
+	apiKey, found, err := as.authRepo.GetAPIKeyByHash(ctx, nil, helper.HashToken(key))
 	if err != nil {
-		return dto.RefreshTokenResponse{}, dto.ErrGetAdminRoleNameFromToken
+		return dto.APIKeyPrincipal{}, dto.ErrGetAPIKey
+	}
+	// admin pemilik yang sudah dihapus tidak ikut ter-preload
+	if !found || apiKey.AdminID == nil || apiKey.Admin.ID == uuid.Nil {
+		return dto.APIKeyPrincipal{}, dto.ErrAPIKeyInvalid
+	}
+	if apiKey.RevokedAt != nil {
+		return dto.APIKeyPrincipal{}, dto.ErrAPIKeyRevoked
+	}
+	if apiKey.ExpiresAt != nil && apiKey.ExpiresAt.Before(time.Now()) {
+		return dto.APIKeyPrincipal{}, dto.ErrAPIKeyExpired
+	}
+
+	if err := as.authRepo.UpdateAPIKeyLastUsed(ctx, nil, apiKey.ID.String(), ip); err != nil {
+		log.Printf("failed update api key last used: %v", err)
+	}
+
+	return dto.APIKeyPrincipal{
+		KeyID:   apiKey.ID.String(),
+		AdminID: apiKey.AdminID.String(),
+		Scopes:  apiKey.Scopes,
+	}, nil
+}
+
+func (as *authService) ChangePassword(ctx context.Context, req dto.ChangePasswordRequest) error {
+	if req.CurrentPassword == "" {
+		return dto.ErrEmptyPassword
+	}
+	if req.NewPassword == "" || len(req.NewPassword) < 8 {
+		return dto.ErrInvalidPassword
+	}
+	if req.NewPassword == req.CurrentPassword {
+		return dto.ErrSamePassword
+	}
+
+	admin, found, err := as.authRepo.GetAdminByID(ctx, nil, req.AdminID)
+	if err != nil {
+		return dto.ErrGetAdminByID
+	}
+	if !found {
+		return dto.ErrAdminNotFound
+	}
+
+	checkPassword, err := helper.CheckPassword(admin.Password, []byte(req.CurrentPassword))
+	if err != nil || !checkPassword {
+		return dto.ErrIncorrectPassword
+	}
+
+	if err := as.setPassword(ctx, admin.ID.String(), req.NewPassword, nil); err != nil {
+		return err
+	}
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_CHANGE_PASSWORD, constants.ENUM_AUDIT_ENTITY_ADMIN, admin.ID.String(), nil, nil)
+
+	return nil
+}
+
+// SetPassword dipakai admin lain untuk mengganti password tanpa password lama,
+// session dan reset token admin tsb ikut dicabut seperti ChangePassword
+func (as *authService) SetPassword(ctx context.Context, adminID, password string) error {
+	if password == "" || len(password) < 8 {
+		return dto.ErrInvalidPassword
+	}
+
+	if err := as.setPassword(ctx, adminID, password, nil); err != nil {
+		return err
+	}
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_CHANGE_PASSWORD, constants.ENUM_AUDIT_ENTITY_ADMIN, adminID, nil, nil)
+
+	return nil
+}
+
+func (as *authService) ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest) error {
+	if req.Email == "" || !helper.IsValidEmail(req.Email) {
+		return dto.ErrInvalidEmail
+	}
+
+	admin, found, err := as.authRepo.GetAdminByEmail(ctx, nil, req.Email)
+	if err != nil {
+		return dto.ErrGetAdminByEmail
+	}
+	// jangan kasih tau email terdaftar atau tidak
+	if !found {
+		return nil
+	}
+
+	token, err := helper.GenerateRandomToken(32)
+	if err != nil {
+		return dto.ErrGeneratePasswordResetToken
+	}
+
+	err = as.authRepo.CreatePasswordResetToken(ctx, nil, &entity.PasswordResetToken{
+		ID:        uuid.New(),
+		TokenHash: helper.HashToken(token),
+		ExpiresAt: time.Now().Add(time.Minute * constants.ENUM_PASSWORD_RESET_TOKEN_TTL_MINUTES),
+		AdminID:   &admin.ID,
+	})
+	if err != nil {
+		return dto.ErrCreatePasswordResetToken
+	}
+
+	link := os.Getenv("PASSWORD_RESET_URL") + token
+	err = as.mailer.Send(ctx, mailer.Message{
+		To:      admin.Email,
+		Subject: "Reset password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nUse the link below to reset your password. It expires in %d minutes and can only be used once.\n\n%s\n\nIf you did not request this, you can ignore this email.",
+			admin.Name, constants.ENUM_PASSWORD_RESET_TOKEN_TTL_MINUTES, link,
+		),
+	})
+	if err != nil {
+		log.Printf("failed send password reset email to %s: %v", admin.Email, err)
+	}
+
+	return nil
+}
+
+func (as *authService) ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error {
+	if req.Token == "" {
+		return dto.ErrPasswordResetTokenInvalid
+	}
+	if req.NewPassword == "" || len(req.NewPassword) < 8 {
+		return dto.ErrInvalidPassword
+	}
+
+	resetToken, found, err := as.authRepo.GetPasswordResetTokenByHash(ctx, nil, helper.HashToken(req.Token))
+	if err != nil {
+		return dto.ErrGetPasswordResetToken
+	}
+	if !found || resetToken.UsedAt != nil || time.Now().After(resetToken.ExpiresAt) || resetToken.AdminID == nil {
+		return dto.ErrPasswordResetTokenInvalid
+	}
+
+	return as.setPassword(ctx, resetToken.AdminID.String(), req.NewPassword, resetToken)
+}
+
+// setPassword ganti password, habiskan semua reset token dan cabut semua session admin tsb
+func (as *authService) setPassword(ctx context.Context, adminID, password string, resetToken *entity.PasswordResetToken) error {
+	hashed, err := helper.HashPassword(password)
+	if err != nil {
+		return dto.ErrHashPassword
+	}
+
+	return as.authRepo.RunInTransaction(ctx, func(txRepo repository.IAuthRepository) error {
+		if resetToken != nil {
+			used, err := txRepo.UsePasswordResetTokenByID(ctx, nil, resetToken.ID.String())
+			if err != nil {
+				return dto.ErrUsePasswordResetToken
+			}
+			if !used {
+				return dto.ErrPasswordResetTokenInvalid
+			}
+		}
+
+		if err := txRepo.UpdateAdminPassword(ctx, nil, adminID, hashed); err != nil {
+			return dto.ErrUpdatePassword
+		}
+
+		if err := txRepo.UsePasswordResetTokensByAdminID(ctx, nil, adminID); err != nil {
+			return dto.ErrUsePasswordResetToken
+		}
+
+		if err := txRepo.RevokeRefreshTokensByAdminID(ctx, nil, adminID); err != nil {
+			return dto.ErrRevokeSession
+		}
+
+		return nil
+	})
+}
+
+func (as *authService) LoginTwoFactor(ctx context.Context, req dto.LoginTwoFactorRequest) (dto.LoginResponse, error) {
+	if req.Code == "" {
+		return dto.LoginResponse{}, dto.ErrEmptyTwoFactorCode
+	}
+
+	claims, err := as.jwt.ValidateToken(req.ChallengeToken)
+	if err != nil {
+		return dto.LoginResponse{}, dto.ErrValidateToken
+	}
+	if claims.TokenType != constants.ENUM_TOKEN_2FA {
+		return dto.LoginResponse{}, dto.ErrWrongTokenType
+	}
+	if claims.AdminID == "" {
+		return dto.LoginResponse{}, dto.ErrGetAdminIDFromToken
+	}
+
+	admin, found, err := as.authRepo.GetAdminByID(ctx, nil, claims.AdminID)
+	if err != nil {
+		return dto.LoginResponse{}, dto.ErrGetAdminByID
+	}
+	if !found {
+		return dto.LoginResponse{}, dto.ErrAdminNotFound
+	}
+	if !admin.TwoFactorEnabled {
+		return dto.LoginResponse{}, dto.ErrTwoFactorNotEnabled
+	}
+
+	emailKey, ipKey := loginThrottleKeys(admin.Email, req.IP)
+	if err := as.checkLoginThrottle(ctx, emailKey, ipKey); err != nil {
+		return dto.LoginResponse{}, err
+	}
+
+	if err := as.verifyTwoFactorCode(ctx, admin, req.Code); err != nil {
+		if errors.Is(err, dto.ErrInvalidTwoFactorCode) {
+			as.registerLoginFailure(ctx, emailKey, ipKey)
+		}
+
+		return dto.LoginResponse{}, err
+	}
+
+	accessToken, refreshToken, err := as.issueTokens(ctx, nil, admin, uuid.New())
+	if err != nil {
+		return dto.LoginResponse{}, err
+	}
+
+	if err := as.authRepo.ResetLoginThrottleByKey(ctx, nil, emailKey); err != nil {
+		log.Printf("failed reset login throttle %s: %v", emailKey, err)
+	}
+	if err := as.authRepo.UpdateAdminLastLogin(ctx, nil, admin.ID.String(), req.IP); err != nil {
+		log.Printf("failed update last login %s: %v", admin.ID, err)
+	}
+
+	return dto.LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+func (as *authService) EnrollTwoFactor(ctx context.Context, adminID string) (dto.TwoFactorEnrollResponse, error) {
+	admin, found, err := as.authRepo.GetAdminByID(ctx, nil, adminID)
+	if err != nil {
+		return dto.TwoFactorEnrollResponse{}, dto.ErrGetAdminByID
+	}
+	if !found {
+		return dto.TwoFactorEnrollResponse{}, dto.ErrAdminNotFound
+	}
+	if admin.TwoFactorEnabled {
+		return dto.TwoFactorEnrollResponse{}, dto.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := helper.GenerateTOTPSecret()
+	if err != nil {
+		return dto.TwoFactorEnrollResponse{}, dto.ErrGenerateTwoFactorSecret
+	}
+
+	encryptedSecret, err := helper.Encrypt(secret)
+	if err != nil {
+		return dto.TwoFactorEnrollResponse{}, dto.ErrEncryptTwoFactorSecret
+	}
+
+	// secret disimpan dulu tapi belum aktif sampai dikonfirmasi
+	if err := as.authRepo.UpdateAdminTwoFactor(ctx, nil, admin.ID.String(), encryptedSecret, false); err != nil {
+		return dto.TwoFactorEnrollResponse{}, dto.ErrUpdateTwoFactor
+	}
+
+	issuer := os.Getenv("TOTP_ISSUER")
+	if issuer == "" {
+		issuer = "Nawasena"
+	}
+
+	return dto.TwoFactorEnrollResponse{
+		Secret:     secret,
+		OtpauthURI: helper.TOTPURI(issuer, admin.Email, secret),
+	}, nil
+}
+
+func (as *authService) ConfirmTwoFactor(ctx context.Context, req dto.ConfirmTwoFactorRequest) (dto.TwoFactorRecoveryCodesResponse, error) {
+	if req.Code == "" {
+		return dto.TwoFactorRecoveryCodesResponse{}, dto.ErrEmptyTwoFactorCode
+	}
+
+	admin, found, err := as.authRepo.GetAdminByID(ctx, nil, req.AdminID)
+	if err != nil {
+		return dto.TwoFactorRecoveryCodesResponse{}, dto.ErrGetAdminByID
+	}
+	if !found {
+		return dto.TwoFactorRecoveryCodesResponse{}, dto.ErrAdminNotFound
+	}
+	if admin.TwoFactorEnabled {
+		return dto.TwoFactorRecoveryCodesResponse{}, dto.ErrTwoFactorAlreadyEnabled
+	}
+	if admin.TwoFactorSecret == "" {
+		return dto.TwoFactorRecoveryCodesResponse{}, dto.ErrTwoFactorNotEnrolled
+	}
+
+	secret, err := helper.Decrypt(admin.TwoFactorSecret)
+	if err != nil {
+		return dto.TwoFactorRecoveryCodesResponse{}, dto.ErrDecryptTwoFactorSecret
+	}
+
+	step, ok := helper.ValidateTOTP(secret, req.Code, time.Now(), 0)
+	if !ok {
+		return dto.TwoFactorRecoveryCodesResponse{}, dto.ErrInvalidTwoFactorCode
+	}
+
+	var codes []string
+	err = as.authRepo.RunInTransaction(ctx, func(txRepo repository.IAuthRepository) error {
+		if err := txRepo.UpdateAdminTwoFactor(ctx, nil, admin.ID.String(), admin.TwoFactorSecret, true); err != nil {
+			return dto.ErrUpdateTwoFactor
+		}
+
+		if _, err := txRepo.UpdateAdminTwoFactorLastStep(ctx, nil, admin.ID.String(), step); err != nil {
+			return dto.ErrUpdateTwoFactor
+		}
+
+		codes, err = as.generateRecoveryCodes(ctx, txRepo, admin)
+		return err
+	})
+	if err != nil {
+		return dto.TwoFactorRecoveryCodesResponse{}, err
+	}
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_ENABLE_2FA, constants.ENUM_AUDIT_ENTITY_ADMIN, admin.ID.String(), nil, nil)
+
+	return dto.TwoFactorRecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (as *authService) ResetTwoFactor(ctx context.Context, adminID string) error {
+	admin, found, err := as.authRepo.GetAdminByID(ctx, nil, adminID)
+	if err != nil {
+		return dto.ErrGetAdminByID
+	}
+	if !found {
+		return dto.ErrAdminNotFound
+	}
+
+	err = as.authRepo.RunInTransaction(ctx, func(txRepo repository.IAuthRepository) error {
+		if err := txRepo.UpdateAdminTwoFactor(ctx, nil, admin.ID.String(), "", false); err != nil {
+			return dto.ErrUpdateTwoFactor
+		}
+
+		if err := txRepo.DeleteRecoveryCodesByAdminID(ctx, nil, admin.ID.String()); err != nil {
+			return dto.ErrDeleteRecoveryCodes
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_RESET_2FA, constants.ENUM_AUDIT_ENTITY_ADMIN, admin.ID.String(), nil, nil)
+
+	return nil
+}
+
+func (as *authService) GetLoginThrottles(ctx context.Context) ([]dto.LoginThrottleResponse, error) {
+	throttles, err := as.authRepo.GetAllLoginThrottles(ctx, nil)
+	if err != nil {
+		return nil, dto.ErrGetLoginThrottle
+	}
+
+	now := time.Now()
+	var datas []dto.LoginThrottleResponse
+	for _, throttle := range throttles {
+		data := dto.LoginThrottleResponse{
+			ID:          throttle.ID.String(),
+			Key:         throttle.Key,
+			FailedCount: throttle.FailedCount,
+			Locked:      throttle.LockedUntil != nil && throttle.LockedUntil.After(now),
+		}
+		if throttle.LastFailedAt != nil {
+			data.LastFailedAt = throttle.LastFailedAt.String()
+		}
+		if throttle.LockedUntil != nil {
+			data.LockedUntil = throttle.LockedUntil.String()
+		}
+
+		datas = append(datas, data)
+	}
+
+	return datas, nil
+}
+
+func (as *authService) ClearLoginThrottle(ctx context.Context, id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return dto.ErrParseUUID
+	}
+
+	cleared, err := as.authRepo.ResetLoginThrottleByID(ctx, nil, id)
+	if err != nil {
+		return dto.ErrClearLoginThrottle
+	}
+	if !cleared {
+		return dto.ErrLoginThrottleNotFound
+	}
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_DELETE, constants.ENUM_AUDIT_ENTITY_LOGIN_THROTTLE, id, nil, nil)
+
+	return nil
+}
+
+func loginThrottleKeys(email, ip string) (string, string) {
+	return "email:" + strings.ToLower(strings.TrimSpace(email)), "ip:" + ip
+}
+
+func (as *authService) checkLoginThrottle(ctx context.Context, keys ...string) error {
+	now := time.Now()
+	for _, key := range keys {
+		throttle, found, err := as.authRepo.GetLoginThrottleByKey(ctx, nil, key)
+		if err != nil {
+			return dto.ErrGetLoginThrottle
+		}
+
+		if found && throttle.LockedUntil != nil && throttle.LockedUntil.After(now) {
+			return fmt.Errorf("%w, try again after %s", dto.ErrLoginLocked, throttle.LockedUntil.Format(time.RFC3339))
+		}
+	}
+
+	return nil
+}
+
+// registerLoginFailure tambah counter gagal, lewat batas dikunci dengan durasi naik 2x tiap gagal
+func (as *authService) registerLoginFailure(ctx context.Context, emailKey, ipKey string) {
+	limits := map[string]int{
+		emailKey: constants.ENUM_LOGIN_MAX_ATTEMPTS_PER_EMAIL,
+		ipKey:    constants.ENUM_LOGIN_MAX_ATTEMPTS_PER_IP,
+	}
+
+	for key, maxAttempts := range limits {
+		throttle, err := as.authRepo.IncrementLoginThrottle(ctx, nil, key)
+		if err != nil {
+			log.Printf("failed increment login throttle %s: %v", key, err)
+			continue
+		}
+
+		if throttle.FailedCount < maxAttempts {
+			continue
+		}
+
+		lockout := time.Duration(constants.ENUM_LOGIN_LOCKOUT_MAX_SECONDS) * time.Second
+		if exp := throttle.FailedCount - maxAttempts; exp < 16 {
+			if backoff := time.Duration(constants.ENUM_LOGIN_LOCKOUT_BASE_SECONDS<<exp) * time.Second; backoff < lockout {
+				lockout = backoff
+			}
+		}
+
+		if err := as.authRepo.UpdateLoginThrottleLockedUntil(ctx, nil, throttle.ID.String(), time.Now().Add(lockout)); err != nil {
+			log.Printf("failed lock login throttle %s: %v", key, err)
+		}
+	}
+}
+
+// verifyTwoFactorCode terima kode TOTP atau salah satu recovery code yang belum dipakai
+func (as *authService) verifyTwoFactorCode(ctx context.Context, admin *entity.Admin, code string) error {
+	secret, err := helper.Decrypt(admin.TwoFactorSecret)
+	if err != nil {
+		return dto.ErrDecryptTwoFactorSecret
+	}
+
+	if step, ok := helper.ValidateTOTP(secret, code, time.Now(), admin.TwoFactorLastStep); ok {
+		updated, err := as.authRepo.UpdateAdminTwoFactorLastStep(ctx, nil, admin.ID.String(), step)
+		if err != nil {
+			return dto.ErrUpdateTwoFactor
+		}
+		if !updated {
+			return dto.ErrInvalidTwoFactorCode
+		}
+
+		return nil
+	}
+
+	recoveryCode, found, err := as.authRepo.GetUnusedRecoveryCodeByHash(ctx, nil, admin.ID.String(), helper.HashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return dto.ErrGetRecoveryCode
+	}
+	if !found {
+		return dto.ErrInvalidTwoFactorCode
+	}
+
+	used, err := as.authRepo.UseRecoveryCodeByID(ctx, nil, recoveryCode.ID.String())
+	if err != nil {
+		return dto.ErrUseRecoveryCode
+	}
+	if !used {
+		return dto.ErrInvalidTwoFactorCode
+	}
+
+	return nil
+}
+
+func (as *authService) generateRecoveryCodes(ctx context.Context, txRepo repository.IAuthRepository, admin *entity.Admin) ([]string, error) {
+	if err := txRepo.DeleteRecoveryCodesByAdminID(ctx, nil, admin.ID.String()); err != nil {
+		return nil, dto.ErrDeleteRecoveryCodes
+	}
+
+	var codes []string
+	for i := 0; i < constants.ENUM_2FA_RECOVERY_CODE_COUNT; i++ {
+		raw, err := helper.GenerateRandomToken(8)
+		if err != nil {
+			return nil, dto.ErrGenerateRecoveryCode
+		}
+
+		err = txRepo.CreateRecoveryCode(ctx, nil, &entity.TwoFactorRecoveryCode{
+			ID:       uuid.New(),
+			CodeHash: helper.HashToken(raw),
+			AdminID:  &admin.ID,
+		})
+		if err != nil {
+			return nil, dto.ErrCreateRecoveryCode
+		}
+
+		codes = append(codes, fmt.Sprintf("%s-%s-%s-%s", raw[0:4], raw[4:8], raw[8:12], raw[12:16]))
+	}
+
+	return codes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
+
+func (as *authService) issueTokens(ctx context.Context, txRepo repository.IAuthRepository, admin *entity.Admin, sessionID uuid.UUID) (string, string, error) {
+	if txRepo == nil {
+		txRepo = as.authRepo
+	}
+
+	accessToken, refreshToken, err := as.jwt.GenerateToken(admin.ID.String(), string(admin.Role), sessionID.String())
+	if err != nil {
+		return "", "", err
+	}
+
+	claims, err := as.jwt.ValidateToken(refreshToken)
+	if err != nil || claims.ExpiresAt == nil {
+		return "", "", dto.ErrGenerateRefreshToken
 	}
 
-	accessToken, _, err := as.jwt.GenerateToken(adminID, adminRole)
+	err = txRepo.CreateRefreshToken(ctx, nil, &entity.RefreshToken{
+		ID:        uuid.New(),
+		TokenHash: helper.HashToken(refreshToken),
+		SessionID: sessionID,
+		ExpiresAt: claims.ExpiresAt.Time,
+		AdminID:   &admin.ID,
+	})
 	if err != nil {
-		return dto.RefreshTokenResponse{}, dto.ErrGenerateAccessToken
+		return "", "", dto.ErrCreateRefreshToken
 	}
 
-	return dto.RefreshTokenResponse{AccessToken: accessToken}, nil
+	return accessToken, refreshToken, nil
 }
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/entity"
+	"github.com/Amierza/nawasena-backend/helper"
+	"github.com/Amierza/nawasena-backend/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// fakeResetRepository hanya mengisi method yang dipakai alur reset password
+type fakeResetRepository struct {
+	repository.IAuthRepository
+
+	tokens    map[string]*entity.PasswordResetToken
+	passwords map[string]string
+	// raceUsed mensimulasikan request lain yang sudah memakai token di antara cek dan update
+	raceUsed bool
+}
+
+func (fr *fakeResetRepository) RunInTransaction(ctx context.Context, fn func(txRepo repository.IAuthRepository) error) error {
+	return fn(fr)
+}
+func (fr *fakeResetRepository) GetPasswordResetTokenByHash(ctx context.Context, tx *gorm.DB, tokenHash string) (*entity.PasswordResetToken, bool, error) {
+	token, ok := fr.tokens[tokenHash]
+	if !ok {
+		return &entity.PasswordResetToken{}, false, nil
+	}
+
+	copied := *token
+	return &copied, true, nil
+}
+func (fr *fakeResetRepository) UsePasswordResetTokenByID(ctx context.Context, tx *gorm.DB, id string) (bool, error) {
+	if fr.raceUsed {
+		return false, nil
+	}
+	for _, token := range fr.tokens {
+		if token.ID.String() == id && token.UsedAt == nil {
+			now := time.Now()
+			token.UsedAt = &now
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+func (fr *fakeResetRepository) UpdateAdminPassword(ctx context.Context, tx *gorm.DB, adminID string, hashedPassword string) error {
+	fr.passwords[adminID] = hashedPassword
+	return nil
+}
+func (fr *fakeResetRepository) UsePasswordResetTokensByAdminID(ctx context.Context, tx *gorm.DB, adminID string) error {
+	now := time.Now()
+	for _, token := range fr.tokens {
+		if token.AdminID != nil && token.AdminID.String() == adminID && token.UsedAt == nil {
+			token.UsedAt = &now
+		}
+	}
+	return nil
+}
+func (fr *fakeResetRepository) RevokeRefreshTokensByAdminID(ctx context.Context, tx *gorm.DB, adminID string) error {
+	return nil
+}
+
+func TestResetPasswordToken(t *testing.T) {
+	adminID := uuid.New()
+	usedAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name         string
+		token        *entity.PasswordResetToken
+		raceUsed     bool
+		req          dto.ResetPasswordRequest
+		wantErr      error
+		wantPassword bool
+	}{
+		{
+			name:         "token valid",
+			token:        &entity.PasswordResetToken{ExpiresAt: time.Now().Add(time.Hour), AdminID: &adminID},
+			req:          dto.ResetPasswordRequest{Token: "token", NewPassword: "rahasia123"},
+			wantPassword: true,
+		},
+		{
+			name:    "token kedaluwarsa",
+			token:   &entity.PasswordResetToken{ExpiresAt: time.Now().Add(-time.Second), AdminID: &adminID},
+			req:     dto.ResetPasswordRequest{Token: "token", NewPassword: "rahasia123"},
+			wantErr: dto.ErrPasswordResetTokenInvalid,
+		},
+		{
+			name:    "token sudah dipakai",
+			token:   &entity.PasswordResetToken{ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt, AdminID: &adminID},
+			req:     dto.ResetPasswordRequest{Token: "token", NewPassword: "rahasia123"},
+			wantErr: dto.ErrPasswordResetTokenInvalid,
+		},
+		{
+			name:     "token dipakai request lain bersamaan",
+			token:    &entity.PasswordResetToken{ExpiresAt: time.Now().Add(time.Hour), AdminID: &adminID},
+			raceUsed: true,
+			req:      dto.ResetPasswordRequest{Token: "token", NewPassword: "rahasia123"},
+			wantErr:  dto.ErrPasswordResetTokenInvalid,
+		},
+		{
+			name:    "admin sudah dihapus",
+			token:   &entity.PasswordResetToken{ExpiresAt: time.Now().Add(time.Hour)},
+			req:     dto.ResetPasswordRequest{Token: "token", NewPassword: "rahasia123"},
+			wantErr: dto.ErrPasswordResetTokenInvalid,
+		},
+		{
+			name:    "token tidak dikenal",
+			token:   &entity.PasswordResetToken{ExpiresAt: time.Now().Add(time.Hour), AdminID: &adminID},
+			req:     dto.ResetPasswordRequest{Token: "token-lain", NewPassword: "rahasia123"},
+			wantErr: dto.ErrPasswordResetTokenInvalid,
+		},
+		{
+			name:    "token kosong",
+			req:     dto.ResetPasswordRequest{NewPassword: "rahasia123"},
+			wantErr: dto.ErrPasswordResetTokenInvalid,
+		},
+		{
+			name:    "password terlalu pendek",
+			token:   &entity.PasswordResetToken{ExpiresAt: time.Now().Add(time.Hour), AdminID: &adminID},
+			req:     dto.ResetPasswordRequest{Token: "token", NewPassword: "pendek"},
+			wantErr: dto.ErrInvalidPassword,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeResetRepository{
+				tokens:    map[string]*entity.PasswordResetToken{},
+				passwords: map[string]string{},
+				raceUsed:  tt.raceUsed,
+			}
+			if tt.token != nil {
+				tt.token.ID = uuid.New()
+				tt.token.TokenHash = helper.HashToken("token")
+				repo.tokens[tt.token.TokenHash] = tt.token
+			}
+
+			as := &authService{authRepo: repo}
+			if err := as.ResetPassword(context.Background(), tt.req); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			_, changed := repo.passwords[adminID.String()]
+			if changed != tt.wantPassword {
+				t.Errorf("password changed = %v, want %v", changed, tt.wantPassword)
+			}
+		})
+	}
+}
+
+func TestResetPasswordTokenSingleUse(t *testing.T) {
+	adminID := uuid.New()
+	repo := &fakeResetRepository{
+		tokens:    map[string]*entity.PasswordResetToken{},
+		passwords: map[string]string{},
+	}
+	for _, raw := range []string{"token-1", "token-2"} {
+		repo.tokens[helper.HashToken(raw)] = &entity.PasswordResetToken{
+			ID:        uuid.New(),
+			TokenHash: helper.HashToken(raw),
+			ExpiresAt: time.Now().Add(time.Hour),
+			AdminID:   &adminID,
+		}
+	}
+
+	as := &authService{authRepo: repo}
+	ctx := context.Background()
+	if err := as.ResetPassword(ctx, dto.ResetPasswordRequest{Token: "token-1", NewPassword: "rahasia123"}); err != nil {
+		t.Fatalf("first reset: %v", err)
+	}
+
+	// token yang sama dan token lain milik admin yang sama tidak bisa dipakai lagi
+	for _, raw := range []string{"token-1", "token-2"} {
+		err := as.ResetPassword(ctx, dto.ResetPasswordRequest{Token: raw, NewPassword: "rahasia456"})
+		if !errors.Is(err, dto.ErrPasswordResetTokenInvalid) {
+			t.Errorf("reuse %s: err = %v, want %v", raw, err, dto.ErrPasswordResetTokenInvalid)
+		}
+	}
+	if ok, _ := helper.CheckPassword(repo.passwords[adminID.String()], []byte("rahasia123")); !ok {
+		t.Error("password was changed by a reused token")
+	}
+}
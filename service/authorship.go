@@ -0,0 +1,24 @@
+package service
+
+import (
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/entity"
+	"github.com/google/uuid"
+)
+
+// mapAuthor memakai id dari kolom, nama hanya diisi kalau relasi admin yang di-preload masih sesuai
+// (setelah update, UpdatedByID sudah berganti tapi relasinya belum dimuat ulang)
+func mapAuthor(id *uuid.UUID, admin *entity.Admin) *dto.AuthorResponse {
+	if id == nil {
+		return nil
+	}
+
+	res := &dto.AuthorResponse{
+		ID: id.String(),
+	}
+	if admin != nil && admin.ID == *id {
+		res.Name = admin.Name
+	}
+
+	return res
+}
@@ -2,11 +2,12 @@ package service
 
 import (
 	"context"
-	"os"
-	"path/filepath"
+	"log"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/dto"
 	"github.com/Amierza/nawasena-backend/entity"
 	"github.com/Amierza/nawasena-backend/helper"
@@ -19,22 +20,32 @@ import (
 type (
 	ICompetitionService interface {
 		Create(ctx context.Context, req dto.CreateCompetitionRequest) (dto.CompetitionResponse, error)
-		GetAll(ctx context.Context) ([]dto.CompetitionResponse, error)
+		GetAll(ctx context.Context, createdBy string) ([]dto.CompetitionResponse, error)
 		GetAllWithPagination(ctx context.Context, req response.PaginationRequest) (dto.CompetitionPaginationResponse, error)
 		GetDetail(ctx context.Context, id string) (dto.CompetitionResponse, error)
 		Update(ctx context.Context, req dto.UpdateCompetitionRequest) (dto.CompetitionResponse, error)
 		Delete(ctx context.Context, id string) (dto.CompetitionResponse, error)
+		AddImage(ctx context.Context, req dto.AddImageRequest) (dto.CompetitionResponse, error)
+		UpdateImage(ctx context.Context, req dto.UpdateImageRequest) (dto.CompetitionResponse, error)
+		RemoveImage(ctx context.Context, id, imageID string) (dto.CompetitionResponse, error)
+		ReorderImages(ctx context.Context, req dto.ReorderImagesRequest) (dto.CompetitionResponse, error)
 	}
 
 	competitionService struct {
 		competitionRepo repository.ICompetitionRepository
+		fileService     IFileService
+		slugService     ISlugService
+		auditLog        IAuditLogService
 		jwt             jwt.IJWT
 	}
 )
 
-func NewCompetitionService(competitionRepo repository.ICompetitionRepository, jwt jwt.IJWT) *competitionService {
+func NewCompetitionService(competitionRepo repository.ICompetitionRepository, fileService IFileService, slugService ISlugService, auditLog IAuditLogService, jwt jwt.IJWT) *competitionService {
 	return &competitionService{
 		competitionRepo: competitionRepo,
+		fileService:     fileService,
+		slugService:     slugService,
+		auditLog:        auditLog,
 		jwt:             jwt,
 	}
 }
@@ -71,37 +82,38 @@ func (as *competitionService) Create(ctx context.Context, req dto.CreateCompetit
 		return dto.CompetitionResponse{}, dto.ErrCompetitionAlreadyExists
 	}
 
+	slug, err := as.slugService.Generate(ctx, constants.ENUM_AUDIT_ENTITY_COMPETITION, req.Name, "")
+	if err != nil {
+		return dto.CompetitionResponse{}, err
+	}
+
 	competitionID := uuid.New()
 	competition := &entity.Competition{
 		ID:          competitionID,
 		Name:        req.Name,
+		Slug:        slug,
 		Date:        date,
 		Description: req.Description,
 	}
 
 	// handle image url
-	var (
-		competitionImages         []*entity.CompetitionImage
-		competitionImageResponses []dto.CompetitionImageResponse
-	)
+	var competitionImages []entity.CompetitionImage
 	if len(req.Images) == 0 {
 		return dto.CompetitionResponse{}, dto.ErrEmptyImages
 	}
-	for _, imgName := range req.Images {
-		imgID := uuid.New()
-		// handle entity
-		competitionImages = append(competitionImages, &entity.CompetitionImage{
-			ID:            imgID,
+	for _, value := range req.Images {
+		imgName, err := as.fileService.ResolveImage(ctx, value)
+		if err != nil {
+			return dto.CompetitionResponse{}, err
+		}
+
+		competitionImages = append(competitionImages, entity.CompetitionImage{
+			ID:            uuid.New(),
 			Name:          imgName,
 			CompetitionID: &competitionID,
 		})
-
-		// handle response
-		competitionImageResponses = append(competitionImageResponses, dto.CompetitionImageResponse{
-			ID:   imgID.String(),
-			Name: imgName,
-		})
 	}
+	arrangeGallery(competitionGalleries(competitionImages))
 
 	err = as.competitionRepo.RunInTransaction(ctx, func(txRepo repository.ICompetitionRepository) error {
 		// create competition
@@ -110,8 +122,8 @@ func (as *competitionService) Create(ctx context.Context, req dto.CreateCompetit
 		}
 
 		// create competition images
-		for _, img := range competitionImages {
-			if err := txRepo.CreateImage(ctx, nil, img); err != nil {
+		for i := range competitionImages {
+			if err := txRepo.CreateImage(ctx, nil, &competitionImages[i]); err != nil {
 				return dto.ErrCreateCompetitionImage
 			}
 		}
@@ -122,17 +134,26 @@ func (as *competitionService) Create(ctx context.Context, req dto.CreateCompetit
 		return dto.CompetitionResponse{}, err
 	}
 
-	return dto.CompetitionResponse{
+	competitionImageResponses, cover := as.mapCompetitionImages(competitionImages)
+	res := dto.CompetitionResponse{
 		ID:          competition.ID.String(),
 		Name:        competition.Name,
+		Slug:        competition.Slug,
 		Date:        competition.Date.Format("2006-01-02"),
 		Description: competition.Description,
 		Images:      competitionImageResponses,
-	}, nil
+		Cover:       cover,
+		CreatedBy:   mapAuthor(competition.CreatedByID, competition.CreatedBy),
+		UpdatedBy:   mapAuthor(competition.UpdatedByID, competition.UpdatedBy),
+	}
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_CREATE, constants.ENUM_AUDIT_ENTITY_COMPETITION, competition.ID.String(), nil, res)
+
+	return res, nil
 }
 
-func (as *competitionService) GetAll(ctx context.Context) ([]dto.CompetitionResponse, error) {
-	competitions, err := as.competitionRepo.GetAll(ctx, nil)
+func (as *competitionService) GetAll(ctx context.Context, createdBy string) ([]dto.CompetitionResponse, error) {
+	competitions, err := as.competitionRepo.GetAll(ctx, nil, createdBy)
 	if err != nil {
 		return nil, dto.ErrGetAllCompetitionNoPagination
 	}
@@ -142,16 +163,14 @@ func (as *competitionService) GetAll(ctx context.Context) ([]dto.CompetitionResp
 		data := dto.CompetitionResponse{
 			ID:          competition.ID.String(),
 			Name:        competition.Name,
+			Slug:        competition.Slug,
 			Date:        competition.Date.Format("2006-01-02"),
 			Description: competition.Description,
+			CreatedBy:   mapAuthor(competition.CreatedByID, competition.CreatedBy),
+			UpdatedBy:   mapAuthor(competition.UpdatedByID, competition.UpdatedBy),
 		}
 
-		for _, a := range competition.Images {
-			data.Images = append(data.Images, dto.CompetitionImageResponse{
-				ID:   a.ID.String(),
-				Name: a.Name,
-			})
-		}
+		data.Images, data.Cover = as.mapCompetitionImages(competition.Images)
 
 		datas = append(datas, data)
 	}
@@ -170,16 +189,14 @@ func (as *competitionService) GetAllWithPagination(ctx context.Context, req resp
 		data := dto.CompetitionResponse{
 			ID:          competition.ID.String(),
 			Name:        competition.Name,
+			Slug:        competition.Slug,
 			Date:        competition.Date.Format("2006-01-02"),
 			Description: competition.Description,
+			CreatedBy:   mapAuthor(competition.CreatedByID, competition.CreatedBy),
+			UpdatedBy:   mapAuthor(competition.UpdatedByID, competition.UpdatedBy),
 		}
 
-		for _, a := range competition.Images {
-			data.Images = append(data.Images, dto.CompetitionImageResponse{
-				ID:   a.ID.String(),
-				Name: a.Name,
-			})
-		}
+		data.Images, data.Cover = as.mapCompetitionImages(competition.Images)
 
 		datas = append(datas, data)
 	}
@@ -196,6 +213,14 @@ func (as *competitionService) GetAllWithPagination(ctx context.Context, req resp
 }
 
 func (as *competitionService) GetDetail(ctx context.Context, id string) (dto.CompetitionResponse, error) {
+	id, err := as.slugService.Resolve(ctx, constants.ENUM_AUDIT_ENTITY_COMPETITION, id)
+	if err != nil {
+		return dto.CompetitionResponse{}, err
+	}
+	if id == "" {
+		return dto.CompetitionResponse{}, dto.ErrCompetitionNotFound
+	}
+
 	competition, _, err := as.competitionRepo.GetByID(ctx, nil, id)
 	if err != nil {
 		return dto.CompetitionResponse{}, dto.ErrCompetitionNotFound
@@ -204,16 +229,14 @@ func (as *competitionService) GetDetail(ctx context.Context, id string) (dto.Com
 	res := dto.CompetitionResponse{
 		ID:          competition.ID.String(),
 		Name:        competition.Name,
+		Slug:        competition.Slug,
 		Date:        competition.Date.Format("2006-01-02"),
 		Description: competition.Description,
+		CreatedBy:   mapAuthor(competition.CreatedByID, competition.CreatedBy),
+		UpdatedBy:   mapAuthor(competition.UpdatedByID, competition.UpdatedBy),
 	}
 
-	for _, a := range competition.Images {
-		res.Images = append(res.Images, dto.CompetitionImageResponse{
-			ID:   a.ID.String(),
-			Name: a.Name,
-		})
-	}
+	res.Images, res.Cover = as.mapCompetitionImages(competition.Images)
 
 	return res, nil
 }
@@ -228,6 +251,18 @@ func (as *competitionService) Update(ctx context.Context, req dto.UpdateCompetit
 		return dto.CompetitionResponse{}, dto.ErrCompetitionNotFound
 	}
 
+	before := dto.CompetitionResponse{
+		ID:          competition.ID.String(),
+		Name:        competition.Name,
+		Slug:        competition.Slug,
+		Date:        competition.Date.Format("2006-01-02"),
+		Description: competition.Description,
+		CreatedBy:   mapAuthor(competition.CreatedByID, competition.CreatedBy),
+		UpdatedBy:   mapAuthor(competition.UpdatedByID, competition.UpdatedBy),
+	}
+
+	before.Images, before.Cover = as.mapCompetitionImages(competition.Images)
+
 	// handle name request
 	if req.Name != "" && req.Name != competition.Name {
 		if len(req.Name) < 3 {
@@ -264,27 +299,50 @@ func (as *competitionService) Update(ctx context.Context, req dto.UpdateCompetit
 		return dto.CompetitionResponse{}, dto.ErrCompetitionAlreadyExists
 	}
 
+	// slug ikut judul, slug lama disimpan sebagai redirect setelah update berhasil
+	oldSlug := competition.Slug
+	if competition.Name != before.Name || competition.Slug == "" {
+		competition.Slug, err = as.slugService.Generate(ctx, constants.ENUM_AUDIT_ENTITY_COMPETITION, competition.Name, competition.ID.String())
+		if err != nil {
+			return dto.CompetitionResponse{}, err
+		}
+	}
+
 	// handle image url
 	var (
-		competitionImages         []*entity.CompetitionImage
-		competitionImageResponses []dto.CompetitionImageResponse
+		competitionImages []entity.CompetitionImage
+		removedImages     []entity.CompetitionImage
 	)
 	if len(req.Images) > 0 {
-		for _, imgName := range req.Images {
-			imgID := uuid.New()
-			// handle entity
-			competitionImages = append(competitionImages, &entity.CompetitionImage{
-				ID:            imgID,
-				Name:          imgName,
-				CompetitionID: &competition.ID,
-			})
+		var names, existing []string
+		for _, value := range req.Images {
+			imgName, err := as.fileService.ResolveImage(ctx, value)
+			if err != nil {
+				return dto.CompetitionResponse{}, err
+			}
+			names = append(names, imgName)
+		}
+		for _, img := range competition.Images {
+			existing = append(existing, img.Name)
+		}
 
-			// handle response
-			competitionImageResponses = append(competitionImageResponses, dto.CompetitionImageResponse{
-				ID:   imgID.String(),
-				Name: imgName,
+		reuse, removed := planGallerySync(existing, names)
+		for i, name := range names {
+			if reuse[i] >= 0 {
+				competitionImages = append(competitionImages, competition.Images[reuse[i]])
+				continue
+			}
+
+			competitionImages = append(competitionImages, entity.CompetitionImage{
+				ID:            uuid.New(),
+				Name:          name,
+				CompetitionID: &competition.ID,
 			})
 		}
+		for _, index := range removed {
+			removedImages = append(removedImages, competition.Images[index])
+		}
+		arrangeGallery(competitionGalleries(competitionImages))
 	}
 
 	err = as.competitionRepo.RunInTransaction(ctx, func(txRepo repository.ICompetitionRepository) error {
@@ -294,32 +352,15 @@ func (as *competitionService) Update(ctx context.Context, req dto.UpdateCompetit
 		}
 
 		// handle new image
-		if len(req.Name) > 0 {
-			// check request images
-			oldImages, err := txRepo.GetImagesByID(ctx, nil, competition.ID.String())
-			if err != nil {
-				return dto.ErrGetCompetitionImages
-			}
-
-			// Delete Existing Competition Image
-			// in assets
-			for _, img := range oldImages {
-				name := strings.TrimPrefix(img.Name, "assets/")
-				path := filepath.Join("assets", name)
-				if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
-					return dto.ErrDeleteOldImage
+		if len(req.Images) > 0 {
+			for _, img := range removedImages {
+				if err := txRepo.DeleteImageByID(ctx, nil, competition.ID.String(), img.ID.String()); err != nil {
+					return dto.ErrDeleteImage
 				}
 			}
-			// in db
-			if err := txRepo.DeleteImagesByID(ctx, nil, competition.ID.String()); err != nil {
-				return dto.ErrDeleteCompetitionImageByCompetitionID
-			}
 
-			// Create new competition images
-			for _, img := range competitionImages {
-				if err := txRepo.CreateImage(ctx, nil, img); err != nil {
-					return dto.ErrCreateCompetitionImage
-				}
+			if err := as.saveCompetitionImages(ctx, txRepo, competitionImages); err != nil {
+				return err
 			}
 		}
 
@@ -329,13 +370,18 @@ func (as *competitionService) Update(ctx context.Context, req dto.UpdateCompetit
 		return dto.CompetitionResponse{}, err
 	}
 
-	return dto.CompetitionResponse{
-		ID:          competition.ID.String(),
-		Name:        competition.Name,
-		Date:        competition.Date.Format("2006-01-02"),
-		Description: competition.Description,
-		Images:      competitionImageResponses,
-	}, nil
+	if err := as.slugService.Rename(ctx, constants.ENUM_AUDIT_ENTITY_COMPETITION, competition.ID.String(), oldSlug, competition.Slug); err != nil {
+		log.Printf("slug redirect competition %s: %v", competition.ID, err)
+	}
+
+	if len(req.Images) > 0 {
+		competition.Images = competitionImages
+	}
+	res := as.mapCompetitionToResponse(competition)
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_COMPETITION, competition.ID.String(), before, res)
+
+	return res, nil
 }
 
 func (as *competitionService) Delete(ctx context.Context, id string) (dto.CompetitionResponse, error) {
@@ -349,17 +395,6 @@ func (as *competitionService) Delete(ctx context.Context, id string) (dto.Compet
 
 	err = as.competitionRepo.RunInTransaction(ctx, func(txRepo repository.ICompetitionRepository) error {
 		// Delete Competition Images
-		oldCompetitionImages, err := txRepo.GetImagesByID(ctx, nil, id)
-		if err != nil {
-			return dto.ErrGetCompetitionImages
-		}
-		for _, img := range oldCompetitionImages {
-			name := strings.TrimPrefix(img.Name, "assets/")
-			path := filepath.Join("assets", name)
-			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
-				return dto.ErrDeleteOldImage
-			}
-		}
 		if err := txRepo.DeleteImagesByID(ctx, nil, id); err != nil {
 			return dto.ErrDeleteCompetitionImageByCompetitionID
 		}
@@ -376,19 +411,280 @@ func (as *competitionService) Delete(ctx context.Context, id string) (dto.Compet
 		return dto.CompetitionResponse{}, err
 	}
 
+	if err := as.slugService.DeleteRedirects(ctx, constants.ENUM_AUDIT_ENTITY_COMPETITION, id); err != nil {
+		log.Printf("slug redirect competition %s: %v", id, err)
+	}
+
 	res := dto.CompetitionResponse{
 		ID:          deletedCompetition.ID.String(),
 		Name:        deletedCompetition.Name,
+		Slug:        deletedCompetition.Slug,
 		Date:        deletedCompetition.Date.Format("2006-01-02"),
 		Description: deletedCompetition.Description,
+		CreatedBy:   mapAuthor(deletedCompetition.CreatedByID, deletedCompetition.CreatedBy),
+		UpdatedBy:   mapAuthor(deletedCompetition.UpdatedByID, deletedCompetition.UpdatedBy),
 	}
 
-	for _, a := range deletedCompetition.Images {
-		res.Images = append(res.Images, dto.CompetitionImageResponse{
-			ID:   a.ID.String(),
-			Name: a.Name,
-		})
+	res.Images, res.Cover = as.mapCompetitionImages(deletedCompetition.Images)
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_DELETE, constants.ENUM_AUDIT_ENTITY_COMPETITION, deletedCompetition.ID.String(), res, nil)
+
+	return res, nil
+}
+
+func (as *competitionService) AddImage(ctx context.Context, req dto.AddImageRequest) (dto.CompetitionResponse, error) {
+	competition, found, err := as.competitionRepo.GetByID(ctx, nil, req.ParentID)
+	if err != nil {
+		return dto.CompetitionResponse{}, dto.ErrGetCompetitionByID
+	}
+	if !found {
+		return dto.CompetitionResponse{}, dto.ErrCompetitionNotFound
+	}
+
+	before := as.mapCompetitionToResponse(competition)
+
+	// handle image request
+	if strings.TrimSpace(req.Image) == "" {
+		return dto.CompetitionResponse{}, dto.ErrEmptyImage
+	}
+	imgName, err := as.fileService.ResolveImage(ctx, strings.TrimSpace(req.Image))
+	if err != nil {
+		return dto.CompetitionResponse{}, err
+	}
+
+	caption, alt, err := validateGalleryText(req.Caption, req.Alt)
+	if err != nil {
+		return dto.CompetitionResponse{}, err
+	}
+
+	index, err := galleryInsertIndex(req.Position, len(competition.Images))
+	if err != nil {
+		return dto.CompetitionResponse{}, err
+	}
+
+	competition.Images = slices.Insert(competition.Images, index, entity.CompetitionImage{
+		ID:            uuid.New(),
+		Name:          imgName,
+		CompetitionID: &competition.ID,
+		Gallery:       entity.Gallery{Caption: caption, Alt: alt},
+	})
+	galleries := competitionGalleries(competition.Images)
+	if req.IsCover {
+		setGalleryCover(galleries, index)
+	}
+	arrangeGallery(galleries)
+
+	err = as.competitionRepo.RunInTransaction(ctx, func(txRepo repository.ICompetitionRepository) error {
+		return as.saveCompetitionImages(ctx, txRepo, competition.Images)
+	})
+	if err != nil {
+		return dto.CompetitionResponse{}, err
+	}
+
+	res := as.mapCompetitionToResponse(competition)
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_COMPETITION, competition.ID.String(), before, res)
+
+	return res, nil
+}
+
+func (as *competitionService) UpdateImage(ctx context.Context, req dto.UpdateImageRequest) (dto.CompetitionResponse, error) {
+	competition, found, err := as.competitionRepo.GetByID(ctx, nil, req.ParentID)
+	if err != nil {
+		return dto.CompetitionResponse{}, dto.ErrGetCompetitionByID
+	}
+	if !found {
+		return dto.CompetitionResponse{}, dto.ErrCompetitionNotFound
+	}
+
+	index := slices.IndexFunc(competition.Images, func(img entity.CompetitionImage) bool { return img.ID.String() == req.ImageID })
+	if index == -1 {
+		return dto.CompetitionResponse{}, dto.ErrImageNotFound
+	}
+
+	before := as.mapCompetitionToResponse(competition)
+	image := &competition.Images[index]
+
+	// handle caption & alt request, string kosong berarti dihapus
+	caption, alt := image.Caption, image.Alt
+	if req.Caption != nil {
+		caption = *req.Caption
+	}
+	if req.Alt != nil {
+		alt = *req.Alt
+	}
+	image.Caption, image.Alt, err = validateGalleryText(caption, alt)
+	if err != nil {
+		return dto.CompetitionResponse{}, err
+	}
+
+	// handle cover request
+	galleries := competitionGalleries(competition.Images)
+	if req.IsCover != nil {
+		if *req.IsCover {
+			setGalleryCover(galleries, index)
+		} else {
+			unsetGalleryCover(galleries, index)
+		}
+	}
+	arrangeGallery(galleries)
+
+	err = as.competitionRepo.RunInTransaction(ctx, func(txRepo repository.ICompetitionRepository) error {
+		return as.saveCompetitionImages(ctx, txRepo, competition.Images)
+	})
+	if err != nil {
+		return dto.CompetitionResponse{}, err
+	}
+
+	res := as.mapCompetitionToResponse(competition)
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_COMPETITION, competition.ID.String(), before, res)
+
+	return res, nil
+}
+
+func (as *competitionService) RemoveImage(ctx context.Context, id, imageID string) (dto.CompetitionResponse, error) {
+	competition, found, err := as.competitionRepo.GetByID(ctx, nil, id)
+	if err != nil {
+		return dto.CompetitionResponse{}, dto.ErrGetCompetitionByID
+	}
+	if !found {
+		return dto.CompetitionResponse{}, dto.ErrCompetitionNotFound
+	}
+
+	index := slices.IndexFunc(competition.Images, func(img entity.CompetitionImage) bool { return img.ID.String() == imageID })
+	if index == -1 {
+		return dto.CompetitionResponse{}, dto.ErrImageNotFound
+	}
+	if len(competition.Images) == 1 {
+		return dto.CompetitionResponse{}, dto.ErrLastImage
+	}
+
+	before := as.mapCompetitionToResponse(competition)
+
+	competition.Images = slices.Delete(competition.Images, index, index+1)
+	arrangeGallery(competitionGalleries(competition.Images))
+
+	err = as.competitionRepo.RunInTransaction(ctx, func(txRepo repository.ICompetitionRepository) error {
+		if err := txRepo.DeleteImageByID(ctx, nil, competition.ID.String(), imageID); err != nil {
+			return dto.ErrDeleteImage
+		}
+
+		return as.saveCompetitionImages(ctx, txRepo, competition.Images)
+	})
+	if err != nil {
+		return dto.CompetitionResponse{}, err
+	}
+
+	res := as.mapCompetitionToResponse(competition)
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_COMPETITION, competition.ID.String(), before, res)
+
+	return res, nil
+}
+
+func (as *competitionService) ReorderImages(ctx context.Context, req dto.ReorderImagesRequest) (dto.CompetitionResponse, error) {
+	competition, found, err := as.competitionRepo.GetByID(ctx, nil, req.ParentID)
+	if err != nil {
+		return dto.CompetitionResponse{}, dto.ErrGetCompetitionByID
+	}
+	if !found {
+		return dto.CompetitionResponse{}, dto.ErrCompetitionNotFound
+	}
+
+	var ids []uuid.UUID
+	for _, img := range competition.Images {
+		ids = append(ids, img.ID)
+	}
+	order, err := galleryOrder(ids, req.ImageIDs)
+	if err != nil {
+		return dto.CompetitionResponse{}, err
+	}
+
+	before := as.mapCompetitionToResponse(competition)
+
+	var images []entity.CompetitionImage
+	for _, index := range order {
+		images = append(images, competition.Images[index])
+	}
+	competition.Images = images
+	arrangeGallery(competitionGalleries(competition.Images))
+
+	err = as.competitionRepo.RunInTransaction(ctx, func(txRepo repository.ICompetitionRepository) error {
+		return as.saveCompetitionImages(ctx, txRepo, competition.Images)
+	})
+	if err != nil {
+		return dto.CompetitionResponse{}, err
 	}
 
+	res := as.mapCompetitionToResponse(competition)
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_COMPETITION, competition.ID.String(), before, res)
+
 	return res, nil
 }
+
+func (as *competitionService) saveCompetitionImages(ctx context.Context, txRepo repository.ICompetitionRepository, images []entity.CompetitionImage) error {
+	for i := range images {
+		if images[i].CreatedAt.IsZero() {
+			if err := txRepo.CreateImage(ctx, nil, &images[i]); err != nil {
+				return dto.ErrCreateCompetitionImage
+			}
+			continue
+		}
+
+		if err := txRepo.UpdateImage(ctx, nil, &images[i]); err != nil {
+			return dto.ErrUpdateImage
+		}
+	}
+
+	return nil
+}
+
+func (as *competitionService) mapCompetitionToResponse(competition *entity.Competition) dto.CompetitionResponse {
+	res := dto.CompetitionResponse{
+		ID:          competition.ID.String(),
+		Name:        competition.Name,
+		Slug:        competition.Slug,
+		Date:        competition.Date.Format("2006-01-02"),
+		Description: competition.Description,
+		CreatedBy:   mapAuthor(competition.CreatedByID, competition.CreatedBy),
+		UpdatedBy:   mapAuthor(competition.UpdatedByID, competition.UpdatedBy),
+	}
+	res.Images, res.Cover = as.mapCompetitionImages(competition.Images)
+
+	return res
+}
+
+func (as *competitionService) mapCompetitionImages(images []entity.CompetitionImage) ([]dto.CompetitionImageResponse, *dto.CompetitionImageResponse) {
+	datas := []dto.CompetitionImageResponse{}
+	for _, img := range images {
+		datas = append(datas, dto.CompetitionImageResponse{
+			ID:       img.ID.String(),
+			Name:     img.Name,
+			Position: img.Position,
+			Caption:  img.Caption,
+			Alt:      img.Alt,
+			IsCover:  img.IsCover,
+			Sources:  as.fileService.ImageSources(img.Name),
+		})
+	}
+
+	cover := galleryCoverIndex(competitionGalleries(images))
+	if cover == -1 {
+		return datas, nil
+	}
+	datas[cover].IsCover = true
+	res := datas[cover]
+
+	return datas, &res
+}
+
+func competitionGalleries(images []entity.CompetitionImage) []*entity.Gallery {
+	galleries := make([]*entity.Gallery, len(images))
+	for i := range images {
+		galleries[i] = &images[i].Gallery
+	}
+
+	return galleries
+}
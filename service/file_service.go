@@ -1,95 +1,765 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
 	"io"
+	"log"
 	"mime/multipart"
+	"net/http"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/entity"
+	"github.com/Amierza/nawasena-backend/helper"
+	"github.com/Amierza/nawasena-backend/repository"
+	"github.com/Amierza/nawasena-backend/storage"
 	"github.com/google/uuid"
 )
 
 type (
 	IFileService interface {
 		// public function
-		Upload(ctx context.Context, files []*multipart.FileHeader) ([]string, error)
+		Upload(ctx context.Context, files []*multipart.FileHeader, visibility string) (dto.UploadFilesResponse, error)
+		StoreFile(ctx context.Context, src io.ReadSeeker, size int64, filename, visibility string) (dto.UploadedFileResponse, error)
+		Open(ctx context.Context, key string) (io.ReadCloser, storage.ObjectInfo, error)
+		OpenPrivate(ctx context.Context, key string) (io.ReadCloser, storage.ObjectInfo, error)
+		URL(key string) string
+		MaxRequestSize() int64
+		ImageSources(name string) *dto.ImageSourcesResponse
+		ResolveImage(ctx context.Context, value string) (string, error)
+		Remove(ctx context.Context, key, visibility string)
+		GenerateMissingDerivatives(ctx context.Context) (int, error)
 		// private / helper function
-		saveUploadedFile(file *multipart.FileHeader, savePath string) error
-		createFile(path string) (*os.File, error)
-		copyFile(dst *os.File, src multipart.File) (int64, error)
+		validateFile(file *multipart.FileHeader) (image.Image, string, *dto.FileError)
+		validateContent(src io.ReadSeeker, filename string) (image.Image, string, *dto.FileError)
+		storeMultipart(ctx context.Context, file *multipart.FileHeader, img image.Image, contentType, visibility string) (dto.UploadedFileResponse, error)
+		store(ctx context.Context, src io.ReadSeeker, size int64, filename string, img image.Image, contentType, visibility string) (dto.UploadedFileResponse, error)
+		uploadedFileResponse(filename string, media *entity.Media, duplicate bool) dto.UploadedFileResponse
+		findDuplicate(ctx context.Context, hash, visibility string) (*entity.Media, bool)
+		saveImage(ctx context.Context, key string, data []byte, img image.Image, contentType, visibility string) (*entity.Media, error)
+		storageFor(visibility string) (storage.IStorage, error)
+		publicURL(key string) string
+		isDerivative(key string) bool
+		normalizeImage(data []byte, img image.Image, contentType string) (image.Image, map[string]string, []byte, error)
+		generateDerivatives(ctx context.Context, key string, img image.Image, contentType string, fields map[string]string) error
 	}
 
 	fileService struct {
+		storage        storage.IStorage
+		privateStorage storage.IStorage
+		mediaRepo      repository.IMediaRepository
+		maxFileSize    int64
+		maxRequestSize int64
+		maxFiles       int
+		variants       []imageVariant
+		webp           bool
+		keepMetadata   []string
+	}
+
+	imageVariant struct {
+		name  string
+		width int
 	}
 )
 
-func NewFileService() *fileService {
-	return &fileService{}
+// NewFileService membaca batas upload dari env UPLOAD_MAX_FILE_SIZE_MB,
+// UPLOAD_MAX_REQUEST_SIZE_MB dan UPLOAD_MAX_FILES, serta ukuran turunan gambar
+// dari UPLOAD_IMAGE_SIZES (mis. thumbnail:320,medium:800,large:1280) dan UPLOAD_IMAGE_WEBP.
+// UPLOAD_KEEP_METADATA berisi tag EXIF yang tidak ikut dibuang, mis. Artist,Copyright.
+// privateStorage boleh nil, upload private akan ditolak
+func NewFileService(storage, privateStorage storage.IStorage, mediaRepo repository.IMediaRepository) *fileService {
+	sizes := os.Getenv("UPLOAD_IMAGE_SIZES")
+	if sizes == "" {
+		sizes = constants.ENUM_UPLOAD_IMAGE_SIZES
+	}
+
+	keep, ok := os.LookupEnv("UPLOAD_KEEP_METADATA")
+	if !ok {
+		keep = constants.ENUM_UPLOAD_KEEP_METADATA
+	}
+
+	return &fileService{
+		storage:        storage,
+		privateStorage: privateStorage,
+		mediaRepo:      mediaRepo,
+		maxFileSize:    int64(intEnv("UPLOAD_MAX_FILE_SIZE_MB", constants.ENUM_UPLOAD_MAX_FILE_SIZE_MB)) << 20,
+		maxRequestSize: int64(intEnv("UPLOAD_MAX_REQUEST_SIZE_MB", constants.ENUM_UPLOAD_MAX_REQUEST_SIZE_MB)) << 20,
+		maxFiles:       intEnv("UPLOAD_MAX_FILES", constants.ENUM_UPLOAD_MAX_FILES),
+		variants:       parseImageVariants(sizes),
+		webp:           os.Getenv("UPLOAD_IMAGE_WEBP") != "false",
+		keepMetadata:   parseMetadataFields(keep),
+	}
 }
 
-var allowedExt = map[string]bool{
-	".jpg":  true,
-	".jpeg": true,
-	".png":  true,
+// allowedTypes memetakan extension ke MIME type yang harus cocok dengan isi file
+var allowedTypes = map[string]string{
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+	".mp4":  "video/mp4",
+	".webm": "video/webm",
+	".mov":  "video/quicktime",
+	".pdf":  "application/pdf",
 }
 
-func (fs *fileService) Upload(ctx context.Context, files []*multipart.FileHeader) ([]string, error) {
+func (fs *fileService) Upload(ctx context.Context, files []*multipart.FileHeader, visibility string) (dto.UploadFilesResponse, error) {
+	if _, err := fs.storageFor(visibility); err != nil {
+		return dto.UploadFilesResponse{}, err
+	}
 	if len(files) == 0 {
-		return nil, dto.ErrNoFilesUploaded
+		return dto.UploadFilesResponse{}, dto.ErrNoFilesUploaded
+	}
+	if len(files) > fs.maxFiles {
+		return dto.UploadFilesResponse{}, fmt.Errorf("%w, max %d files", dto.ErrTooManyFiles, fs.maxFiles)
 	}
 
-	var uploadedPaths []string
+	var total int64
 	for _, file := range files {
-		// Validasi extension
-		ext := filepath.Ext(file.Filename)
-		if !allowedExt[strings.ToLower(ext)] {
-			return nil, dto.ErrInvalidFileType
-		}
+		total += file.Size
+	}
+	if total > fs.maxRequestSize {
+		return dto.UploadFilesResponse{}, fmt.Errorf("%w, max %d MB", dto.ErrUploadTooLarge, fs.maxRequestSize>>20)
+	}
 
-		// Generate unique file name
-		newFileName := fmt.Sprintf("%s%s", uuid.New().String(), ext)
-		savePath := filepath.Join("uploads/", newFileName)
-		if err := os.MkdirAll(filepath.Dir(savePath), os.ModePerm); err != nil {
-			return nil, dto.ErrCreateFolderAssets
+	// file yang gagal validasi dilaporkan per file, file lain tetap disimpan
+	res := dto.UploadFilesResponse{
+		Files: []dto.UploadedFileResponse{},
+	}
+	for i, file := range files {
+		img, contentType, fileErr := fs.validateFile(file)
+		if fileErr != nil {
+			fileErr.Index = i
+			res.Errors = append(res.Errors, *fileErr)
+			continue
 		}
 
-		// Simpan file (local)
-		if err := fs.saveUploadedFile(file, savePath); err != nil {
-			return nil, dto.ErrSaveFile
+		uploaded, err := fs.storeMultipart(ctx, file, img, contentType, visibility)
+		if err != nil {
+			res.Errors = append(res.Errors, dto.FileError{
+				Index:    i,
+				Filename: file.Filename,
+				Code:     constants.ENUM_FILE_ERROR_SAVE_FAILED,
+				Message:  err.Error(),
+			})
+			continue
 		}
 
-		uploadedPaths = append(uploadedPaths, newFileName)
+		res.Files = append(res.Files, uploaded)
+	}
+
+	return res, nil
+}
+
+// StoreFile dipakai upload bertahap: file hasil gabungan chunk divalidasi dengan aturan
+// yang sama lalu disimpan seperti upload biasa
+func (fs *fileService) StoreFile(ctx context.Context, src io.ReadSeeker, size int64, filename, visibility string) (dto.UploadedFileResponse, error) {
+	if _, err := fs.storageFor(visibility); err != nil {
+		return dto.UploadedFileResponse{}, err
+	}
+
+	img, contentType, fileErr := fs.validateContent(src, filename)
+	if fileErr != nil {
+		return dto.UploadedFileResponse{}, *fileErr
+	}
+
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return dto.UploadedFileResponse{}, dto.ErrSaveFile
+	}
+
+	return fs.store(ctx, src, size, filename, img, contentType, visibility)
+}
+
+func (fs *fileService) Open(ctx context.Context, key string) (io.ReadCloser, storage.ObjectInfo, error) {
+	body, info, err := fs.storage.Get(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrInvalidKey) {
+		return nil, storage.ObjectInfo{}, dto.ErrFileNotFound
+	}
+	if err != nil {
+		return nil, storage.ObjectInfo{}, dto.ErrGetFile
+	}
+
+	return body, info, nil
+}
+
+// OpenPrivate hanya dipanggil setelah signed url diverifikasi
+func (fs *fileService) OpenPrivate(ctx context.Context, key string) (io.ReadCloser, storage.ObjectInfo, error) {
+	if fs.privateStorage == nil {
+		return nil, storage.ObjectInfo{}, dto.ErrFileNotFound
+	}
+
+	body, info, err := fs.privateStorage.Get(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrInvalidKey) {
+		return nil, storage.ObjectInfo{}, dto.ErrFileNotFound
+	}
+	if err != nil {
+		return nil, storage.ObjectInfo{}, dto.ErrGetFile
+	}
+
+	return body, info, nil
+}
+
+func (fs *fileService) URL(key string) string {
+	return fs.storage.URL(key)
+}
+
+func (fs *fileService) MaxRequestSize() int64 {
+	return fs.maxRequestSize
+}
+
+// validateFile mengecek ukuran lalu isi file, batas ukuran upload bertahap dicek terpisah
+func (fs *fileService) validateFile(file *multipart.FileHeader) (image.Image, string, *dto.FileError) {
+	if file.Size > fs.maxFileSize {
+		return nil, "", &dto.FileError{Filename: file.Filename, Code: constants.ENUM_FILE_ERROR_TOO_LARGE, Message: fmt.Sprintf("file is larger than %d MB", fs.maxFileSize>>20)}
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, "", &dto.FileError{Filename: file.Filename, Code: constants.ENUM_FILE_ERROR_INVALID_IMAGE, Message: "failed read file"}
+	}
+	defer src.Close()
+
+	return fs.validateContent(src, file.Filename)
+}
+
+// validateContent mengecek MIME type dari isi file (bukan dari nama file) dan memastikan
+// gambar benar-benar bisa di-decode. Video hanya dicek signature-nya
+func (fs *fileService) validateContent(src io.ReadSeeker, filename string) (image.Image, string, *dto.FileError) {
+	fileErr := func(code, message string) *dto.FileError {
+		return &dto.FileError{Filename: filename, Code: code, Message: message}
+	}
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	expected, ok := allowedTypes[ext]
+	if !ok {
+		return nil, "", fileErr(constants.ENUM_FILE_ERROR_UNSUPPORTED_TYPE, "only "+allowedExtensions()+" allowed")
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(src, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, "", fileErr(constants.ENUM_FILE_ERROR_INVALID_IMAGE, "failed read file")
+	}
+
+	sniffed := sniffContentType(head[:n])
+	if sniffed != expected {
+		return nil, "", fileErr(constants.ENUM_FILE_ERROR_TYPE_MISMATCH, fmt.Sprintf("file content is %s, expected %s", sniffed, expected))
+	}
+
+	if !isImageType(expected) {
+		return nil, expected, nil
+	}
+
+	// cek dimensi dulu supaya gambar raksasa (decompression bomb) tidak ikut di-decode
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return nil, "", fileErr(constants.ENUM_FILE_ERROR_INVALID_IMAGE, "failed read file")
+	}
+	config, _, err := image.DecodeConfig(src)
+	if err != nil {
+		return nil, "", fileErr(constants.ENUM_FILE_ERROR_INVALID_IMAGE, "file is not a valid image")
+	}
+	if config.Width*config.Height > constants.ENUM_UPLOAD_MAX_IMAGE_PIXELS {
+		return nil, "", fileErr(constants.ENUM_FILE_ERROR_TOO_LARGE, fmt.Sprintf("image is %dx%d, too many pixels", config.Width, config.Height))
 	}
 
-	return uploadedPaths, nil
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return nil, "", fileErr(constants.ENUM_FILE_ERROR_INVALID_IMAGE, "failed read file")
+	}
+	img, _, err := image.Decode(src)
+	if err != nil {
+		return nil, "", fileErr(constants.ENUM_FILE_ERROR_INVALID_IMAGE, "file is not a valid image")
+	}
+
+	return img, expected, nil
 }
 
-func (fs *fileService) saveUploadedFile(file *multipart.FileHeader, savePath string) error {
+func (fs *fileService) storeMultipart(ctx context.Context, file *multipart.FileHeader, img image.Image, contentType, visibility string) (dto.UploadedFileResponse, error) {
 	src, err := file.Open()
 	if err != nil {
-		return err
+		return dto.UploadedFileResponse{}, dto.ErrSaveFile
 	}
 	defer src.Close()
 
-	dst, err := fs.createFile(savePath)
+	return fs.store(ctx, src, file.Size, file.Filename, img, contentType, visibility)
+}
+
+// store menyimpan file yang sudah lolos validasi lewat storage backend yang aktif
+// (local / s3 / supabase) lalu mencatatnya di media library
+func (fs *fileService) store(ctx context.Context, src io.ReadSeeker, size int64, filename string, img image.Image, contentType, visibility string) (dto.UploadedFileResponse, error) {
+	if visibility == "" {
+		visibility = constants.ENUM_VISIBILITY_PUBLIC
+	}
+	st, err := fs.storageFor(visibility)
 	if err != nil {
-		return err
+		return dto.UploadedFileResponse{}, err
+	}
+
+	// hash dihitung dari file mentah supaya admin UI bisa menghitung hash yang sama sebelum upload
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, src); err != nil {
+		return dto.UploadedFileResponse{}, dto.ErrSaveFile
+	}
+	hash := hex.EncodeToString(hasher.Sum(nil))
+
+	// file yang sama persis sudah pernah di-upload → pakai file yang sudah ada
+	// file private tidak pernah dipakai ulang untuk upload public, begitu juga sebaliknya
+	if existing, found := fs.findDuplicate(ctx, hash, visibility); found {
+		return fs.uploadedFileResponse(filename, existing, true), nil
+	}
+
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return dto.UploadedFileResponse{}, dto.ErrSaveFile
+	}
+
+	// Generate unique file name
+	newFileName := fmt.Sprintf("%s%s", uuid.New().String(), strings.ToLower(filepath.Ext(filename)))
+
+	var media *entity.Media
+	if img != nil {
+		data, err := io.ReadAll(src)
+		if err != nil {
+			return dto.UploadedFileResponse{}, dto.ErrSaveFile
+		}
+
+		media, err = fs.saveImage(ctx, newFileName, data, img, contentType, visibility)
+		if err != nil {
+			return dto.UploadedFileResponse{}, dto.ErrSaveFile
+		}
+	} else {
+		// video disimpan apa adanya, di-stream tanpa dibaca penuh ke memori
+		if err := st.Put(ctx, newFileName, src, size, contentType); err != nil {
+			return dto.UploadedFileResponse{}, dto.ErrSaveFile
+		}
+
+		media = &entity.Media{
+			Name:        newFileName,
+			ContentType: contentType,
+			Size:        size,
+		}
+	}
+
+	// catat di media library, uploader diisi dari admin_id di context lewat Authorship
+	media.ID = uuid.New()
+	media.OriginalName = filename
+	media.Hash = hash
+	media.Visibility = visibility
+	if err := fs.mediaRepo.Create(ctx, nil, media); err != nil {
+		fs.Remove(ctx, newFileName, visibility)
+		return dto.UploadedFileResponse{}, dto.ErrCreateMedia
 	}
-	defer dst.Close()
 
-	_, err = fs.copyFile(dst, src)
-	return err
+	return fs.uploadedFileResponse(filename, media, false), nil
 }
 
-// ini nanti kita ganti kalau mau langsung ke S3
-func (fs *fileService) createFile(path string) (*os.File, error) {
-	return os.Create(path)
+func (fs *fileService) uploadedFileResponse(filename string, media *entity.Media, duplicate bool) dto.UploadedFileResponse {
+	res := dto.UploadedFileResponse{
+		Filename:    filename,
+		Name:        media.Name,
+		ContentType: media.ContentType,
+		Size:        media.Size,
+		Hash:        media.Hash,
+		Duplicate:   duplicate,
+		MediaID:     media.ID.String(),
+		Visibility:  media.Visibility,
+	}
+
+	// file private tidak punya url tetap, url-nya dibuat lewat endpoint signed url
+	if media.Visibility != constants.ENUM_VISIBILITY_PRIVATE {
+		res.URL = fs.publicURL(media.Name)
+		res.Sources = fs.ImageSources(media.Name)
+	}
+
+	return res
 }
 
-func (fs *fileService) copyFile(dst *os.File, src multipart.File) (int64, error) {
-	return io.Copy(dst, src)
+// saveImage menyimpan hasil encode ulang, bukan file mentah, sehingga EXIF (GPS, info
+// kamera) tidak ikut tersimpan, lalu membuat turunan gambarnya. Gambar private tidak punya turunan
+func (fs *fileService) saveImage(ctx context.Context, key string, data []byte, img image.Image, contentType, visibility string) (*entity.Media, error) {
+	st, err := fs.storageFor(visibility)
+	if err != nil {
+		return nil, err
+	}
+
+	img, fields, data, err := fs.normalizeImage(data, img, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := st.Put(ctx, key, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+		return nil, err
+	}
+
+	// thumbnail / medium / large dan webp disimpan di samping file aslinya
+	if visibility == constants.ENUM_VISIBILITY_PUBLIC {
+		if err := fs.generateDerivatives(ctx, key, img, contentType, fields); err != nil {
+			fs.Remove(ctx, key, visibility)
+			return nil, err
+		}
+	}
+
+	// ukuran dan dimensi diambil dari file yang benar-benar tersimpan
+	bounds := img.Bounds()
+
+	return &entity.Media{
+		Name:        key,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Width:       bounds.Dx(),
+		Height:      bounds.Dy(),
+	}, nil
+}
+
+// findDuplicate mencari media dengan hash yang sama dan memastikan filenya masih ada di
+// storage, jadi tetap benar untuk backend apa pun walau file sempat dihapus di luar aplikasi.
+// updated_at media disentuh supaya GC menganggapnya baru di-upload dan memberi grace period lagi
+func (fs *fileService) findDuplicate(ctx context.Context, hash, visibility string) (*entity.Media, bool) {
+	media, found, err := fs.mediaRepo.GetByHash(ctx, nil, hash, visibility)
+	if err != nil || !found {
+		return nil, false
+	}
+
+	st, err := fs.storageFor(visibility)
+	if err != nil {
+		return nil, false
+	}
+	if _, err := st.Stat(ctx, media.Name); err != nil {
+		return nil, false
+	}
+	if err := fs.mediaRepo.Touch(ctx, nil, media.ID.String()); err != nil {
+		return nil, false
+	}
+
+	return media, true
+}
+
+// normalizeImage menerapkan orientasi EXIF ke pixel lalu meng-encode ulang gambar,
+// hanya tag yang ada di allow-list yang ditulis kembali
+func (fs *fileService) normalizeImage(data []byte, img image.Image, contentType string) (image.Image, map[string]string, []byte, error) {
+	meta := helper.ReadImageMetadata(data, contentType)
+	img = helper.ApplyOrientation(img, meta.Orientation)
+
+	fields := map[string]string{}
+	for _, name := range fs.keepMetadata {
+		if value, ok := meta.Fields[name]; ok {
+			fields[name] = value
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := helper.EncodeImageWithMetadata(&buf, img, contentType, fields); err != nil {
+		return nil, nil, nil, err
+	}
+
+	return img, fields, buf.Bytes(), nil
+}
+
+func (fs *fileService) ImageSources(name string) *dto.ImageSourcesResponse {
+	// nama lama bisa berupa url penuh, untuk itu tidak ada turunan
+	key := strings.TrimPrefix(name, "/uploads/")
+	if key == "" || strings.Contains(key, "://") || len(fs.variants) == 0 {
+		return nil
+	}
+
+	ext := strings.ToLower(path.Ext(key))
+	if contentType, ok := allowedTypes[ext]; !ok || !isImageType(contentType) {
+		return nil
+	}
+
+	res := &dto.ImageSourcesResponse{
+		Original: fs.publicURL(key),
+		Variants: []dto.ImageVariantResponse{},
+	}
+
+	var srcset, webpSrcset []string
+	for _, variant := range fs.variants {
+		data := dto.ImageVariantResponse{
+			Name:  variant.name,
+			Width: variant.width,
+			URL:   fs.publicURL(derivativeKey(key, variant.name, ext)),
+		}
+		srcset = append(srcset, fmt.Sprintf("%s %dw", data.URL, variant.width))
+
+		if fs.webp {
+			data.WebP = fs.publicURL(derivativeKey(key, variant.name, ".webp"))
+			webpSrcset = append(webpSrcset, fmt.Sprintf("%s %dw", data.WebP, variant.width))
+		}
+
+		res.Variants = append(res.Variants, data)
+	}
+	res.Srcset = strings.Join(srcset, ", ")
+	res.WebPSrcset = strings.Join(webpSrcset, ", ")
+
+	return res
+}
+
+// GenerateMissingDerivatives dipakai CLI --backfill-derivatives untuk file yang di-upload
+// sebelum turunan gambar ada, file yang turunannya sudah lengkap dilewati
+func (fs *fileService) GenerateMissingDerivatives(ctx context.Context) (int, error) {
+	objects, err := fs.storage.List(ctx, "")
+	if err != nil {
+		return 0, err
+	}
+
+	existing := map[string]bool{}
+	for _, object := range objects {
+		existing[object.Key] = true
+	}
+
+	generated := 0
+	for _, object := range objects {
+		ext := strings.ToLower(path.Ext(object.Key))
+		contentType, ok := allowedTypes[ext]
+		if !ok || !isImageType(contentType) || fs.isDerivative(object.Key) {
+			continue
+		}
+
+		missing := false
+		for _, key := range fs.derivativeKeys(object.Key) {
+			if !existing[key] {
+				missing = true
+				break
+			}
+		}
+		if !missing {
+			continue
+		}
+
+		body, _, err := fs.storage.Get(ctx, object.Key)
+		if err != nil {
+			log.Printf("backfill %s: %v", object.Key, err)
+			continue
+		}
+		data, err := io.ReadAll(body)
+		body.Close()
+		if err != nil {
+			log.Printf("backfill %s: %v", object.Key, err)
+			continue
+		}
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			log.Printf("backfill %s: %v", object.Key, err)
+			continue
+		}
+
+		// file lama belum dinormalisasi, jadi orientasi EXIF-nya tetap diterapkan ke turunan
+		img, fields, _, err := fs.normalizeImage(data, img, contentType)
+		if err != nil {
+			log.Printf("backfill %s: %v", object.Key, err)
+			continue
+		}
+
+		if err := fs.generateDerivatives(ctx, object.Key, img, contentType, fields); err != nil {
+			log.Printf("backfill %s: %v", object.Key, err)
+			continue
+		}
+		generated++
+	}
+
+	return generated, nil
+}
+
+func (fs *fileService) generateDerivatives(ctx context.Context, key string, img image.Image, contentType string, fields map[string]string) error {
+	ext := strings.ToLower(path.Ext(key))
+
+	for _, variant := range fs.variants {
+		resized := helper.ResizeImage(img, variant.width)
+
+		var buf bytes.Buffer
+		if err := helper.EncodeImageWithMetadata(&buf, resized, contentType, fields); err != nil {
+			return err
+		}
+		if err := fs.storage.Put(ctx, derivativeKey(key, variant.name, ext), bytes.NewReader(buf.Bytes()), int64(buf.Len()), contentType); err != nil {
+			return err
+		}
+
+		if !fs.webp {
+			continue
+		}
+
+		buf.Reset()
+		if err := helper.EncodeWebP(&buf, resized); err != nil {
+			return err
+		}
+		if err := fs.storage.Put(ctx, derivativeKey(key, variant.name, ".webp"), bytes.NewReader(buf.Bytes()), int64(buf.Len()), "image/webp"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ResolveImage mengizinkan field gambar berisi id media; id diganti nama file-nya,
+// nilai lain (nama file lama / url) dikembalikan apa adanya
+func (fs *fileService) ResolveImage(ctx context.Context, value string) (string, error) {
+	if _, err := uuid.Parse(value); err != nil {
+		return value, nil
+	}
+
+	media, found, err := fs.mediaRepo.GetByID(ctx, nil, value)
+	if err != nil {
+		return "", dto.ErrGetMediaByID
+	}
+	if !found {
+		return "", dto.ErrMediaNotFound
+	}
+	if media.Visibility == constants.ENUM_VISIBILITY_PRIVATE {
+		return "", dto.ErrPrivateMedia
+	}
+
+	return media.Name, nil
+}
+
+// Remove menghapus file beserta semua turunannya dari storage
+func (fs *fileService) Remove(ctx context.Context, key, visibility string) {
+	if visibility == constants.ENUM_VISIBILITY_PRIVATE {
+		if fs.privateStorage == nil {
+			return
+		}
+		if err := fs.privateStorage.Delete(ctx, key); err != nil {
+			log.Printf("failed delete private %s: %v", key, err)
+		}
+		return
+	}
+
+	for _, k := range append(fs.derivativeKeys(key), key) {
+		if err := fs.storage.Delete(ctx, k); err != nil {
+			log.Printf("failed delete %s: %v", k, err)
+		}
+	}
+}
+
+func (fs *fileService) derivativeKeys(key string) []string {
+	ext := strings.ToLower(path.Ext(key))
+
+	var keys []string
+	for _, variant := range fs.variants {
+		keys = append(keys, derivativeKey(key, variant.name, ext))
+		if fs.webp {
+			keys = append(keys, derivativeKey(key, variant.name, ".webp"))
+		}
+	}
+
+	return keys
+}
+
+func (fs *fileService) isDerivative(key string) bool {
+	base := strings.TrimSuffix(path.Base(key), path.Ext(key))
+	for _, variant := range fs.variants {
+		if strings.HasSuffix(base, "_"+variant.name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// storageFor memilih backend sesuai visibility, kosong berarti public
+func (fs *fileService) storageFor(visibility string) (storage.IStorage, error) {
+	switch visibility {
+	case "", constants.ENUM_VISIBILITY_PUBLIC:
+		return fs.storage, nil
+	case constants.ENUM_VISIBILITY_PRIVATE:
+		if fs.privateStorage == nil {
+			return nil, dto.ErrPrivateStorageUnavailable
+		}
+		return fs.privateStorage, nil
+	default:
+		return nil, dto.ErrInvalidVisibility
+	}
+}
+
+// derivativeKey membentuk nama turunan, mis. abc.png → abc_thumbnail.webp
+func derivativeKey(key, variant, ext string) string {
+	return strings.TrimSuffix(key, path.Ext(key)) + "_" + variant + ext
+}
+
+func parseImageVariants(value string) []imageVariant {
+	var variants []imageVariant
+	for _, part := range strings.Split(value, ",") {
+		name, width, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok {
+			continue
+		}
+
+		w, err := strconv.Atoi(strings.TrimSpace(width))
+		if err != nil || w <= 0 || strings.TrimSpace(name) == "" {
+			log.Printf("warning: ignoring invalid UPLOAD_IMAGE_SIZES entry %q", part)
+			continue
+		}
+		variants = append(variants, imageVariant{name: strings.TrimSpace(name), width: w})
+	}
+
+	return variants
+}
+
+func parseMetadataFields(value string) []string {
+	var fields []string
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if _, ok := helper.MetadataFields[field]; !ok {
+			log.Printf("warning: ignoring unsupported UPLOAD_KEEP_METADATA field %q", field)
+			continue
+		}
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+// sniffContentType menambah deteksi QuickTime (.mov) yang tidak dikenali http.DetectContentType
+func sniffContentType(head []byte) string {
+	if len(head) >= 12 && string(head[4:8]) == "ftyp" && string(head[8:12]) == "qt  " {
+		return "video/quicktime"
+	}
+
+	return http.DetectContentType(head)
+}
+
+// isImageType membedakan gambar (punya turunan & metadata) dari video yang disimpan apa adanya
+func isImageType(contentType string) bool {
+	return strings.HasPrefix(contentType, "image/")
+}
+
+func allowedExtensions() string {
+	var exts []string
+	for ext := range allowedTypes {
+		exts = append(exts, strings.TrimPrefix(ext, "."))
+	}
+	sort.Strings(exts)
+
+	return strings.Join(exts, "/")
+}
+
+// publicURL dipakai di response upload, backend tanpa url publik dilayani lewat /uploads
+func (fs *fileService) publicURL(key string) string {
+	if url := fs.storage.URL(key); url != "" {
+		return url
+	}
+
+	return "/uploads/" + key
+}
+
+func intEnv(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+
+	return value
 }
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/dto"
 	"github.com/Amierza/nawasena-backend/entity"
 	"github.com/Amierza/nawasena-backend/jwt"
@@ -14,7 +15,7 @@ import (
 type (
 	IFlyerService interface {
 		Create(ctx context.Context, req dto.CreateFlyerRequest) (dto.FlyerResponse, error)
-		GetAll(ctx context.Context) ([]dto.FlyerResponse, error)
+		GetAll(ctx context.Context, createdBy string) ([]dto.FlyerResponse, error)
 		GetAllWithPagination(ctx context.Context, req response.PaginationRequest) (dto.FlyerPaginationResponse, error)
 		GetDetail(ctx context.Context, id string) (dto.FlyerResponse, error)
 		Update(ctx context.Context, req dto.UpdateFlyerRequest) (dto.FlyerResponse, error)
@@ -22,15 +23,19 @@ type (
 	}
 
 	flyerService struct {
-		flyerRepo repository.IFlyerRepository
-		jwt       jwt.IJWT
+		flyerRepo   repository.IFlyerRepository
+		fileService IFileService
+		auditLog    IAuditLogService
+		jwt         jwt.IJWT
 	}
 )
 
-func NewFlyerService(flyerRepo repository.IFlyerRepository, jwt jwt.IJWT) *flyerService {
+func NewFlyerService(flyerRepo repository.IFlyerRepository, fileService IFileService, auditLog IAuditLogService, jwt jwt.IJWT) *flyerService {
 	return &flyerService{
-		flyerRepo: flyerRepo,
-		jwt:       jwt,
+		flyerRepo:   flyerRepo,
+		fileService: fileService,
+		auditLog:    auditLog,
+		jwt:         jwt,
 	}
 }
 
@@ -47,6 +52,10 @@ func (as *flyerService) Create(ctx context.Context, req dto.CreateFlyerRequest)
 	if req.Image == "" {
 		return dto.FlyerResponse{}, dto.ErrEmptyImage
 	}
+	image, err := as.fileService.ResolveImage(ctx, req.Image)
+	if err != nil {
+		return dto.FlyerResponse{}, err
+	}
 
 	// handle double data
 	_, found, _ := as.flyerRepo.GetByName(ctx, nil, req.Name)
@@ -58,7 +67,7 @@ func (as *flyerService) Create(ctx context.Context, req dto.CreateFlyerRequest)
 	flyer := &entity.Flyer{
 		ID:    flyerID,
 		Name:  req.Name,
-		Image: req.Image,
+		Image: image,
 	}
 
 	// create flyer
@@ -66,15 +75,21 @@ func (as *flyerService) Create(ctx context.Context, req dto.CreateFlyerRequest)
 		return dto.FlyerResponse{}, dto.ErrCreateFlyer
 	}
 
-	return dto.FlyerResponse{
-		ID:    flyer.ID.String(),
-		Name:  flyer.Name,
-		Image: flyer.Image,
-	}, nil
+	res := dto.FlyerResponse{
+		ID:        flyer.ID.String(),
+		Name:      flyer.Name,
+		Image:     flyer.Image,
+		CreatedBy: mapAuthor(flyer.CreatedByID, flyer.CreatedBy),
+		UpdatedBy: mapAuthor(flyer.UpdatedByID, flyer.UpdatedBy),
+	}
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_CREATE, constants.ENUM_AUDIT_ENTITY_FLYER, flyer.ID.String(), nil, res)
+
+	return res, nil
 }
 
-func (as *flyerService) GetAll(ctx context.Context) ([]dto.FlyerResponse, error) {
-	flyers, err := as.flyerRepo.GetAll(ctx, nil)
+func (as *flyerService) GetAll(ctx context.Context, createdBy string) ([]dto.FlyerResponse, error) {
+	flyers, err := as.flyerRepo.GetAll(ctx, nil, createdBy)
 	if err != nil {
 		return nil, dto.ErrGetAllFlyerNoPagination
 	}
@@ -82,9 +97,11 @@ func (as *flyerService) GetAll(ctx context.Context) ([]dto.FlyerResponse, error)
 	var datas []dto.FlyerResponse
 	for _, flyer := range flyers {
 		data := dto.FlyerResponse{
-			ID:    flyer.ID.String(),
-			Name:  flyer.Name,
-			Image: flyer.Image,
+			ID:        flyer.ID.String(),
+			Name:      flyer.Name,
+			Image:     flyer.Image,
+			CreatedBy: mapAuthor(flyer.CreatedByID, flyer.CreatedBy),
+			UpdatedBy: mapAuthor(flyer.UpdatedByID, flyer.UpdatedBy),
 		}
 
 		datas = append(datas, data)
@@ -102,9 +119,11 @@ func (as *flyerService) GetAllWithPagination(ctx context.Context, req response.P
 	var datas []dto.FlyerResponse
 	for _, flyer := range dataWithPaginate.Flyers {
 		data := dto.FlyerResponse{
-			ID:    flyer.ID.String(),
-			Name:  flyer.Name,
-			Image: flyer.Image,
+			ID:        flyer.ID.String(),
+			Name:      flyer.Name,
+			Image:     flyer.Image,
+			CreatedBy: mapAuthor(flyer.CreatedByID, flyer.CreatedBy),
+			UpdatedBy: mapAuthor(flyer.UpdatedByID, flyer.UpdatedBy),
 		}
 
 		datas = append(datas, data)
@@ -128,9 +147,11 @@ func (as *flyerService) GetDetail(ctx context.Context, id string) (dto.FlyerResp
 	}
 
 	res := dto.FlyerResponse{
-		ID:    flyer.ID.String(),
-		Name:  flyer.Name,
-		Image: flyer.Image,
+		ID:        flyer.ID.String(),
+		Name:      flyer.Name,
+		Image:     flyer.Image,
+		CreatedBy: mapAuthor(flyer.CreatedByID, flyer.CreatedBy),
+		UpdatedBy: mapAuthor(flyer.UpdatedByID, flyer.UpdatedBy),
 	}
 
 	return res, nil
@@ -146,6 +167,14 @@ func (as *flyerService) Update(ctx context.Context, req dto.UpdateFlyerRequest)
 		return dto.FlyerResponse{}, dto.ErrFlyerNotFound
 	}
 
+	before := dto.FlyerResponse{
+		ID:        flyer.ID.String(),
+		Name:      flyer.Name,
+		Image:     flyer.Image,
+		CreatedBy: mapAuthor(flyer.CreatedByID, flyer.CreatedBy),
+		UpdatedBy: mapAuthor(flyer.UpdatedByID, flyer.UpdatedBy),
+	}
+
 	// handle name request
 	if req.Name != "" && req.Name != flyer.Name {
 		if len(req.Name) < 3 {
@@ -155,6 +184,16 @@ func (as *flyerService) Update(ctx context.Context, req dto.UpdateFlyerRequest)
 		flyer.Name = req.Name
 	}
 
+	// handle image request
+	if req.Image != "" && req.Image != flyer.Image {
+		image, err := as.fileService.ResolveImage(ctx, req.Image)
+		if err != nil {
+			return dto.FlyerResponse{}, err
+		}
+
+		flyer.Image = image
+	}
+
 	err = as.flyerRepo.RunInTransaction(ctx, func(txRepo repository.IFlyerRepository) error {
 		// update flyer
 		if err := txRepo.Update(ctx, nil, flyer); err != nil {
@@ -167,11 +206,17 @@ func (as *flyerService) Update(ctx context.Context, req dto.UpdateFlyerRequest)
 		return dto.FlyerResponse{}, err
 	}
 
-	return dto.FlyerResponse{
-		ID:    flyer.ID.String(),
-		Name:  flyer.Name,
-		Image: flyer.Image,
-	}, nil
+	res := dto.FlyerResponse{
+		ID:        flyer.ID.String(),
+		Name:      flyer.Name,
+		Image:     flyer.Image,
+		CreatedBy: mapAuthor(flyer.CreatedByID, flyer.CreatedBy),
+		UpdatedBy: mapAuthor(flyer.UpdatedByID, flyer.UpdatedBy),
+	}
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_FLYER, flyer.ID.String(), before, res)
+
+	return res, nil
 }
 
 func (as *flyerService) Delete(ctx context.Context, id string) (dto.FlyerResponse, error) {
@@ -197,10 +242,14 @@ func (as *flyerService) Delete(ctx context.Context, id string) (dto.FlyerRespons
 	}
 
 	res := dto.FlyerResponse{
-		ID:    deletedFlyer.ID.String(),
-		Name:  deletedFlyer.Name,
-		Image: deletedFlyer.Image,
+		ID:        deletedFlyer.ID.String(),
+		Name:      deletedFlyer.Name,
+		Image:     deletedFlyer.Image,
+		CreatedBy: mapAuthor(deletedFlyer.CreatedByID, deletedFlyer.CreatedBy),
+		UpdatedBy: mapAuthor(deletedFlyer.UpdatedByID, deletedFlyer.UpdatedBy),
 	}
 
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_DELETE, constants.ENUM_AUDIT_ENTITY_FLYER, deletedFlyer.ID.String(), res, nil)
+
 	return res, nil
 }
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/entity"
+	"github.com/google/uuid"
+)
+
+// helper galeri dipakai bersama oleh news, achievement, ship dan competition.
+// Setiap service memegang slice gambarnya sendiri, helper di sini hanya mengatur
+// posisi & cover lewat pointer ke entity.Gallery yang di-embed di tiap gambar
+
+// arrangeGallery menulis ulang posisi sesuai urutan slice dan memastikan tepat satu cover,
+// kalau belum ada yang ditandai gambar pertama jadi cover
+func arrangeGallery(items []*entity.Gallery) {
+	cover := -1
+	for i, item := range items {
+		item.Position = i
+		if item.IsCover && cover == -1 {
+			cover = i
+			continue
+		}
+		item.IsCover = false
+	}
+
+	if cover == -1 && len(items) > 0 {
+		items[0].IsCover = true
+	}
+}
+
+func setGalleryCover(items []*entity.Gallery, index int) {
+	for i, item := range items {
+		item.IsCover = i == index
+	}
+}
+
+// unsetGalleryCover memindahkan cover ke gambar lain, galeri dengan satu gambar tetap memakai gambar itu
+func unsetGalleryCover(items []*entity.Gallery, index int) {
+	if !items[index].IsCover || len(items) < 2 {
+		return
+	}
+
+	next := 0
+	if index == 0 {
+		next = 1
+	}
+	setGalleryCover(items, next)
+}
+
+// galleryCoverIndex dipakai saat mapping response, data lama yang belum punya cover memakai gambar pertama
+func galleryCoverIndex(items []*entity.Gallery) int {
+	for i, item := range items {
+		if item.IsCover {
+			return i
+		}
+	}
+	if len(items) > 0 {
+		return 0
+	}
+
+	return -1
+}
+
+// galleryInsertIndex mengubah position dari request jadi index slice, kosong berarti di akhir
+func galleryInsertIndex(position *int, length int) (int, error) {
+	if position == nil {
+		return length, nil
+	}
+	if *position < 0 || *position > length {
+		return 0, dto.ErrInvalidImagePosition
+	}
+
+	return *position, nil
+}
+
+// galleryOrder mengembalikan index gambar lama untuk tiap posisi baru,
+// request harus memuat semua id gambar tepat satu kali
+func galleryOrder(current []uuid.UUID, requested []string) ([]int, error) {
+	if len(requested) != len(current) {
+		return nil, dto.ErrInvalidImageOrder
+	}
+
+	indexes := make(map[uuid.UUID]int, len(current))
+	for i, id := range current {
+		indexes[id] = i
+	}
+
+	order := make([]int, 0, len(requested))
+	for _, value := range requested {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, dto.ErrInvalidImageOrder
+		}
+
+		index, ok := indexes[id]
+		if !ok {
+			return nil, dto.ErrInvalidImageOrder
+		}
+		delete(indexes, id)
+
+		order = append(order, index)
+	}
+
+	return order, nil
+}
+
+// planGallerySync dipakai Update yang masih mengirim daftar gambar lengkap. Gambar dengan nama
+// yang sama dipakai ulang supaya caption, alt dan cover-nya tidak hilang; reuse berisi index gambar
+// lama untuk tiap nama baru (-1 berarti gambar baru) dan removed berisi index gambar lama yang dibuang
+func planGallerySync(existing, names []string) (reuse []int, removed []int) {
+	available := map[string][]int{}
+	for i, name := range existing {
+		available[name] = append(available[name], i)
+	}
+
+	for _, name := range names {
+		if indexes := available[name]; len(indexes) > 0 {
+			reuse = append(reuse, indexes[0])
+			available[name] = indexes[1:]
+			continue
+		}
+		reuse = append(reuse, -1)
+	}
+
+	for i, name := range existing {
+		for _, index := range available[name] {
+			if index == i {
+				removed = append(removed, i)
+			}
+		}
+	}
+
+	return reuse, removed
+}
+
+func validateGalleryText(caption, alt string) (string, string, error) {
+	alt = strings.TrimSpace(alt)
+	if len(alt) > 255 {
+		return "", "", dto.ErrAltTooLong
+	}
+
+	return strings.TrimSpace(caption), alt, nil
+}
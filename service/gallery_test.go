@@ -0,0 +1,108 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/google/uuid"
+)
+
+func TestPlanGallerySync(t *testing.T) {
+	tests := []struct {
+		name        string
+		existing    []string
+		names       []string
+		wantReuse   []int
+		wantRemoved []int
+	}{
+		{
+			name:      "galeri kosong",
+			existing:  nil,
+			names:     []string{"a.jpg", "b.jpg"},
+			wantReuse: []int{-1, -1},
+		},
+		{
+			name:      "sama persis",
+			existing:  []string{"a.jpg", "b.jpg"},
+			names:     []string{"a.jpg", "b.jpg"},
+			wantReuse: []int{0, 1},
+		},
+		{
+			name:      "urutan ditukar tetap dipakai ulang",
+			existing:  []string{"a.jpg", "b.jpg", "c.jpg"},
+			names:     []string{"c.jpg", "a.jpg", "b.jpg"},
+			wantReuse: []int{2, 0, 1},
+		},
+		{
+			name:        "gambar diganti",
+			existing:    []string{"a.jpg", "b.jpg"},
+			names:       []string{"a.jpg", "c.jpg"},
+			wantReuse:   []int{0, -1},
+			wantRemoved: []int{1},
+		},
+		{
+			name:        "semua dihapus",
+			existing:    []string{"a.jpg", "b.jpg"},
+			names:       nil,
+			wantRemoved: []int{0, 1},
+		},
+		{
+			name:      "nama kembar dipakai ulang berurutan",
+			existing:  []string{"a.jpg", "a.jpg"},
+			names:     []string{"a.jpg", "a.jpg", "a.jpg"},
+			wantReuse: []int{0, 1, -1},
+		},
+		{
+			name:        "nama kembar berkurang",
+			existing:    []string{"a.jpg", "b.jpg", "a.jpg"},
+			names:       []string{"b.jpg", "a.jpg"},
+			wantReuse:   []int{1, 0},
+			wantRemoved: []int{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reuse, removed := planGallerySync(tt.existing, tt.names)
+			if !reflect.DeepEqual(reuse, tt.wantReuse) {
+				t.Errorf("reuse = %v, want %v", reuse, tt.wantReuse)
+			}
+			if !reflect.DeepEqual(removed, tt.wantRemoved) {
+				t.Errorf("removed = %v, want %v", removed, tt.wantRemoved)
+			}
+		})
+	}
+}
+
+func TestGalleryOrder(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	current := []uuid.UUID{a, b, c}
+
+	tests := []struct {
+		name      string
+		requested []string
+		want      []int
+		wantErr   error
+	}{
+		{name: "urutan sama", requested: []string{a.String(), b.String(), c.String()}, want: []int{0, 1, 2}},
+		{name: "dibalik", requested: []string{c.String(), b.String(), a.String()}, want: []int{2, 1, 0}},
+		{name: "kurang satu", requested: []string{a.String(), b.String()}, wantErr: dto.ErrInvalidImageOrder},
+		{name: "id kembar", requested: []string{a.String(), a.String(), b.String()}, wantErr: dto.ErrInvalidImageOrder},
+		{name: "id asing", requested: []string{a.String(), b.String(), uuid.NewString()}, wantErr: dto.ErrInvalidImageOrder},
+		{name: "bukan uuid", requested: []string{a.String(), b.String(), "c"}, wantErr: dto.ErrInvalidImageOrder},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := galleryOrder(current, tt.requested)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Amierza/nawasena-backend/constants"
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/entity"
+	"github.com/Amierza/nawasena-backend/helper"
+	"github.com/Amierza/nawasena-backend/repository"
+	"github.com/Amierza/nawasena-backend/response"
+	"github.com/google/uuid"
+)
+
+type (
+	IMediaService interface {
+		GetAllWithPagination(ctx context.Context, req dto.MediaPaginationRequest) (dto.MediaPaginationResponse, error)
+		GetDetail(ctx context.Context, id string) (dto.MediaResponse, error)
+		Update(ctx context.Context, req dto.UpdateMediaRequest) (dto.MediaResponse, error)
+		Delete(ctx context.Context, id string) (dto.MediaResponse, error)
+		SignURL(ctx context.Context, req dto.SignMediaURLRequest) (dto.SignedMediaURLResponse, error)
+		Download(ctx context.Context, req dto.DownloadMediaRequest) (dto.MediaDownloadResponse, error)
+	}
+
+	mediaService struct {
+		mediaRepo     repository.IMediaRepository
+		referenceRepo repository.IUploadReferenceRepository
+		fileService   IFileService
+		auditLog      IAuditLogService
+	}
+)
+
+func NewMediaService(mediaRepo repository.IMediaRepository, referenceRepo repository.IUploadReferenceRepository, fileService IFileService, auditLog IAuditLogService) *mediaService {
+	return &mediaService{
+		mediaRepo:     mediaRepo,
+		referenceRepo: referenceRepo,
+		fileService:   fileService,
+		auditLog:      auditLog,
+	}
+}
+
+func (ms *mediaService) GetAllWithPagination(ctx context.Context, req dto.MediaPaginationRequest) (dto.MediaPaginationResponse, error) {
+	if req.Visibility != "" && req.Visibility != constants.ENUM_VISIBILITY_PUBLIC && req.Visibility != constants.ENUM_VISIBILITY_PRIVATE {
+		return dto.MediaPaginationResponse{}, dto.ErrInvalidVisibility
+	}
+
+	dataWithPaginate, err := ms.mediaRepo.GetAllWithPagination(ctx, nil, req)
+	if err != nil {
+		return dto.MediaPaginationResponse{}, dto.ErrGetAllMediaWithPagination
+	}
+
+	datas := []dto.MediaResponse{}
+	for _, media := range dataWithPaginate.Medias {
+		datas = append(datas, ms.mapMediaToResponse(&media))
+	}
+
+	return dto.MediaPaginationResponse{
+		Data: datas,
+		PaginationResponse: response.PaginationResponse{
+			Page:    dataWithPaginate.Page,
+			PerPage: dataWithPaginate.PerPage,
+			MaxPage: dataWithPaginate.MaxPage,
+			Count:   dataWithPaginate.Count,
+		},
+	}, nil
+}
+
+func (ms *mediaService) GetDetail(ctx context.Context, id string) (dto.MediaResponse, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return dto.MediaResponse{}, dto.ErrParseUUID
+	}
+
+	media, found, err := ms.mediaRepo.GetByID(ctx, nil, id)
+	if err != nil {
+		return dto.MediaResponse{}, dto.ErrGetMediaByID
+	}
+	if !found {
+		return dto.MediaResponse{}, dto.ErrMediaNotFound
+	}
+
+	return ms.mapMediaToResponse(media), nil
+}
+
+func (ms *mediaService) Update(ctx context.Context, req dto.UpdateMediaRequest) (dto.MediaResponse, error) {
+	if _, err := uuid.Parse(req.ID); err != nil {
+		return dto.MediaResponse{}, dto.ErrParseUUID
+	}
+
+	media, found, err := ms.mediaRepo.GetByID(ctx, nil, req.ID)
+	if err != nil {
+		return dto.MediaResponse{}, dto.ErrGetMediaByID
+	}
+	if !found {
+		return dto.MediaResponse{}, dto.ErrMediaNotFound
+	}
+
+	before := ms.mapMediaToResponse(media)
+
+	// handle alt request, string kosong berarti alt dihapus
+	if req.Alt != nil {
+		alt := strings.TrimSpace(*req.Alt)
+		if len(alt) > 255 {
+			return dto.MediaResponse{}, dto.ErrAltTooLong
+		}
+
+		media.Alt = alt
+	}
+
+	// handle caption request
+	if req.Caption != nil {
+		media.Caption = strings.TrimSpace(*req.Caption)
+	}
+
+	if err := ms.mediaRepo.Update(ctx, nil, media); err != nil {
+		return dto.MediaResponse{}, dto.ErrUpdateMedia
+	}
+
+	res := ms.mapMediaToResponse(media)
+
+	ms.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_MEDIA, media.ID.String(), before, res)
+
+	return res, nil
+}
+
+func (ms *mediaService) Delete(ctx context.Context, id string) (dto.MediaResponse, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return dto.MediaResponse{}, dto.ErrParseUUID
+	}
+
+	deletedMedia, found, err := ms.mediaRepo.GetByID(ctx, nil, id)
+	if err != nil {
+		return dto.MediaResponse{}, dto.ErrGetMediaByID
+	}
+	if !found {
+		return dto.MediaResponse{}, dto.ErrMediaNotFound
+	}
+
+	// file yang masih dipasang di berita, prestasi, avatar dsb. tidak boleh dihapus dari library
+	names, err := ms.referenceRepo.GetReferencedNames(ctx, nil)
+	if err != nil {
+		return dto.MediaResponse{}, dto.ErrDeleteMediaByID
+	}
+	if referencedKeys(names)[deletedMedia.Name] {
+		return dto.MediaResponse{}, dto.ErrMediaInUse
+	}
+
+	if err := ms.mediaRepo.DeleteByID(ctx, nil, id); err != nil {
+		return dto.MediaResponse{}, dto.ErrDeleteMediaByID
+	}
+
+	// file baru dihapus setelah row media terhapus, kalau gagal cukup jadi file yatim
+	ms.fileService.Remove(ctx, deletedMedia.Name, deletedMedia.Visibility)
+
+	res := ms.mapMediaToResponse(deletedMedia)
+
+	ms.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_DELETE, constants.ENUM_AUDIT_ENTITY_MEDIA, deletedMedia.ID.String(), res, nil)
+
+	return res, nil
+}
+
+// SignURL membuat link unduhan sementara, link yang sudah dibagikan tetap berlaku sampai expired
+func (ms *mediaService) SignURL(ctx context.Context, req dto.SignMediaURLRequest) (dto.SignedMediaURLResponse, error) {
+	if _, err := uuid.Parse(req.ID); err != nil {
+		return dto.SignedMediaURLResponse{}, dto.ErrParseUUID
+	}
+
+	// handle expires in request
+	ttl := time.Duration(constants.ENUM_SIGNED_URL_TTL_MINUTES) * time.Minute
+	if req.ExpiresIn != 0 {
+		ttl = time.Duration(req.ExpiresIn) * time.Second
+	}
+	if ttl <= 0 || ttl > time.Duration(constants.ENUM_SIGNED_URL_MAX_TTL_MINUTES)*time.Minute {
+		return dto.SignedMediaURLResponse{}, dto.ErrInvalidSignedURLTTL
+	}
+
+	media, found, err := ms.mediaRepo.GetByID(ctx, nil, req.ID)
+	if err != nil {
+		return dto.SignedMediaURLResponse{}, dto.ErrGetMediaByID
+	}
+	if !found {
+		return dto.SignedMediaURLResponse{}, dto.ErrMediaNotFound
+	}
+
+	expiresAt := time.Now().Add(ttl).Truncate(time.Second)
+	signature, err := helper.SignDownload(media.Name, expiresAt.Unix())
+	if err != nil {
+		return dto.SignedMediaURLResponse{}, dto.ErrSignMediaURL
+	}
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expiresAt.Unix(), 10))
+	query.Set("signature", signature)
+
+	res := dto.SignedMediaURLResponse{
+		URL:       "/files/" + url.PathEscape(media.Name) + "?" + query.Encode(),
+		ExpiresAt: expiresAt,
+	}
+
+	// jejak siapa membagikan dokumen private, signature-nya sendiri tidak dicatat
+	ms.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_SIGN_URL, constants.ENUM_AUDIT_ENTITY_MEDIA, media.ID.String(), nil, map[string]any{"expires_at": expiresAt})
+
+	return res, nil
+}
+
+// Download dipanggil tanpa login, aksesnya hanya dari signature
+func (ms *mediaService) Download(ctx context.Context, req dto.DownloadMediaRequest) (dto.MediaDownloadResponse, error) {
+	expires, err := strconv.ParseInt(req.Expires, 10, 64)
+	if err != nil || req.Signature == "" {
+		return dto.MediaDownloadResponse{}, dto.ErrInvalidSignature
+	}
+
+	// signature dicek lebih dulu supaya link palsu tidak bisa membedakan expired dan tidak ada
+	if !helper.VerifyDownload(req.Name, expires, req.Signature) {
+		return dto.MediaDownloadResponse{}, dto.ErrInvalidSignature
+	}
+	if time.Now().Unix() > expires {
+		return dto.MediaDownloadResponse{}, dto.ErrSignedURLExpired
+	}
+
+	media, found, err := ms.mediaRepo.GetByName(ctx, nil, req.Name)
+	if err != nil {
+		return dto.MediaDownloadResponse{}, dto.ErrGetFile
+	}
+	if !found {
+		return dto.MediaDownloadResponse{}, dto.ErrFileNotFound
+	}
+
+	open := ms.fileService.Open
+	if media.Visibility == constants.ENUM_VISIBILITY_PRIVATE {
+		open = ms.fileService.OpenPrivate
+	}
+
+	body, info, err := open(ctx, media.Name)
+	if err != nil {
+		return dto.MediaDownloadResponse{}, err
+	}
+
+	return dto.MediaDownloadResponse{
+		Filename: media.OriginalName,
+		Body:     body,
+		Info:     info,
+	}, nil
+}
+
+func (ms *mediaService) mapMediaToResponse(media *entity.Media) dto.MediaResponse {
+	res := dto.MediaResponse{
+		ID:           media.ID.String(),
+		Name:         media.Name,
+		OriginalName: media.OriginalName,
+		ContentType:  media.ContentType,
+		Size:         media.Size,
+		Hash:         media.Hash,
+		Width:        media.Width,
+		Height:       media.Height,
+		Alt:          media.Alt,
+		Caption:      media.Caption,
+		Visibility:   media.Visibility,
+		DetachedAt:   media.DetachedAt,
+		UploadedBy:   mapAuthor(media.CreatedByID, media.CreatedBy),
+		UpdatedBy:    mapAuthor(media.UpdatedByID, media.UpdatedBy),
+		CreatedAt:    media.CreatedAt,
+	}
+
+	if media.Visibility != constants.ENUM_VISIBILITY_PRIVATE {
+		res.URL = ms.fileService.publicURL(media.Name)
+		res.Sources = ms.fileService.ImageSources(media.Name)
+	}
+
+	return res
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/helper"
+)
+
+// kasus di sini ditolak sebelum menyentuh repository, jadi mediaService kosong cukup
+func TestMediaDownloadSignature(t *testing.T) {
+	t.Setenv("SIGNED_URL_SECRET", "rahasia-signed-url")
+
+	sign := func(name string, expires int64) string {
+		signature, err := helper.SignDownload(name, expires)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signature
+	}
+
+	future := time.Now().Add(time.Hour).Unix()
+	past := time.Now().Add(-time.Minute).Unix()
+
+	tests := []struct {
+		name    string
+		req     dto.DownloadMediaRequest
+		wantErr error
+	}{
+		{
+			name:    "expires bukan angka",
+			req:     dto.DownloadMediaRequest{Name: "a.pdf", Expires: "besok", Signature: sign("a.pdf", future)},
+			wantErr: dto.ErrInvalidSignature,
+		},
+		{
+			name:    "tanpa signature",
+			req:     dto.DownloadMediaRequest{Name: "a.pdf", Expires: strconv.FormatInt(future, 10)},
+			wantErr: dto.ErrInvalidSignature,
+		},
+		{
+			name:    "signature file lain",
+			req:     dto.DownloadMediaRequest{Name: "b.pdf", Expires: strconv.FormatInt(future, 10), Signature: sign("a.pdf", future)},
+			wantErr: dto.ErrInvalidSignature,
+		},
+		{
+			name:    "expiry diubah",
+			req:     dto.DownloadMediaRequest{Name: "a.pdf", Expires: strconv.FormatInt(future+60, 10), Signature: sign("a.pdf", future)},
+			wantErr: dto.ErrInvalidSignature,
+		},
+		{
+			// link palsu yang sudah lewat tetap invalid, bukan expired
+			name:    "signature palsu dan expired",
+			req:     dto.DownloadMediaRequest{Name: "a.pdf", Expires: strconv.FormatInt(past, 10), Signature: "palsu"},
+			wantErr: dto.ErrInvalidSignature,
+		},
+		{
+			name:    "signature valid tapi expired",
+			req:     dto.DownloadMediaRequest{Name: "a.pdf", Expires: strconv.FormatInt(past, 10), Signature: sign("a.pdf", past)},
+			wantErr: dto.ErrSignedURLExpired,
+		},
+	}
+
+	ms := &mediaService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ms.Download(context.Background(), tt.req); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Download err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/url"
 
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/dto"
 	"github.com/Amierza/nawasena-backend/entity"
 	"github.com/Amierza/nawasena-backend/jwt"
@@ -15,7 +16,7 @@ import (
 type (
 	IMemberService interface {
 		Create(ctx context.Context, req dto.CreateMemberRequest) (dto.MemberResponse, error)
-		GetAll(ctx context.Context) ([]dto.MemberResponse, error)
+		GetAll(ctx context.Context, createdBy string) ([]dto.MemberResponse, error)
 		GetAllWithPagination(ctx context.Context, req response.PaginationRequest) (dto.MemberPaginationResponse, error)
 		GetDetail(ctx context.Context, id string) (dto.MemberResponse, error)
 		Update(ctx context.Context, req dto.UpdateMemberRequest) (dto.MemberResponse, error)
@@ -25,14 +26,16 @@ type (
 	memberService struct {
 		memberRepo  repository.IMemberRepository
 		fileService IFileService
+		auditLog    IAuditLogService
 		jwt         jwt.IJWT
 	}
 )
 
-func NewMemberService(memberRepo repository.IMemberRepository, fileService IFileService, jwt jwt.IJWT) *memberService {
+func NewMemberService(memberRepo repository.IMemberRepository, fileService IFileService, auditLog IAuditLogService, jwt jwt.IJWT) *memberService {
 	return &memberService{
 		memberRepo:  memberRepo,
 		fileService: fileService,
+		auditLog:    auditLog,
 		jwt:         jwt,
 	}
 }
@@ -50,9 +53,9 @@ func (ms *memberService) Create(ctx context.Context, req dto.CreateMemberRequest
 	if req.Image == "" {
 		return dto.MemberResponse{}, dto.ErrEmptyImage
 	}
-	_, err := url.ParseRequestURI(req.Image)
+	image, err := ms.resolveImage(ctx, req.Image)
 	if err != nil {
-		return dto.MemberResponse{}, dto.ErrFormatImage
+		return dto.MemberResponse{}, err
 	}
 
 	// handle major request
@@ -84,7 +87,7 @@ func (ms *memberService) Create(ctx context.Context, req dto.CreateMemberRequest
 	member := &entity.Member{
 		ID:         id,
 		Name:       req.Name,
-		Image:      req.Image,
+		Image:      image,
 		Major:      req.Major,
 		Generation: req.Generation,
 		PositionID: &position.ID,
@@ -95,7 +98,7 @@ func (ms *memberService) Create(ctx context.Context, req dto.CreateMemberRequest
 		return dto.MemberResponse{}, dto.ErrCreateMember
 	}
 
-	return dto.MemberResponse{
+	res := dto.MemberResponse{
 		ID:         member.ID.String(),
 		Name:       member.Name,
 		Image:      member.Image,
@@ -106,11 +109,17 @@ func (ms *memberService) Create(ctx context.Context, req dto.CreateMemberRequest
 			Name:   position.Name,
 			IsTech: position.IsTech,
 		},
-	}, nil
+		CreatedBy: mapAuthor(member.CreatedByID, member.CreatedBy),
+		UpdatedBy: mapAuthor(member.UpdatedByID, member.UpdatedBy),
+	}
+
+	ms.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_CREATE, constants.ENUM_AUDIT_ENTITY_MEMBER, member.ID.String(), nil, res)
+
+	return res, nil
 }
 
-func (ms *memberService) GetAll(ctx context.Context) ([]dto.MemberResponse, error) {
-	members, err := ms.memberRepo.GetAll(ctx, nil)
+func (ms *memberService) GetAll(ctx context.Context, createdBy string) ([]dto.MemberResponse, error) {
+	members, err := ms.memberRepo.GetAll(ctx, nil, createdBy)
 	if err != nil {
 		return nil, dto.ErrGetAllMemberNoPagination
 	}
@@ -128,6 +137,8 @@ func (ms *memberService) GetAll(ctx context.Context) ([]dto.MemberResponse, erro
 				Name:   member.Position.Name,
 				IsTech: member.Position.IsTech,
 			},
+			CreatedBy: mapAuthor(member.CreatedByID, member.CreatedBy),
+			UpdatedBy: mapAuthor(member.UpdatedByID, member.UpdatedBy),
 		}
 
 		datas = append(datas, data)
@@ -155,6 +166,8 @@ func (ms *memberService) GetAllWithPagination(ctx context.Context, req response.
 				Name:   member.Position.Name,
 				IsTech: member.Position.IsTech,
 			},
+			CreatedBy: mapAuthor(member.CreatedByID, member.CreatedBy),
+			UpdatedBy: mapAuthor(member.UpdatedByID, member.UpdatedBy),
 		}
 
 		datas = append(datas, data)
@@ -201,6 +214,21 @@ func (ms *memberService) Update(ctx context.Context, req dto.UpdateMemberRequest
 		return dto.MemberResponse{}, dto.ErrMemberNotFound
 	}
 
+	before := dto.MemberResponse{
+		ID:         member.ID.String(),
+		Name:       member.Name,
+		Image:      member.Image,
+		Major:      member.Major,
+		Generation: member.Generation,
+		Position: dto.PositionResponse{
+			ID:     member.Position.ID.String(),
+			Name:   member.Position.Name,
+			IsTech: member.Position.IsTech,
+		},
+		CreatedBy: mapAuthor(member.CreatedByID, member.CreatedBy),
+		UpdatedBy: mapAuthor(member.UpdatedByID, member.UpdatedBy),
+	}
+
 	// handle name request
 	if req.Name != "" && req.Name != member.Name {
 		if len(req.Name) < 3 {
@@ -212,12 +240,12 @@ func (ms *memberService) Update(ctx context.Context, req dto.UpdateMemberRequest
 
 	// handle image request
 	if req.Image != "" && req.Image != member.Image {
-		_, err := url.ParseRequestURI(req.Image)
+		image, err := ms.resolveImage(ctx, req.Image)
 		if err != nil {
-			return dto.MemberResponse{}, dto.ErrFormatImage
+			return dto.MemberResponse{}, err
 		}
 
-		member.Image = req.Image
+		member.Image = image
 	}
 
 	// handle major request
@@ -265,8 +293,12 @@ func (ms *memberService) Update(ctx context.Context, req dto.UpdateMemberRequest
 			Name:   member.Position.Name,
 			IsTech: member.Position.IsTech,
 		},
+		CreatedBy: mapAuthor(member.CreatedByID, member.CreatedBy),
+		UpdatedBy: mapAuthor(member.UpdatedByID, member.UpdatedBy),
 	}
 
+	ms.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_MEMBER, member.ID.String(), before, res)
+
 	return res, nil
 }
 
@@ -295,7 +327,28 @@ func (ms *memberService) Delete(ctx context.Context, id string) (dto.MemberRespo
 			Name:   deletedMember.Position.Name,
 			IsTech: deletedMember.Position.IsTech,
 		},
+		CreatedBy: mapAuthor(deletedMember.CreatedByID, deletedMember.CreatedBy),
+		UpdatedBy: mapAuthor(deletedMember.UpdatedByID, deletedMember.UpdatedBy),
 	}
 
+	ms.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_DELETE, constants.ENUM_AUDIT_ENTITY_MEMBER, deletedMember.ID.String(), res, nil)
+
 	return res, nil
 }
+
+// resolveImage: image member disimpan sebagai url, jadi id media diubah ke url publiknya
+func (ms *memberService) resolveImage(ctx context.Context, value string) (string, error) {
+	name, err := ms.fileService.ResolveImage(ctx, value)
+	if err != nil {
+		return "", err
+	}
+	if name != value {
+		return ms.fileService.publicURL(name), nil
+	}
+
+	if _, err := url.ParseRequestURI(value); err != nil {
+		return "", dto.ErrFormatImage
+	}
+
+	return value, nil
+}
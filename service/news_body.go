@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/entity"
+	"github.com/Amierza/nawasena-backend/helper"
+	"github.com/lib/pq"
+)
+
+// renderNewsBody merender body Markdown lalu menyimpan HTML, excerpt, waktu baca, daftar isi dan
+// gambar yang dipakai ke news. Referensi gambar yang tidak valid membuat create / update gagal
+func (ns *newsService) renderNewsBody(ctx context.Context, news *entity.News) error {
+	doc, err := helper.RenderMarkdown(newsMarkdown(news), func(ref string) (helper.MarkdownImage, error) {
+		return ns.resolveBodyImage(ctx, ref)
+	})
+	if err != nil {
+		return err
+	}
+
+	applyNewsBody(news, doc)
+
+	return nil
+}
+
+// resolveBodyImage menerima id media, nama file upload, atau url /uploads/ hasil upload
+func (ns *newsService) resolveBodyImage(ctx context.Context, ref string) (helper.MarkdownImage, error) {
+	name, err := ns.fileService.ResolveImage(ctx, strings.TrimPrefix(ref, "/uploads/"))
+	if err != nil {
+		return helper.MarkdownImage{}, err
+	}
+
+	contentType, ok := allowedTypes[strings.ToLower(path.Ext(name))]
+	if !ok || !isImageType(contentType) || strings.ContainsAny(name, `/\`) {
+		return helper.MarkdownImage{}, dto.ErrInvalidBodyImage
+	}
+
+	image := helper.MarkdownImage{
+		Name: name,
+		URL:  ns.fileService.publicURL(name),
+	}
+	if sources := ns.fileService.ImageSources(name); sources != nil {
+		image.Srcset = sources.Srcset
+	}
+
+	return image, nil
+}
+
+// newsMarkdown: berita lama hanya punya description, isinya tetap dirender sebagai Markdown
+func newsMarkdown(news *entity.News) string {
+	if news.Body != "" {
+		return news.Body
+	}
+
+	return news.Description
+}
+
+func applyNewsBody(news *entity.News, doc helper.MarkdownDocument) {
+	news.BodyHTML = doc.HTML
+	news.Excerpt = doc.Excerpt
+	news.ReadingTime = doc.ReadingTime
+	news.BodyImages = pq.StringArray(doc.Images)
+	news.TableOfContents = []entity.NewsHeading{}
+	for _, heading := range doc.Headings {
+		news.TableOfContents = append(news.TableOfContents, entity.NewsHeading{
+			Level: heading.Level,
+			Text:  heading.Text,
+			ID:    heading.ID,
+		})
+	}
+}
+
+func mapNewsBody(res *dto.NewsResponse, news *entity.News) {
+	// berita yang dibuat sebelum ada body Markdown belum punya hasil render, dirender tanpa disimpan
+	if news.BodyHTML == "" && newsMarkdown(news) != "" {
+		if doc, err := helper.RenderMarkdown(newsMarkdown(news), nil); err == nil {
+			rendered := *news
+			applyNewsBody(&rendered, doc)
+			news = &rendered
+		}
+	}
+
+	res.Summary = news.Summary
+	res.Excerpt = news.Summary
+	if res.Excerpt == "" {
+		res.Excerpt = news.Excerpt
+	}
+	res.Body = news.Body
+	res.BodyHTML = news.BodyHTML
+	res.ReadingTime = news.ReadingTime
+	res.TableOfContents = []dto.NewsHeadingResponse{}
+	for _, heading := range news.TableOfContents {
+		res.TableOfContents = append(res.TableOfContents, dto.NewsHeadingResponse{
+			Level: heading.Level,
+			Text:  heading.Text,
+			ID:    heading.ID,
+		})
+	}
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/dto"
 	"github.com/Amierza/nawasena-backend/entity"
 	"github.com/Amierza/nawasena-backend/jwt"
@@ -13,7 +14,7 @@ import (
 type (
 	INewsCategoryService interface {
 		Create(ctx context.Context, req dto.CreateNewsCategoryRequest) (dto.NewsCategoryResponse, error)
-		GetAll(ctx context.Context) ([]dto.NewsCategoryResponse, error)
+		GetAll(ctx context.Context, createdBy string) ([]dto.NewsCategoryResponse, error)
 		GetDetail(ctx context.Context, id string) (dto.NewsCategoryResponse, error)
 		Update(ctx context.Context, req dto.UpdateNewsCategoryRequest) (dto.NewsCategoryResponse, error)
 		Delete(ctx context.Context, id string) (dto.NewsCategoryResponse, error)
@@ -21,13 +22,15 @@ type (
 
 	newsCategoryService struct {
 		newsCategoryRepo repository.INewsCategoryRepository
+		auditLog         IAuditLogService
 		jwt              jwt.IJWT
 	}
 )
 
-func NewNewsCategoryService(newsCategoryRepo repository.INewsCategoryRepository, jwt jwt.IJWT) *newsCategoryService {
+func NewNewsCategoryService(newsCategoryRepo repository.INewsCategoryRepository, auditLog IAuditLogService, jwt jwt.IJWT) *newsCategoryService {
 	return &newsCategoryService{
 		newsCategoryRepo: newsCategoryRepo,
+		auditLog:         auditLog,
 		jwt:              jwt,
 	}
 }
@@ -58,14 +61,20 @@ func (as *newsCategoryService) Create(ctx context.Context, req dto.CreateNewsCat
 		return dto.NewsCategoryResponse{}, dto.ErrCreateNewsCategory
 	}
 
-	return dto.NewsCategoryResponse{
-		ID:   newsCategory.ID.String(),
-		Name: newsCategory.Name,
-	}, nil
+	res := dto.NewsCategoryResponse{
+		ID:        newsCategory.ID.String(),
+		Name:      newsCategory.Name,
+		CreatedBy: mapAuthor(newsCategory.CreatedByID, newsCategory.CreatedBy),
+		UpdatedBy: mapAuthor(newsCategory.UpdatedByID, newsCategory.UpdatedBy),
+	}
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_CREATE, constants.ENUM_AUDIT_ENTITY_NEWS_CATEGORY, newsCategory.ID.String(), nil, res)
+
+	return res, nil
 }
 
-func (as *newsCategoryService) GetAll(ctx context.Context) ([]dto.NewsCategoryResponse, error) {
-	newsCategorys, err := as.newsCategoryRepo.GetAll(ctx, nil)
+func (as *newsCategoryService) GetAll(ctx context.Context, createdBy string) ([]dto.NewsCategoryResponse, error) {
+	newsCategorys, err := as.newsCategoryRepo.GetAll(ctx, nil, createdBy)
 	if err != nil {
 		return nil, dto.ErrGetAllNewsCategory
 	}
@@ -73,8 +82,10 @@ func (as *newsCategoryService) GetAll(ctx context.Context) ([]dto.NewsCategoryRe
 	var datas []dto.NewsCategoryResponse
 	for _, newsCategory := range newsCategorys {
 		data := dto.NewsCategoryResponse{
-			ID:   newsCategory.ID.String(),
-			Name: newsCategory.Name,
+			ID:        newsCategory.ID.String(),
+			Name:      newsCategory.Name,
+			CreatedBy: mapAuthor(newsCategory.CreatedByID, newsCategory.CreatedBy),
+			UpdatedBy: mapAuthor(newsCategory.UpdatedByID, newsCategory.UpdatedBy),
 		}
 
 		datas = append(datas, data)
@@ -90,8 +101,10 @@ func (as *newsCategoryService) GetDetail(ctx context.Context, id string) (dto.Ne
 	}
 
 	res := dto.NewsCategoryResponse{
-		ID:   newsCategory.ID.String(),
-		Name: newsCategory.Name,
+		ID:        newsCategory.ID.String(),
+		Name:      newsCategory.Name,
+		CreatedBy: mapAuthor(newsCategory.CreatedByID, newsCategory.CreatedBy),
+		UpdatedBy: mapAuthor(newsCategory.UpdatedByID, newsCategory.UpdatedBy),
 	}
 
 	return res, nil
@@ -107,6 +120,13 @@ func (as *newsCategoryService) Update(ctx context.Context, req dto.UpdateNewsCat
 		return dto.NewsCategoryResponse{}, dto.ErrNewsCategoryNotFound
 	}
 
+	before := dto.NewsCategoryResponse{
+		ID:        newsCategory.ID.String(),
+		Name:      newsCategory.Name,
+		CreatedBy: mapAuthor(newsCategory.CreatedByID, newsCategory.CreatedBy),
+		UpdatedBy: mapAuthor(newsCategory.UpdatedByID, newsCategory.UpdatedBy),
+	}
+
 	// handle name request
 	if req.Name != "" && req.Name != newsCategory.Name {
 		if len(req.Name) < 3 {
@@ -121,10 +141,16 @@ func (as *newsCategoryService) Update(ctx context.Context, req dto.UpdateNewsCat
 		return dto.NewsCategoryResponse{}, dto.ErrUpdateNewsCategory
 	}
 
-	return dto.NewsCategoryResponse{
-		ID:   newsCategory.ID.String(),
-		Name: newsCategory.Name,
-	}, nil
+	res := dto.NewsCategoryResponse{
+		ID:        newsCategory.ID.String(),
+		Name:      newsCategory.Name,
+		CreatedBy: mapAuthor(newsCategory.CreatedByID, newsCategory.CreatedBy),
+		UpdatedBy: mapAuthor(newsCategory.UpdatedByID, newsCategory.UpdatedBy),
+	}
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_NEWS_CATEGORY, newsCategory.ID.String(), before, res)
+
+	return res, nil
 }
 
 func (as *newsCategoryService) Delete(ctx context.Context, id string) (dto.NewsCategoryResponse, error) {
@@ -143,9 +169,13 @@ func (as *newsCategoryService) Delete(ctx context.Context, id string) (dto.NewsC
 	}
 
 	res := dto.NewsCategoryResponse{
-		ID:   deletedNewsCategory.ID.String(),
-		Name: deletedNewsCategory.Name,
+		ID:        deletedNewsCategory.ID.String(),
+		Name:      deletedNewsCategory.Name,
+		CreatedBy: mapAuthor(deletedNewsCategory.CreatedByID, deletedNewsCategory.CreatedBy),
+		UpdatedBy: mapAuthor(deletedNewsCategory.UpdatedByID, deletedNewsCategory.UpdatedBy),
 	}
 
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_DELETE, constants.ENUM_AUDIT_ENTITY_NEWS_CATEGORY, deletedNewsCategory.ID.String(), res, nil)
+
 	return res, nil
 }
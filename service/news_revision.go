@@ -0,0 +1,425 @@
+package service
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/Amierza/nawasena-backend/constants"
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/entity"
+	"github.com/Amierza/nawasena-backend/repository"
+	"github.com/google/uuid"
+)
+
+func (ns *newsService) GetRevisions(ctx context.Context, id string) ([]dto.NewsRevisionResponse, error) {
+	_, found, err := ns.newsRepo.GetByID(ctx, nil, id)
+	if err != nil || !found {
+		return nil, dto.ErrNewsNotFound
+	}
+
+	revisions, err := ns.newsRepo.GetRevisions(ctx, nil, id)
+	if err != nil {
+		return nil, dto.ErrGetNewsRevisions
+	}
+
+	datas := []dto.NewsRevisionResponse{}
+	for _, revision := range revisions {
+		datas = append(datas, ns.mapNewsRevisionToResponse(revision))
+	}
+
+	return datas, nil
+}
+
+func (ns *newsService) GetRevision(ctx context.Context, id string, number int) (dto.NewsRevisionResponse, error) {
+	revision, err := ns.getNewsRevision(ctx, id, number)
+	if err != nil {
+		return dto.NewsRevisionResponse{}, err
+	}
+
+	return ns.mapNewsRevisionToResponse(revision), nil
+}
+
+// DiffRevisions membandingkan dua revisi, kalau to kosong revisi from dibandingkan dengan isi berita saat ini
+func (ns *newsService) DiffRevisions(ctx context.Context, req dto.NewsRevisionDiffRequest) (dto.NewsRevisionDiffResponse, error) {
+	if req.From < 1 || req.To < 0 {
+		return dto.NewsRevisionDiffResponse{}, dto.ErrInvalidRevisionNumber
+	}
+
+	from, err := ns.getNewsRevision(ctx, req.ID, req.From)
+	if err != nil {
+		return dto.NewsRevisionDiffResponse{}, err
+	}
+
+	var to *entity.NewsRevision
+	if req.To == 0 {
+		news, found, err := ns.newsRepo.GetByID(ctx, nil, req.ID)
+		if err != nil || !found {
+			return dto.NewsRevisionDiffResponse{}, dto.ErrNewsNotFound
+		}
+		to = newsRevisionOf(news, news.Images)
+	} else {
+		to, err = ns.getNewsRevision(ctx, req.ID, req.To)
+		if err != nil {
+			return dto.NewsRevisionDiffResponse{}, err
+		}
+	}
+
+	return dto.NewsRevisionDiffResponse{
+		From:    req.From,
+		To:      req.To,
+		Changes: diffNewsRevisions(from, to),
+	}, nil
+}
+
+// RestoreRevision mengembalikan isi berita ke revisi tertentu. Isi sebelum restore ikut disimpan
+// sebagai revisi baru, jadi restore yang salah bisa dibatalkan dengan restore lagi
+func (ns *newsService) RestoreRevision(ctx context.Context, id string, number int) (dto.NewsResponse, error) {
+	news, found, err := ns.newsRepo.GetByID(ctx, nil, id)
+	if err != nil {
+		return dto.NewsResponse{}, dto.ErrGetNewsByID
+	}
+	if !found {
+		return dto.NewsResponse{}, dto.ErrNewsNotFound
+	}
+
+	revision, err := ns.getNewsRevision(ctx, id, number)
+	if err != nil {
+		return dto.NewsResponse{}, err
+	}
+
+	before := ns.mapNewsToResponse(news)
+	current := newsRevisionOf(news, news.Images)
+
+	// handle name, nama lama bisa saja sudah dipakai berita lain
+	if revision.Name != news.Name {
+		other, found, _ := ns.newsRepo.GetByName(ctx, nil, revision.Name)
+		if found && other.ID != news.ID {
+			return dto.NewsResponse{}, dto.ErrNewsAlreadyExists
+		}
+	}
+
+	// handle news category
+	if revision.CategoryID != nil && (news.NewsCategoryID == nil || *revision.CategoryID != *news.NewsCategoryID) {
+		category, found, _ := ns.newsRepo.GetCategoryByCategoryID(ctx, nil, revision.CategoryID.String())
+		if !found {
+			return dto.NewsResponse{}, dto.ErrNewsCategoryNotFound
+		}
+		news.NewsCategoryID = revision.CategoryID
+		news.NewsCategory = *category
+	}
+
+	news.Name = revision.Name
+	news.Description = revision.Description
+	news.Summary = revision.Summary
+	news.Body = revision.Body
+	news.Location = revision.Location
+	news.URL = revision.URL
+	news.Status = revision.Status
+	news.Featured = revision.Featured
+
+	oldSlug := news.Slug
+	if news.Name != before.Name || news.Slug == "" {
+		news.Slug, err = ns.slugService.Generate(ctx, constants.ENUM_AUDIT_ENTITY_NEWS, news.Name, news.ID.String())
+		if err != nil {
+			return dto.NewsResponse{}, err
+		}
+	}
+
+	// referensi gambar di body di-resolve ulang, media yang sudah dihapus membuat restore gagal
+	if err := ns.renderNewsBody(ctx, news); err != nil {
+		return dto.NewsResponse{}, err
+	}
+
+	// handle images, gambar yang namanya sama dipakai ulang lalu caption, alt & posisinya ditimpa
+	var existing, names []string
+	for _, img := range news.Images {
+		existing = append(existing, img.Name)
+	}
+	for _, img := range revision.Images {
+		names = append(names, img.Name)
+	}
+
+	var (
+		newsImages    []entity.NewsImage
+		removedImages []entity.NewsImage
+	)
+	reuse, removed := planGallerySync(existing, names)
+	for i, img := range revision.Images {
+		image := entity.NewsImage{
+			ID:     uuid.New(),
+			Name:   img.Name,
+			NewsID: &news.ID,
+		}
+		if reuse[i] >= 0 {
+			image = news.Images[reuse[i]]
+		}
+		image.Gallery = img.Gallery
+
+		newsImages = append(newsImages, image)
+	}
+	for _, index := range removed {
+		removedImages = append(removedImages, news.Images[index])
+	}
+	arrangeGallery(newsGalleries(newsImages))
+	news.Images = newsImages
+
+	err = ns.newsRepo.RunInTransaction(ctx, func(txRepo repository.INewsRepository) error {
+		if err := ns.saveNewsRevision(ctx, txRepo, current); err != nil {
+			return err
+		}
+
+		if err := txRepo.UpdateContent(ctx, nil, news); err != nil {
+			return dto.ErrUpdateNews
+		}
+		if err := txRepo.UpdateBody(ctx, nil, news); err != nil {
+			return dto.ErrUpdateNews
+		}
+
+		for _, img := range removedImages {
+			if err := txRepo.DeleteImageByID(ctx, nil, news.ID.String(), img.ID.String()); err != nil {
+				return dto.ErrDeleteImage
+			}
+		}
+
+		return ns.saveNewsImages(ctx, txRepo, news.Images)
+	})
+	if err != nil {
+		return dto.NewsResponse{}, err
+	}
+
+	if err := ns.slugService.Rename(ctx, constants.ENUM_AUDIT_ENTITY_NEWS, news.ID.String(), oldSlug, news.Slug); err != nil {
+		log.Printf("slug redirect news %s: %v", news.ID, err)
+	}
+
+	res := ns.mapNewsToResponse(news)
+
+	ns.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_RESTORE, constants.ENUM_AUDIT_ENTITY_NEWS, news.ID.String(), before, res)
+
+	return res, nil
+}
+
+func (ns *newsService) getNewsRevision(ctx context.Context, id string, number int) (*entity.NewsRevision, error) {
+	if number < 1 {
+		return nil, dto.ErrInvalidRevisionNumber
+	}
+
+	revision, found, err := ns.newsRepo.GetRevisionByNumber(ctx, nil, id, number)
+	if err != nil {
+		return nil, dto.ErrGetNewsRevision
+	}
+	if !found {
+		return nil, dto.ErrNewsRevisionNotFound
+	}
+
+	return revision, nil
+}
+
+// saveNewsRevision memberi nomor berikutnya lalu menyimpan revisi, harus dipanggil di dalam transaksi
+func (ns *newsService) saveNewsRevision(ctx context.Context, txRepo repository.INewsRepository, revision *entity.NewsRevision) error {
+	last, err := txRepo.GetLastRevisionNumber(ctx, nil, revision.NewsID.String())
+	if err != nil {
+		return dto.ErrCreateNewsRevision
+	}
+
+	revision.ID = uuid.New()
+	revision.Number = last + 1
+	for i := range revision.Images {
+		revision.Images[i].ID = uuid.New()
+		revision.Images[i].NewsRevisionID = revision.ID
+	}
+
+	if err := txRepo.CreateRevision(ctx, nil, revision); err != nil {
+		return dto.ErrCreateNewsRevision
+	}
+
+	return nil
+}
+
+func (ns *newsService) mapNewsRevisionToResponse(revision *entity.NewsRevision) dto.NewsRevisionResponse {
+	res := dto.NewsRevisionResponse{
+		ID:          revision.ID.String(),
+		Number:      revision.Number,
+		Name:        revision.Name,
+		Description: revision.Description,
+		Summary:     revision.Summary,
+		Body:        revision.Body,
+		Location:    revision.Location,
+		URL:         revision.URL,
+		Status:      revision.Status,
+		Featured:    revision.Featured,
+		Images:      mapNewsRevisionImages(revision.Images),
+		CreatedAt:   revision.CreatedAt.String(),
+		CreatedBy:   mapAuthor(revision.CreatedByID, revision.CreatedBy),
+	}
+	if revision.CategoryID != nil {
+		res.CategoryID = revision.CategoryID.String()
+	}
+	for i := range res.Images {
+		res.Images[i].Sources = ns.fileService.ImageSources(res.Images[i].Name)
+	}
+
+	return res
+}
+
+func mapNewsRevisionImages(images []entity.NewsRevisionImage) []dto.NewsRevisionImageResponse {
+	datas := []dto.NewsRevisionImageResponse{}
+	for _, img := range images {
+		datas = append(datas, dto.NewsRevisionImageResponse{
+			Name:     img.Name,
+			Position: img.Position,
+			Caption:  img.Caption,
+			Alt:      img.Alt,
+			IsCover:  img.IsCover,
+		})
+	}
+
+	return datas
+}
+
+// newsRevisionOf menyalin isi berita, images dipisah karena Update baru menempelkan galeri baru setelah transaksi
+func newsRevisionOf(news *entity.News, images []entity.NewsImage) *entity.NewsRevision {
+	revision := &entity.NewsRevision{
+		NewsID:      news.ID,
+		Name:        news.Name,
+		Description: news.Description,
+		Summary:     news.Summary,
+		Body:        news.Body,
+		BodyImages:  news.BodyImages,
+		Location:    news.Location,
+		URL:         news.URL,
+		Status:      news.Status,
+		Featured:    news.Featured,
+		CategoryID:  news.NewsCategoryID,
+	}
+	for _, img := range images {
+		revision.Images = append(revision.Images, entity.NewsRevisionImage{
+			Name:    img.Name,
+			Gallery: img.Gallery,
+		})
+	}
+
+	return revision
+}
+
+func diffNewsRevisions(before, after *entity.NewsRevision) []dto.NewsFieldChange {
+	changes := []dto.NewsFieldChange{}
+	add := func(field string, from, to any) {
+		changes = append(changes, dto.NewsFieldChange{Field: field, Before: from, After: to})
+	}
+
+	if before.Name != after.Name {
+		add("name", before.Name, after.Name)
+	}
+	if before.Description != after.Description {
+		add("description", before.Description, after.Description)
+		changes[len(changes)-1].Lines, changes[len(changes)-1].TooLarge = diffLines(before.Description, after.Description)
+	}
+	if before.Summary != after.Summary {
+		add("summary", before.Summary, after.Summary)
+	}
+	if before.Body != after.Body {
+		add("body", before.Body, after.Body)
+		changes[len(changes)-1].Lines, changes[len(changes)-1].TooLarge = diffLines(before.Body, after.Body)
+	}
+	if before.Location != after.Location {
+		add("location", before.Location, after.Location)
+	}
+	if before.URL != after.URL {
+		add("url", before.URL, after.URL)
+	}
+	if before.Status != after.Status {
+		add("status", before.Status, after.Status)
+	}
+	if before.Featured != after.Featured {
+		add("featured", before.Featured, after.Featured)
+	}
+
+	var beforeCategory, afterCategory string
+	if before.CategoryID != nil {
+		beforeCategory = before.CategoryID.String()
+	}
+	if after.CategoryID != nil {
+		afterCategory = after.CategoryID.String()
+	}
+	if beforeCategory != afterCategory {
+		add("category_id", beforeCategory, afterCategory)
+	}
+
+	beforeImages, afterImages := mapNewsRevisionImages(before.Images), mapNewsRevisionImages(after.Images)
+	if !slices.Equal(beforeImages, afterImages) {
+		add("images", beforeImages, afterImages)
+	}
+
+	return changes
+}
+
+// diffLines membandingkan teks per baris dengan longest common subsequence. Baris awal & akhir
+// yang sama dipotong dulu, kalau sisanya masih melebihi ENUM_NEWS_DIFF_MAX_CELLS tabel LCS tidak
+// dibuat dan hasilnya ditandai terlalu besar (before & after tetap dikirim utuh)
+func diffLines(before, after string) ([]dto.DiffLine, bool) {
+	a, b := strings.Split(before, "\n"), strings.Split(after, "\n")
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var lines []dto.DiffLine
+	for _, line := range a[:prefix] {
+		lines = append(lines, dto.DiffLine{Op: "equal", Text: line})
+	}
+	tail := a[len(a)-suffix:]
+	a, b = a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	if (len(a)+1)*(len(b)+1) > constants.ENUM_NEWS_DIFF_MAX_CELLS {
+		return nil, true
+	}
+
+	// lcs[i][j] adalah panjang LCS dari a[i:] dan b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, dto.DiffLine{Op: "equal", Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, dto.DiffLine{Op: "delete", Text: a[i]})
+			i++
+		default:
+			lines = append(lines, dto.DiffLine{Op: "insert", Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, dto.DiffLine{Op: "delete", Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, dto.DiffLine{Op: "insert", Text: b[j]})
+	}
+	for _, line := range tail {
+		lines = append(lines, dto.DiffLine{Op: "equal", Text: line})
+	}
+
+	return lines, false
+}
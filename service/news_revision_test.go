@@ -0,0 +1,126 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Amierza/nawasena-backend/dto"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   string
+	}{
+		{
+			name:   "sama persis",
+			before: "a\nb",
+			after:  "a\nb",
+			want:   "=a =b",
+		},
+		{
+			name:   "baris diganti di tengah",
+			before: "judul\nlama\npenutup",
+			after:  "judul\nbaru\npenutup",
+			want:   "=judul -lama +baru =penutup",
+		},
+		{
+			name:   "baris ditambah di akhir",
+			before: "a",
+			after:  "a\nb\nc",
+			want:   "=a +b +c",
+		},
+		{
+			name:   "baris dihapus di awal",
+			before: "a\nb\nc",
+			after:  "b\nc",
+			want:   "-a =b =c",
+		},
+		{
+			name:   "urutan ditukar",
+			before: "a\nb\nc",
+			after:  "c\nb\na",
+			want:   "-a -b =c +b +a",
+		},
+		{
+			name:   "dari kosong",
+			before: "",
+			after:  "isi",
+			want:   "- +isi",
+		},
+	}
+
+	ops := map[string]string{"equal": "=", "insert": "+", "delete": "-"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, tooLarge := diffLines(tt.before, tt.after)
+			if tooLarge {
+				t.Fatal("diffLines reported too large for a small input")
+			}
+
+			var got []string
+			for _, line := range lines {
+				got = append(got, ops[line.Op]+line.Text)
+			}
+			if strings.Join(got, " ") != tt.want {
+				t.Errorf("diffLines = %q, want %q", strings.Join(got, " "), tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffLinesAppliesToAfter(t *testing.T) {
+	before := "pembuka\nsatu\ndua\ntiga\nempat\npenutup"
+	after := "pembuka\ndua\ntiga baru\nempat\nlima\npenutup"
+
+	lines, _ := diffLines(before, after)
+
+	// baris equal + delete harus membentuk teks lama, equal + insert teks baru
+	var gotBefore, gotAfter []string
+	for _, line := range lines {
+		if line.Op != "insert" {
+			gotBefore = append(gotBefore, line.Text)
+		}
+		if line.Op != "delete" {
+			gotAfter = append(gotAfter, line.Text)
+		}
+	}
+	if strings.Join(gotBefore, "\n") != before {
+		t.Errorf("before reconstructed as %q", strings.Join(gotBefore, "\n"))
+	}
+	if strings.Join(gotAfter, "\n") != after {
+		t.Errorf("after reconstructed as %q", strings.Join(gotAfter, "\n"))
+	}
+}
+
+func TestDiffLinesTooLarge(t *testing.T) {
+	var a, b []string
+	for i := 0; i < 2000; i++ {
+		a = append(a, "lama "+strings.Repeat("x", i%7))
+		b = append(b, "baru "+strings.Repeat("y", i%5))
+	}
+
+	lines, tooLarge := diffLines(strings.Join(a, "\n"), strings.Join(b, "\n"))
+	if !tooLarge || lines != nil {
+		t.Errorf("diffLines = %d lines, tooLarge %v, want nil and true", len(lines), tooLarge)
+	}
+
+	// body panjang yang hanya berubah satu baris tetap bisa dibandingkan karena awal & akhir dipotong
+	b = append([]string(nil), a...)
+	b[1000] = "diubah"
+	lines, tooLarge = diffLines(strings.Join(a, "\n"), strings.Join(b, "\n"))
+	if tooLarge {
+		t.Fatal("single-line change in a long body reported too large")
+	}
+	changed := []dto.DiffLine{}
+	for _, line := range lines {
+		if line.Op != "equal" {
+			changed = append(changed, line)
+		}
+	}
+	if len(changed) != 2 || changed[0].Op != "delete" || changed[1] != (dto.DiffLine{Op: "insert", Text: "diubah"}) {
+		t.Errorf("changed lines = %+v", changed)
+	}
+}
@@ -2,9 +2,14 @@ package service
 
 import (
 	"context"
+	"log"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/dto"
 	"github.com/Amierza/nawasena-backend/entity"
 	"github.com/Amierza/nawasena-backend/jwt"
@@ -16,24 +21,46 @@ import (
 type (
 	INewsService interface {
 		Create(ctx context.Context, req dto.CreateNewsRequest) (dto.NewsResponse, error)
-		GetAll(ctx context.Context) ([]dto.NewsResponse, error)
-		GetAllWithPagination(ctx context.Context, req response.PaginationRequest) (dto.NewsPaginationResponse, error)
+		GetAll(ctx context.Context, req dto.NewsPaginationRequest) ([]dto.NewsResponse, error)
+		GetAllWithPagination(ctx context.Context, req dto.NewsPaginationRequest) (dto.NewsPaginationResponse, error)
 		GetFeatured(ctx context.Context, limit string) ([]dto.NewsResponse, error)
 		GetDetail(ctx context.Context, id string) (dto.NewsResponse, error)
+		GetTags(ctx context.Context) ([]dto.NewsTagResponse, error)
+		GetRelated(ctx context.Context, id, limit string) ([]dto.NewsResponse, error)
+		GetAdminDetail(ctx context.Context, id string) (dto.NewsResponse, error)
 		Update(ctx context.Context, req dto.UpdateNewsRequest) (dto.NewsResponse, error)
 		Delete(ctx context.Context, id string) (dto.NewsResponse, error)
+		AddImage(ctx context.Context, req dto.AddImageRequest) (dto.NewsResponse, error)
+		UpdateImage(ctx context.Context, req dto.UpdateImageRequest) (dto.NewsResponse, error)
+		RemoveImage(ctx context.Context, id, imageID string) (dto.NewsResponse, error)
+		ReorderImages(ctx context.Context, req dto.ReorderImagesRequest) (dto.NewsResponse, error)
+		GetRevisions(ctx context.Context, id string) ([]dto.NewsRevisionResponse, error)
+		GetRevision(ctx context.Context, id string, number int) (dto.NewsRevisionResponse, error)
+		DiffRevisions(ctx context.Context, req dto.NewsRevisionDiffRequest) (dto.NewsRevisionDiffResponse, error)
+		RestoreRevision(ctx context.Context, id string, number int) (dto.NewsResponse, error)
+		PublishScheduled(ctx context.Context) (int, error)
+		Start(ctx context.Context)
 	}
 
 	newsService struct {
-		newsRepo repository.INewsRepository
-		jwt      jwt.IJWT
+		newsRepo        repository.INewsRepository
+		fileService     IFileService
+		slugService     ISlugService
+		auditLog        IAuditLogService
+		jwt             jwt.IJWT
+		publishInterval time.Duration
 	}
 )
 
-func NewNewsService(newsRepo repository.INewsRepository, jwt jwt.IJWT) *newsService {
+// NewNewsService membaca NEWS_PUBLISH_INTERVAL_SECONDS (default 60 detik) untuk scheduler berita terjadwal
+func NewNewsService(newsRepo repository.INewsRepository, fileService IFileService, slugService ISlugService, auditLog IAuditLogService, jwt jwt.IJWT) *newsService {
 	return &newsService{
-		newsRepo: newsRepo,
-		jwt:      jwt,
+		newsRepo:        newsRepo,
+		fileService:     fileService,
+		slugService:     slugService,
+		auditLog:        auditLog,
+		jwt:             jwt,
+		publishInterval: time.Duration(intEnv("NEWS_PUBLISH_INTERVAL_SECONDS", constants.ENUM_NEWS_PUBLISH_INTERVAL_SECONDS)) * time.Second,
 	}
 }
 
@@ -46,20 +73,31 @@ func (ns *newsService) Create(ctx context.Context, req dto.CreateNewsRequest) (d
 		return dto.NewsResponse{}, dto.ErrNameTooShort
 	}
 
-	// handle description request
-	if req.Description == "" {
+	// handle description request, boleh kosong kalau isi berita dikirim lewat body Markdown
+	if req.Description == "" && req.Body == "" {
 		return dto.NewsResponse{}, dto.ErrEmptyDescription
 	}
-	if len(req.Description) < 5 {
+	if req.Description != "" && len(req.Description) < 5 {
 		return dto.NewsResponse{}, dto.ErrDescriptionTooShort
 	}
 
+	// handle summary request
+	if utf8.RuneCountInString(req.Summary) > constants.ENUM_NEWS_SUMMARY_MAX_LENGTH {
+		return dto.NewsResponse{}, dto.ErrSummaryTooLong
+	}
+
 	// handle location request
 	if req.Location == "" {
 		return dto.NewsResponse{}, dto.ErrEmptyLocation
 	}
-	if len(req.Description) < 5 {
-		return dto.NewsResponse{}, dto.ErrDescriptionTooShort
+	if len(req.Location) < 5 {
+		return dto.NewsResponse{}, dto.ErrLocationTooShort
+	}
+
+	// handle tags request, boleh kosong
+	tags, err := normalizeNewsTags(req.Tags)
+	if err != nil {
+		return dto.NewsResponse{}, err
 	}
 
 	// handle status request
@@ -92,12 +130,20 @@ func (ns *newsService) Create(ctx context.Context, req dto.CreateNewsRequest) (d
 	// handle published at for instance
 	publishedAt := time.Now()
 
+	slug, err := ns.slugService.Generate(ctx, constants.ENUM_AUDIT_ENTITY_NEWS, req.Name, "")
+	if err != nil {
+		return dto.NewsResponse{}, err
+	}
+
 	// create instance
 	newsID := uuid.New()
 	news := &entity.News{
 		ID:             newsID,
 		Name:           req.Name,
+		Slug:           slug,
 		Description:    req.Description,
+		Summary:        req.Summary,
+		Body:           req.Body,
 		PublishedAt:    publishedAt,
 		Location:       req.Location,
 		URL:            req.URL,
@@ -106,29 +152,41 @@ func (ns *newsService) Create(ctx context.Context, req dto.CreateNewsRequest) (d
 		NewsCategoryID: &categoryID,
 	}
 
-	// handle image url
-	var (
-		newsImages         []*entity.NewsImage
-		newsImageResponses []dto.NewsImageResponse
-	)
+	// handle publication request, kosong berarti langsung published kecuali publish_at diisi
+	publication := req.PublicationStatus
+	if publication == "" {
+		publication = constants.ENUM_PUBLICATION_PUBLISHED
+		if req.PublishAt != nil {
+			publication = constants.ENUM_PUBLICATION_SCHEDULED
+		}
+	}
+	if err := applyNewsPublication(news, publication, req.PublishAt, publishedAt); err != nil {
+		return dto.NewsResponse{}, err
+	}
+
+	// handle body request
+	if err := ns.renderNewsBody(ctx, news); err != nil {
+		return dto.NewsResponse{}, err
+	}
+
+	// handle image url, urutan request jadi urutan galeri dan gambar pertama jadi cover
+	var newsImages []entity.NewsImage
 	if len(req.Images) == 0 {
 		return dto.NewsResponse{}, dto.ErrEmptyImages
 	}
-	for _, imgName := range req.Images {
-		imgID := uuid.New()
-		// handle entity
-		newsImages = append(newsImages, &entity.NewsImage{
-			ID:     imgID,
+	for _, value := range req.Images {
+		imgName, err := ns.fileService.ResolveImage(ctx, value)
+		if err != nil {
+			return dto.NewsResponse{}, err
+		}
+
+		newsImages = append(newsImages, entity.NewsImage{
+			ID:     uuid.New(),
 			Name:   imgName,
 			NewsID: &newsID,
 		})
-
-		// handle response
-		newsImageResponses = append(newsImageResponses, dto.NewsImageResponse{
-			ID:   imgID.String(),
-			Name: imgName,
-		})
 	}
+	arrangeGallery(newsGalleries(newsImages))
 
 	err = ns.newsRepo.RunInTransaction(ctx, func(txRepo repository.INewsRepository) error {
 		// create news
@@ -137,66 +195,43 @@ func (ns *newsService) Create(ctx context.Context, req dto.CreateNewsRequest) (d
 		}
 
 		// Create new news images
-		for _, img := range newsImages {
-			if err := txRepo.CreateImage(ctx, nil, img); err != nil {
+		for i := range newsImages {
+			if err := txRepo.CreateImage(ctx, nil, &newsImages[i]); err != nil {
 				return dto.ErrCreateNewsImage
 			}
 		}
 
-		return nil
+		return ns.saveNewsTags(ctx, txRepo, news, tags)
 	})
 	if err != nil {
 		return dto.NewsResponse{}, err
 	}
 
-	return dto.NewsResponse{
-		ID:          news.ID.String(),
-		Name:        news.Name,
-		Description: news.Description,
-		PublishedAt: news.PublishedAt.String(),
-		Location:    news.Location,
-		URL:         news.URL,
-		Status:      news.Status,
-		Views:       news.Views,
-		Featured:    news.Featured,
-		Category: dto.NewsCategoryResponse{
-			ID:   categoryID.String(),
-			Name: category.Name,
-		},
-		Images: newsImageResponses,
-	}, nil
+	news.NewsCategory = *category
+	news.Images = newsImages
+	res := ns.mapNewsToResponse(news)
+
+	ns.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_CREATE, constants.ENUM_AUDIT_ENTITY_NEWS, news.ID.String(), nil, res)
+
+	return res, nil
 }
 
-func (ns *newsService) GetAll(ctx context.Context) ([]dto.NewsResponse, error) {
-	newss, err := ns.newsRepo.GetAll(ctx, nil)
+func (ns *newsService) GetAll(ctx context.Context, req dto.NewsPaginationRequest) ([]dto.NewsResponse, error) {
+	if !req.PublishedOnly && !validPublicationFilter(req.PublicationStatus) {
+		return nil, dto.ErrInvalidPublicationStatus
+	}
+	if err := newsTagFilter(&req); err != nil {
+		return nil, err
+	}
+
+	newss, err := ns.newsRepo.GetAll(ctx, nil, req)
 	if err != nil {
 		return nil, dto.ErrGetAllNewsNoPagination
 	}
 
 	var datas []dto.NewsResponse
 	for _, news := range newss {
-		data := dto.NewsResponse{
-			ID:          news.ID.String(),
-			Name:        news.Name,
-			Description: news.Description,
-			PublishedAt: news.PublishedAt.String(),
-			Location:    news.Location,
-			URL:         news.URL,
-			Status:      news.Status,
-			Views:       news.Views,
-			Featured:    news.Featured,
-			Category: dto.NewsCategoryResponse{
-				ID:   news.NewsCategoryID.String(),
-				Name: news.NewsCategory.Name,
-			},
-		}
-
-		for _, a := range news.Images {
-			data.Images = append(data.Images, dto.NewsImageResponse{
-				ID:   a.ID.String(),
-				Name: a.Name,
-			})
-		}
+		data := ns.mapNewsToResponse(news)
 
 		datas = append(datas, data)
 	}
@@ -204,7 +239,14 @@ func (ns *newsService) GetAll(ctx context.Context) ([]dto.NewsResponse, error) {
 	return datas, nil
 }
 
-func (ns *newsService) GetAllWithPagination(ctx context.Context, req response.PaginationRequest) (dto.NewsPaginationResponse, error) {
+func (ns *newsService) GetAllWithPagination(ctx context.Context, req dto.NewsPaginationRequest) (dto.NewsPaginationResponse, error) {
+	if !req.PublishedOnly && !validPublicationFilter(req.PublicationStatus) {
+		return dto.NewsPaginationResponse{}, dto.ErrInvalidPublicationStatus
+	}
+	if err := newsTagFilter(&req); err != nil {
+		return dto.NewsPaginationResponse{}, err
+	}
+
 	dataWithPaginate, err := ns.newsRepo.GetAllWithPagination(ctx, nil, req)
 	if err != nil {
 		return dto.NewsPaginationResponse{}, dto.ErrGetAllNewsWithPagination
@@ -212,28 +254,7 @@ func (ns *newsService) GetAllWithPagination(ctx context.Context, req response.Pa
 
 	var datas []dto.NewsResponse
 	for _, news := range dataWithPaginate.Newss {
-		data := dto.NewsResponse{
-			ID:          news.ID.String(),
-			Name:        news.Name,
-			Description: news.Description,
-			PublishedAt: news.PublishedAt.String(),
-			Location:    news.Location,
-			URL:         news.URL,
-			Status:      news.Status,
-			Views:       news.Views,
-			Featured:    news.Featured,
-			Category: dto.NewsCategoryResponse{
-				ID:   news.NewsCategoryID.String(),
-				Name: news.NewsCategory.Name,
-			},
-		}
-
-		for _, a := range news.Images {
-			data.Images = append(data.Images, dto.NewsImageResponse{
-				ID:   a.ID.String(),
-				Name: a.Name,
-			})
-		}
+		data := ns.mapNewsToResponse(&news)
 
 		datas = append(datas, data)
 	}
@@ -266,28 +287,7 @@ func (ns *newsService) GetFeatured(ctx context.Context, limit string) ([]dto.New
 
 	var datas []dto.NewsResponse
 	for _, news := range featuredNews {
-		data := dto.NewsResponse{
-			ID:          news.ID.String(),
-			Name:        news.Name,
-			Description: news.Description,
-			PublishedAt: news.PublishedAt.String(),
-			Location:    news.Location,
-			URL:         news.URL,
-			Status:      news.Status,
-			Views:       news.Views,
-			Featured:    news.Featured,
-			Category: dto.NewsCategoryResponse{
-				ID:   news.NewsCategoryID.String(),
-				Name: news.NewsCategory.Name,
-			},
-		}
-
-		for _, a := range news.Images {
-			data.Images = append(data.Images, dto.NewsImageResponse{
-				ID:   a.ID.String(),
-				Name: a.Name,
-			})
-		}
+		data := ns.mapNewsToResponse(news)
 
 		datas = append(datas, data)
 	}
@@ -295,41 +295,46 @@ func (ns *newsService) GetFeatured(ctx context.Context, limit string) ([]dto.New
 	return datas, nil
 }
 
+// GetDetail untuk publik, berita yang belum published dianggap tidak ada
 func (ns *newsService) GetDetail(ctx context.Context, id string) (dto.NewsResponse, error) {
+	id, err := ns.slugService.Resolve(ctx, constants.ENUM_AUDIT_ENTITY_NEWS, id)
+	if err != nil {
+		return dto.NewsResponse{}, err
+	}
+	if id == "" {
+		return dto.NewsResponse{}, dto.ErrNewsNotFound
+	}
+
 	news, _, err := ns.newsRepo.GetByID(ctx, nil, id)
 	if err != nil {
 		return dto.NewsResponse{}, dto.ErrNewsNotFound
 	}
+	if !isNewsPublished(news, time.Now()) {
+		return dto.NewsResponse{}, dto.ErrNewsNotFound
+	}
 
 	err = ns.newsRepo.IncrementViews(ctx, nil, id)
 	if err != nil {
 		return dto.NewsResponse{}, dto.ErrIncrementViews
 	}
 
-	res := dto.NewsResponse{
-		ID:          news.ID.String(),
-		Name:        news.Name,
-		Description: news.Description,
-		PublishedAt: news.PublishedAt.String(),
-		Location:    news.Location,
-		URL:         news.URL,
-		Status:      news.Status,
-		Views:       news.Views + 1,
-		Featured:    news.Featured,
-		Category: dto.NewsCategoryResponse{
-			ID:   news.NewsCategoryID.String(),
-			Name: news.NewsCategory.Name,
-		},
-	}
+	res := ns.mapNewsToResponse(news)
+	res.Views = news.Views + 1
 
-	for _, a := range news.Images {
-		res.Images = append(res.Images, dto.NewsImageResponse{
-			ID:   a.ID.String(),
-			Name: a.Name,
-		})
+	return res, nil
+}
+
+// GetAdminDetail dipakai dashboard, semua status terlihat dan views tidak bertambah
+func (ns *newsService) GetAdminDetail(ctx context.Context, id string) (dto.NewsResponse, error) {
+	news, found, err := ns.newsRepo.GetByID(ctx, nil, id)
+	if err != nil {
+		return dto.NewsResponse{}, dto.ErrGetNewsByID
+	}
+	if !found {
+		return dto.NewsResponse{}, dto.ErrNewsNotFound
 	}
 
-	return res, nil
+	return ns.mapNewsToResponse(news), nil
 }
 
 func (ns *newsService) Update(ctx context.Context, req dto.UpdateNewsRequest) (dto.NewsResponse, error) {
@@ -342,6 +347,9 @@ func (ns *newsService) Update(ctx context.Context, req dto.UpdateNewsRequest) (d
 		return dto.NewsResponse{}, dto.ErrNewsNotFound
 	}
 
+	before := ns.mapNewsToResponse(news)
+	revision := newsRevisionOf(news, news.Images)
+
 	// handle name request
 	if req.Name != "" && req.Name != news.Name {
 		if len(req.Name) < 3 {
@@ -360,9 +368,29 @@ func (ns *newsService) Update(ctx context.Context, req dto.UpdateNewsRequest) (d
 		news.Description = req.Description
 	}
 
+	// handle summary request
+	if req.Summary != "" && req.Summary != news.Summary {
+		if utf8.RuneCountInString(req.Summary) > constants.ENUM_NEWS_SUMMARY_MAX_LENGTH {
+			return dto.NewsResponse{}, dto.ErrSummaryTooLong
+		}
+
+		news.Summary = req.Summary
+	}
+
+	// handle body request, description ikut dirender selama body masih kosong (konten lama)
+	if req.Body != "" {
+		news.Body = req.Body
+	}
+	bodyChanged := news.Body != before.Body || news.Description != before.Description || news.BodyHTML == ""
+	if bodyChanged {
+		if err := ns.renderNewsBody(ctx, news); err != nil {
+			return dto.NewsResponse{}, err
+		}
+	}
+
 	// handle location request
 	if req.Location != "" && req.Location != news.Location {
-		if len(req.Description) < 5 {
+		if len(req.Location) < 5 {
 			return dto.NewsResponse{}, dto.ErrLocationTooShort
 		}
 
@@ -383,6 +411,15 @@ func (ns *newsService) Update(ctx context.Context, req dto.UpdateNewsRequest) (d
 		news.Status = req.Status
 	}
 
+	// handle tags request, nil berarti tag tidak diubah
+	var tags []entity.NewsTag
+	if req.Tags != nil {
+		tags, err = normalizeNewsTags(req.Tags)
+		if err != nil {
+			return dto.NewsResponse{}, err
+		}
+	}
+
 	// handle news category
 	if req.CategoryID != "" && req.CategoryID != news.NewsCategoryID.String() {
 		_, found, _ = ns.newsRepo.GetCategoryByCategoryID(ctx, nil, req.CategoryID)
@@ -398,46 +435,104 @@ func (ns *newsService) Update(ctx context.Context, req dto.UpdateNewsRequest) (d
 		news.NewsCategoryID = &categoryID
 	}
 
-	// handle image url
+	// handle publication request, publish_at tanpa status berarti menjadwalkan ulang
+	publication := req.PublicationStatus
+	if publication == "" && req.PublishAt != nil {
+		publication = constants.ENUM_PUBLICATION_SCHEDULED
+	}
+	if publication != "" {
+		if err := applyNewsPublication(news, publication, req.PublishAt, time.Now()); err != nil {
+			return dto.NewsResponse{}, err
+		}
+	}
+
+	// slug ikut judul, slug lama disimpan sebagai redirect setelah update berhasil
+	oldSlug := news.Slug
+	if news.Name != before.Name || news.Slug == "" {
+		news.Slug, err = ns.slugService.Generate(ctx, constants.ENUM_AUDIT_ENTITY_NEWS, news.Name, news.ID.String())
+		if err != nil {
+			return dto.NewsResponse{}, err
+		}
+	}
+
+	// handle image url, gambar yang namanya sama dipakai ulang supaya caption, alt dan cover tetap
 	var (
-		newsImages         []*entity.NewsImage
-		newsImageResponses []dto.NewsImageResponse
+		newsImages    []entity.NewsImage
+		removedImages []entity.NewsImage
 	)
 	if len(req.Images) > 0 {
-		for _, imgName := range req.Images {
-			imgID := uuid.New()
-			// handle entity
-			newsImages = append(newsImages, &entity.NewsImage{
-				ID:     imgID,
-				Name:   imgName,
-				NewsID: &news.ID,
-			})
+		var names, existing []string
+		for _, value := range req.Images {
+			imgName, err := ns.fileService.ResolveImage(ctx, value)
+			if err != nil {
+				return dto.NewsResponse{}, err
+			}
+			names = append(names, imgName)
+		}
+		for _, img := range news.Images {
+			existing = append(existing, img.Name)
+		}
+
+		reuse, removed := planGallerySync(existing, names)
+		for i, name := range names {
+			if reuse[i] >= 0 {
+				newsImages = append(newsImages, news.Images[reuse[i]])
+				continue
+			}
 
-			// handle response
-			newsImageResponses = append(newsImageResponses, dto.NewsImageResponse{
-				ID:   imgID.String(),
-				Name: imgName,
+			newsImages = append(newsImages, entity.NewsImage{
+				ID:     uuid.New(),
+				Name:   name,
+				NewsID: &news.ID,
 			})
 		}
+		for _, index := range removed {
+			removedImages = append(removedImages, news.Images[index])
+		}
+		arrangeGallery(newsGalleries(newsImages))
+	}
+
+	// simpan isi lama sebagai revisi, update yang hanya mengubah status publikasi tidak perlu revisi
+	updated := newsRevisionOf(news, news.Images)
+	if len(req.Images) > 0 {
+		updated = newsRevisionOf(news, newsImages)
 	}
 
 	err = ns.newsRepo.RunInTransaction(ctx, func(txRepo repository.INewsRepository) error {
+		if len(diffNewsRevisions(revision, updated)) > 0 {
+			if err := ns.saveNewsRevision(ctx, txRepo, revision); err != nil {
+				return err
+			}
+		}
+
 		// update news
 		if err := txRepo.Update(ctx, nil, news); err != nil {
 			return dto.ErrUpdateNews
 		}
+		if err := txRepo.UpdatePublication(ctx, nil, news); err != nil {
+			return dto.ErrUpdateNews
+		}
+		if bodyChanged {
+			if err := txRepo.UpdateBody(ctx, nil, news); err != nil {
+				return dto.ErrUpdateNews
+			}
+		}
+		if req.Tags != nil {
+			if err := ns.saveNewsTags(ctx, txRepo, news, tags); err != nil {
+				return err
+			}
+		}
 
 		// handle new image
-		if len(req.Name) > 0 {
-			if err := txRepo.DeleteImagesByID(ctx, nil, news.ID.String()); err != nil {
-				return dto.ErrDeleteNewsImageByNewsID
+		if len(req.Images) > 0 {
+			for _, img := range removedImages {
+				if err := txRepo.DeleteImageByID(ctx, nil, news.ID.String(), img.ID.String()); err != nil {
+					return dto.ErrDeleteImage
+				}
 			}
 
-			// Create new news images
-			for _, img := range newsImages {
-				if err := txRepo.CreateImage(ctx, nil, img); err != nil {
-					return dto.ErrCreateNewsImage
-				}
+			if err := ns.saveNewsImages(ctx, txRepo, newsImages); err != nil {
+				return err
 			}
 		}
 
@@ -447,22 +542,18 @@ func (ns *newsService) Update(ctx context.Context, req dto.UpdateNewsRequest) (d
 		return dto.NewsResponse{}, err
 	}
 
-	return dto.NewsResponse{
-		ID:          news.ID.String(),
-		Name:        news.Name,
-		Description: news.Description,
-		PublishedAt: news.PublishedAt.String(),
-		Location:    news.Location,
-		URL:         news.URL,
-		Status:      news.Status,
-		Views:       news.Views,
-		Featured:    news.Featured,
-		Category: dto.NewsCategoryResponse{
-			ID:   news.NewsCategoryID.String(),
-			Name: news.NewsCategory.Name,
-		},
-		Images: newsImageResponses,
-	}, nil
+	if err := ns.slugService.Rename(ctx, constants.ENUM_AUDIT_ENTITY_NEWS, news.ID.String(), oldSlug, news.Slug); err != nil {
+		log.Printf("slug redirect news %s: %v", news.ID, err)
+	}
+
+	if len(req.Images) > 0 {
+		news.Images = newsImages
+	}
+	res := ns.mapNewsToResponse(news)
+
+	ns.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_NEWS, news.ID.String(), before, res)
+
+	return res, nil
 }
 
 func (ns *newsService) Delete(ctx context.Context, id string) (dto.NewsResponse, error) {
@@ -479,8 +570,17 @@ func (ns *newsService) Delete(ctx context.Context, id string) (dto.NewsResponse,
 			return dto.ErrDeleteNewsImageByNewsID
 		}
 
+		if err := txRepo.ClearTags(ctx, nil, deletedNews); err != nil {
+			return dto.ErrSaveNewsTags
+		}
+
+		// revisi dihapus permanen supaya file gambarnya bisa dibersihkan GC
+		if err := txRepo.DeleteRevisionsByNewsID(ctx, nil, id); err != nil {
+			return dto.ErrDeleteNewsRevisions
+		}
+
 		// Delete News
-		err = ns.newsRepo.DeleteByID(ctx, nil, id)
+		err = txRepo.DeleteByID(ctx, nil, id)
 		if err != nil {
 			return dto.ErrDeleteNewsByID
 		}
@@ -491,28 +591,404 @@ func (ns *newsService) Delete(ctx context.Context, id string) (dto.NewsResponse,
 		return dto.NewsResponse{}, err
 	}
 
+	if err := ns.slugService.DeleteRedirects(ctx, constants.ENUM_AUDIT_ENTITY_NEWS, id); err != nil {
+		log.Printf("slug redirect news %s: %v", id, err)
+	}
+
+	res := ns.mapNewsToResponse(deletedNews)
+
+	ns.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_DELETE, constants.ENUM_AUDIT_ENTITY_NEWS, deletedNews.ID.String(), res, nil)
+
+	return res, nil
+}
+
+// AddImage menambah satu gambar ke galeri tanpa mengganti gambar lain
+func (ns *newsService) AddImage(ctx context.Context, req dto.AddImageRequest) (dto.NewsResponse, error) {
+	news, found, err := ns.newsRepo.GetByID(ctx, nil, req.ParentID)
+	if err != nil {
+		return dto.NewsResponse{}, dto.ErrGetNewsByID
+	}
+	if !found {
+		return dto.NewsResponse{}, dto.ErrNewsNotFound
+	}
+
+	before := ns.mapNewsToResponse(news)
+
+	// handle image request
+	if strings.TrimSpace(req.Image) == "" {
+		return dto.NewsResponse{}, dto.ErrEmptyImage
+	}
+	imgName, err := ns.fileService.ResolveImage(ctx, strings.TrimSpace(req.Image))
+	if err != nil {
+		return dto.NewsResponse{}, err
+	}
+
+	caption, alt, err := validateGalleryText(req.Caption, req.Alt)
+	if err != nil {
+		return dto.NewsResponse{}, err
+	}
+
+	index, err := galleryInsertIndex(req.Position, len(news.Images))
+	if err != nil {
+		return dto.NewsResponse{}, err
+	}
+
+	news.Images = slices.Insert(news.Images, index, entity.NewsImage{
+		ID:      uuid.New(),
+		Name:    imgName,
+		NewsID:  &news.ID,
+		Gallery: entity.Gallery{Caption: caption, Alt: alt},
+	})
+	galleries := newsGalleries(news.Images)
+	if req.IsCover {
+		setGalleryCover(galleries, index)
+	}
+	arrangeGallery(galleries)
+
+	err = ns.newsRepo.RunInTransaction(ctx, func(txRepo repository.INewsRepository) error {
+		return ns.saveNewsImages(ctx, txRepo, news.Images)
+	})
+	if err != nil {
+		return dto.NewsResponse{}, err
+	}
+
+	res := ns.mapNewsToResponse(news)
+
+	ns.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_NEWS, news.ID.String(), before, res)
+
+	return res, nil
+}
+
+func (ns *newsService) UpdateImage(ctx context.Context, req dto.UpdateImageRequest) (dto.NewsResponse, error) {
+	news, found, err := ns.newsRepo.GetByID(ctx, nil, req.ParentID)
+	if err != nil {
+		return dto.NewsResponse{}, dto.ErrGetNewsByID
+	}
+	if !found {
+		return dto.NewsResponse{}, dto.ErrNewsNotFound
+	}
+
+	index := slices.IndexFunc(news.Images, func(img entity.NewsImage) bool { return img.ID.String() == req.ImageID })
+	if index == -1 {
+		return dto.NewsResponse{}, dto.ErrImageNotFound
+	}
+
+	before := ns.mapNewsToResponse(news)
+	image := &news.Images[index]
+
+	// handle caption & alt request, string kosong berarti dihapus
+	caption, alt := image.Caption, image.Alt
+	if req.Caption != nil {
+		caption = *req.Caption
+	}
+	if req.Alt != nil {
+		alt = *req.Alt
+	}
+	image.Caption, image.Alt, err = validateGalleryText(caption, alt)
+	if err != nil {
+		return dto.NewsResponse{}, err
+	}
+
+	// handle cover request
+	galleries := newsGalleries(news.Images)
+	if req.IsCover != nil {
+		if *req.IsCover {
+			setGalleryCover(galleries, index)
+		} else {
+			unsetGalleryCover(galleries, index)
+		}
+	}
+	arrangeGallery(galleries)
+
+	err = ns.newsRepo.RunInTransaction(ctx, func(txRepo repository.INewsRepository) error {
+		return ns.saveNewsImages(ctx, txRepo, news.Images)
+	})
+	if err != nil {
+		return dto.NewsResponse{}, err
+	}
+
+	res := ns.mapNewsToResponse(news)
+
+	ns.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_NEWS, news.ID.String(), before, res)
+
+	return res, nil
+}
+
+// RemoveImage menghapus satu gambar, galeri tidak boleh kosong
+func (ns *newsService) RemoveImage(ctx context.Context, id, imageID string) (dto.NewsResponse, error) {
+	news, found, err := ns.newsRepo.GetByID(ctx, nil, id)
+	if err != nil {
+		return dto.NewsResponse{}, dto.ErrGetNewsByID
+	}
+	if !found {
+		return dto.NewsResponse{}, dto.ErrNewsNotFound
+	}
+
+	index := slices.IndexFunc(news.Images, func(img entity.NewsImage) bool { return img.ID.String() == imageID })
+	if index == -1 {
+		return dto.NewsResponse{}, dto.ErrImageNotFound
+	}
+	if len(news.Images) == 1 {
+		return dto.NewsResponse{}, dto.ErrLastImage
+	}
+
+	before := ns.mapNewsToResponse(news)
+
+	news.Images = slices.Delete(news.Images, index, index+1)
+	arrangeGallery(newsGalleries(news.Images))
+
+	err = ns.newsRepo.RunInTransaction(ctx, func(txRepo repository.INewsRepository) error {
+		if err := txRepo.DeleteImageByID(ctx, nil, news.ID.String(), imageID); err != nil {
+			return dto.ErrDeleteImage
+		}
+
+		return ns.saveNewsImages(ctx, txRepo, news.Images)
+	})
+	if err != nil {
+		return dto.NewsResponse{}, err
+	}
+
+	res := ns.mapNewsToResponse(news)
+
+	ns.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_NEWS, news.ID.String(), before, res)
+
+	return res, nil
+}
+
+func (ns *newsService) ReorderImages(ctx context.Context, req dto.ReorderImagesRequest) (dto.NewsResponse, error) {
+	news, found, err := ns.newsRepo.GetByID(ctx, nil, req.ParentID)
+	if err != nil {
+		return dto.NewsResponse{}, dto.ErrGetNewsByID
+	}
+	if !found {
+		return dto.NewsResponse{}, dto.ErrNewsNotFound
+	}
+
+	var ids []uuid.UUID
+	for _, img := range news.Images {
+		ids = append(ids, img.ID)
+	}
+	order, err := galleryOrder(ids, req.ImageIDs)
+	if err != nil {
+		return dto.NewsResponse{}, err
+	}
+
+	before := ns.mapNewsToResponse(news)
+
+	var images []entity.NewsImage
+	for _, index := range order {
+		images = append(images, news.Images[index])
+	}
+	news.Images = images
+	arrangeGallery(newsGalleries(news.Images))
+
+	err = ns.newsRepo.RunInTransaction(ctx, func(txRepo repository.INewsRepository) error {
+		return ns.saveNewsImages(ctx, txRepo, news.Images)
+	})
+	if err != nil {
+		return dto.NewsResponse{}, err
+	}
+
+	res := ns.mapNewsToResponse(news)
+
+	ns.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_NEWS, news.ID.String(), before, res)
+
+	return res, nil
+}
+
+// PublishScheduled menayangkan berita terjadwal yang publish_at-nya sudah lewat,
+// published_at diisi dengan jadwalnya supaya urutan berita tetap sesuai rencana
+func (ns *newsService) PublishScheduled(ctx context.Context) (int, error) {
+	now := time.Now()
+	newss, err := ns.newsRepo.GetDueScheduled(ctx, nil, now)
+	if err != nil {
+		return 0, dto.ErrPublishScheduledNews
+	}
+
+	published := 0
+	for _, news := range newss {
+		before := ns.mapNewsToResponse(news)
+
+		news.PublicationStatus = constants.ENUM_PUBLICATION_PUBLISHED
+		news.PublishedAt = *news.PublishAt
+		news.PublishAt = nil
+		ok, err := ns.newsRepo.PublishIfDue(ctx, nil, news, now)
+		if err != nil {
+			log.Printf("publish news %s: %v", news.ID, err)
+			continue
+		}
+		if !ok {
+			// sudah dipindah ke draft / arsip atau jadwalnya diubah setelah dibaca
+			continue
+		}
+		published++
+
+		ns.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_PUBLISH, constants.ENUM_AUDIT_ENTITY_NEWS, news.ID.String(), before, ns.mapNewsToResponse(news))
+	}
+
+	return published, nil
+}
+
+// Start menjalankan scheduler publikasi di background sampai ctx selesai, jadwal yang
+// terlewat selama server mati langsung diproses saat start
+func (ns *newsService) Start(ctx context.Context) {
+	publish := func() {
+		published, err := ns.PublishScheduled(ctx)
+		if err != nil {
+			log.Printf("publish scheduled news: %v", err)
+			return
+		}
+		if published > 0 {
+			log.Printf("publish scheduled news: published %d news", published)
+		}
+	}
+
+	go func() {
+		publish()
+
+		ticker := time.NewTicker(ns.publishInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				publish()
+			}
+		}
+	}()
+}
+
+// saveNewsImages membuat gambar baru (CreatedAt masih kosong) dan menulis ulang posisi & cover gambar lama
+func (ns *newsService) saveNewsImages(ctx context.Context, txRepo repository.INewsRepository, images []entity.NewsImage) error {
+	for i := range images {
+		if images[i].CreatedAt.IsZero() {
+			if err := txRepo.CreateImage(ctx, nil, &images[i]); err != nil {
+				return dto.ErrCreateNewsImage
+			}
+			continue
+		}
+
+		if err := txRepo.UpdateImage(ctx, nil, &images[i]); err != nil {
+			return dto.ErrUpdateImage
+		}
+	}
+
+	return nil
+}
+
+func (ns *newsService) mapNewsToResponse(news *entity.News) dto.NewsResponse {
 	res := dto.NewsResponse{
-		ID:          deletedNews.ID.String(),
-		Name:        deletedNews.Name,
-		Description: deletedNews.Description,
-		PublishedAt: deletedNews.PublishedAt.String(),
-		Location:    deletedNews.Location,
-		URL:         deletedNews.URL,
-		Status:      deletedNews.Status,
-		Views:       deletedNews.Views,
-		Featured:    deletedNews.Featured,
+		ID:                news.ID.String(),
+		Name:              news.Name,
+		Slug:              news.Slug,
+		Description:       news.Description,
+		PublishedAt:       news.PublishedAt.String(),
+		Location:          news.Location,
+		URL:               news.URL,
+		Status:            news.Status,
+		Views:             news.Views,
+		Featured:          news.Featured,
+		PublicationStatus: news.PublicationStatus,
 		Category: dto.NewsCategoryResponse{
-			ID:   deletedNews.NewsCategoryID.String(),
-			Name: deletedNews.NewsCategory.Name,
+			ID:   news.NewsCategoryID.String(),
+			Name: news.NewsCategory.Name,
 		},
+		CreatedBy: mapAuthor(news.CreatedByID, news.CreatedBy),
+		UpdatedBy: mapAuthor(news.UpdatedByID, news.UpdatedBy),
 	}
+	if news.PublishAt != nil {
+		res.PublishAt = news.PublishAt.Format(time.RFC3339)
+	}
+	mapNewsBody(&res, news)
+	res.Tags = mapNewsTags(news.Tags)
+	res.Images, res.Cover = ns.mapNewsImages(news.Images)
+
+	return res
+}
 
-	for _, a := range deletedNews.Images {
-		res.Images = append(res.Images, dto.NewsImageResponse{
-			ID:   a.ID.String(),
-			Name: a.Name,
+// mapNewsImages mengembalikan galeri sesuai urutan posisi beserta cover-nya
+func (ns *newsService) mapNewsImages(images []entity.NewsImage) ([]dto.NewsImageResponse, *dto.NewsImageResponse) {
+	datas := []dto.NewsImageResponse{}
+	for _, img := range images {
+		datas = append(datas, dto.NewsImageResponse{
+			ID:       img.ID.String(),
+			Name:     img.Name,
+			Position: img.Position,
+			Caption:  img.Caption,
+			Alt:      img.Alt,
+			IsCover:  img.IsCover,
+			Sources:  ns.fileService.ImageSources(img.Name),
 		})
 	}
 
-	return res, nil
+	cover := galleryCoverIndex(newsGalleries(images))
+	if cover == -1 {
+		return datas, nil
+	}
+	datas[cover].IsCover = true
+	res := datas[cover]
+
+	return datas, &res
+}
+
+func newsGalleries(images []entity.NewsImage) []*entity.Gallery {
+	galleries := make([]*entity.Gallery, len(images))
+	for i := range images {
+		galleries[i] = &images[i].Gallery
+	}
+
+	return galleries
+}
+
+// applyNewsPublication memvalidasi perpindahan status publikasi. published_at hanya diisi ulang saat
+// berita baru tayang, jadi mengarsipkan lalu menayangkan lagi tidak mengubah tanggal aslinya
+func applyNewsPublication(news *entity.News, status string, publishAt *time.Time, now time.Time) error {
+	switch status {
+	case constants.ENUM_PUBLICATION_DRAFT, constants.ENUM_PUBLICATION_ARCHIVED:
+		news.PublishAt = nil
+	case constants.ENUM_PUBLICATION_SCHEDULED:
+		if publishAt == nil {
+			publishAt = news.PublishAt
+		}
+		if publishAt == nil {
+			return dto.ErrEmptyPublishAt
+		}
+		if !publishAt.After(now) {
+			return dto.ErrInvalidPublishAt
+		}
+		news.PublishAt = publishAt
+	case constants.ENUM_PUBLICATION_PUBLISHED:
+		if news.PublicationStatus != constants.ENUM_PUBLICATION_PUBLISHED && news.PublicationStatus != constants.ENUM_PUBLICATION_ARCHIVED {
+			news.PublishedAt = now
+		}
+		news.PublishAt = nil
+	default:
+		return dto.ErrInvalidPublicationStatus
+	}
+
+	news.PublicationStatus = status
+	return nil
+}
+
+// isNewsPublished sama dengan filter list publik di repository
+func isNewsPublished(news *entity.News, now time.Time) bool {
+	switch news.PublicationStatus {
+	case constants.ENUM_PUBLICATION_PUBLISHED:
+		return true
+	case constants.ENUM_PUBLICATION_SCHEDULED:
+		return news.PublishAt != nil && !news.PublishAt.After(now)
+	}
+
+	return false
+}
+
+func validPublicationFilter(status string) bool {
+	switch status {
+	case "", constants.ENUM_PUBLICATION_DRAFT, constants.ENUM_PUBLICATION_SCHEDULED, constants.ENUM_PUBLICATION_PUBLISHED, constants.ENUM_PUBLICATION_ARCHIVED:
+		return true
+	}
+
+	return false
 }
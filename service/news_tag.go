@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Amierza/nawasena-backend/constants"
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/entity"
+	"github.com/Amierza/nawasena-backend/helper"
+	"github.com/Amierza/nawasena-backend/repository"
+	"github.com/google/uuid"
+)
+
+// GetTags mengembalikan semua tag beserta jumlah berita published, diurutkan dari yang paling banyak
+func (ns *newsService) GetTags(ctx context.Context) ([]dto.NewsTagResponse, error) {
+	tags, err := ns.newsRepo.GetTagCounts(ctx, nil)
+	if err != nil {
+		return nil, dto.ErrGetNewsTags
+	}
+
+	return tags, nil
+}
+
+// GetRelated menerima id atau slug, hasilnya berita published lain yang diurutkan berdasarkan tag
+// yang sama, kategori yang sama lalu tanggal terbit
+func (ns *newsService) GetRelated(ctx context.Context, id, limit string) ([]dto.NewsResponse, error) {
+	lim := constants.ENUM_NEWS_RELATED_LIMIT
+	if limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil || l < 1 {
+			return nil, dto.ErrParseLimit
+		}
+		lim = min(l, constants.ENUM_NEWS_RELATED_MAX_LIMIT)
+	}
+
+	id, err := ns.slugService.Resolve(ctx, constants.ENUM_AUDIT_ENTITY_NEWS, id)
+	if err != nil {
+		return nil, err
+	}
+	if id == "" {
+		return nil, dto.ErrNewsNotFound
+	}
+
+	news, found, err := ns.newsRepo.GetByID(ctx, nil, id)
+	if err != nil || !found || !isNewsPublished(news, time.Now()) {
+		return nil, dto.ErrNewsNotFound
+	}
+
+	related, err := ns.newsRepo.GetRelated(ctx, nil, news, lim)
+	if err != nil {
+		return nil, dto.ErrGetRelatedNews
+	}
+
+	datas := []dto.NewsResponse{}
+	for _, item := range related {
+		datas = append(datas, ns.mapNewsToResponse(item))
+	}
+
+	return datas, nil
+}
+
+// normalizeNewsTags merapikan spasi dan membuang tag yang slug-nya sama ("Kapal Selam" = "kapal selam")
+func normalizeNewsTags(names []string) ([]entity.NewsTag, error) {
+	tags := []entity.NewsTag{}
+	for _, name := range names {
+		name = strings.Join(strings.Fields(name), " ")
+		slug := helper.Slugify(name)
+		if slug == "" || utf8.RuneCountInString(name) > constants.ENUM_NEWS_TAG_MAX_LENGTH {
+			return nil, dto.ErrInvalidTag
+		}
+
+		if slices.ContainsFunc(tags, func(tag entity.NewsTag) bool { return tag.Slug == slug }) {
+			continue
+		}
+		tags = append(tags, entity.NewsTag{ID: uuid.New(), Name: name, Slug: slug})
+	}
+
+	if len(tags) > constants.ENUM_NEWS_MAX_TAGS {
+		return nil, dto.ErrTooManyTags
+	}
+
+	return tags, nil
+}
+
+// saveNewsTags membuat tag yang belum ada lalu mengganti relasi tag berita. Tag yang sudah ada
+// tetap memakai nama pertama kali dibuat
+func (ns *newsService) saveNewsTags(ctx context.Context, txRepo repository.INewsRepository, news *entity.News, tags []entity.NewsTag) error {
+	if len(tags) == 0 {
+		if err := txRepo.ClearTags(ctx, nil, news); err != nil {
+			return dto.ErrSaveNewsTags
+		}
+		news.Tags = []entity.NewsTag{}
+		return nil
+	}
+
+	slugs := []string{}
+	for _, tag := range tags {
+		slugs = append(slugs, tag.Slug)
+	}
+
+	if err := txRepo.CreateTags(ctx, nil, tags); err != nil {
+		return dto.ErrSaveNewsTags
+	}
+	saved, err := txRepo.GetTagsBySlugs(ctx, nil, slugs)
+	if err != nil {
+		return dto.ErrSaveNewsTags
+	}
+	slices.SortFunc(saved, func(a, b entity.NewsTag) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	if err := txRepo.ReplaceTags(ctx, nil, news, saved); err != nil {
+		return dto.ErrSaveNewsTags
+	}
+	news.Tags = saved
+
+	return nil
+}
+
+func mapNewsTags(tags []entity.NewsTag) []dto.NewsTagResponse {
+	datas := []dto.NewsTagResponse{}
+	for _, tag := range tags {
+		datas = append(datas, dto.NewsTagResponse{
+			Name: tag.Name,
+			Slug: tag.Slug,
+		})
+	}
+
+	return datas
+}
+
+// newsTagFilter mengubah ?tag= (nama atau slug) menjadi slug
+func newsTagFilter(req *dto.NewsPaginationRequest) error {
+	if req.Tag == "" {
+		return nil
+	}
+
+	req.Tag = helper.Slugify(req.Tag)
+	if req.Tag == "" {
+		return dto.ErrInvalidTag
+	}
+
+	return nil
+}
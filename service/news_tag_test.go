@@ -0,0 +1,119 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Amierza/nawasena-backend/constants"
+	"github.com/Amierza/nawasena-backend/dto"
+)
+
+func TestNormalizeNewsTags(t *testing.T) {
+	tooMany := []string{}
+	for i := 0; i <= constants.ENUM_NEWS_MAX_TAGS; i++ {
+		tooMany = append(tooMany, fmt.Sprintf("tag %d", i))
+	}
+
+	tests := []struct {
+		name      string
+		in        []string
+		wantNames []string
+		wantSlugs []string
+		wantErr   error
+	}{
+		{
+			name:      "kosong",
+			in:        nil,
+			wantNames: []string{},
+			wantSlugs: []string{},
+		},
+		{
+			name:      "spasi dirapikan",
+			in:        []string{"  Kapal   Selam  ", "Robotika"},
+			wantNames: []string{"Kapal Selam", "Robotika"},
+			wantSlugs: []string{"kapal-selam", "robotika"},
+		},
+		{
+			// slug sama dianggap tag yang sama, nama pertama yang dipakai
+			name:      "slug kembar dibuang",
+			in:        []string{"Kapal Selam", "kapal selam", "KAPAL-SELAM"},
+			wantNames: []string{"Kapal Selam"},
+			wantSlugs: []string{"kapal-selam"},
+		},
+		{
+			name:    "tanpa huruf atau angka",
+			in:      []string{"Robotika", "!!!"},
+			wantErr: dto.ErrInvalidTag,
+		},
+		{
+			name:    "terlalu panjang",
+			in:      []string{strings.Repeat("a", constants.ENUM_NEWS_TAG_MAX_LENGTH+1)},
+			wantErr: dto.ErrInvalidTag,
+		},
+		{
+			name:    "terlalu banyak",
+			in:      tooMany,
+			wantErr: dto.ErrTooManyTags,
+		},
+		{
+			// duplikat tidak dihitung ke batas jumlah tag
+			name:      "duplikat tidak menambah jumlah",
+			in:        append(append([]string{}, tooMany[:constants.ENUM_NEWS_MAX_TAGS]...), "Tag 0"),
+			wantNames: tooMany[:constants.ENUM_NEWS_MAX_TAGS],
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags, err := normalizeNewsTags(tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			names, slugs := []string{}, []string{}
+			for _, tag := range tags {
+				names = append(names, tag.Name)
+				slugs = append(slugs, tag.Slug)
+			}
+			if !reflect.DeepEqual(names, tt.wantNames) {
+				t.Errorf("names = %v, want %v", names, tt.wantNames)
+			}
+			if tt.wantSlugs != nil && !reflect.DeepEqual(slugs, tt.wantSlugs) {
+				t.Errorf("slugs = %v, want %v", slugs, tt.wantSlugs)
+			}
+		})
+	}
+}
+
+func TestNewsTagFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     string
+		want    string
+		wantErr error
+	}{
+		{name: "tanpa filter", tag: "", want: ""},
+		{name: "nama jadi slug", tag: "Kapal Selam", want: "kapal-selam"},
+		{name: "slug tetap", tag: "kapal-selam", want: "kapal-selam"},
+		{name: "tidak valid", tag: "???", wantErr: dto.ErrInvalidTag},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := dto.NewsPaginationRequest{Tag: tt.tag}
+			err := newsTagFilter(&req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && req.Tag != tt.want {
+				t.Errorf("tag = %q, want %q", req.Tag, tt.want)
+			}
+		})
+	}
+}
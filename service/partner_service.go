@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/dto"
 	"github.com/Amierza/nawasena-backend/entity"
 	"github.com/Amierza/nawasena-backend/jwt"
@@ -14,7 +15,7 @@ import (
 type (
 	IPartnerService interface {
 		Create(ctx context.Context, req dto.CreatePartnerRequest) (dto.PartnerResponse, error)
-		GetAll(ctx context.Context) ([]dto.PartnerResponse, error)
+		GetAll(ctx context.Context, createdBy string) ([]dto.PartnerResponse, error)
 		GetAllWithPagination(ctx context.Context, req response.PaginationRequest) (dto.PartnerPaginationResponse, error)
 		GetDetail(ctx context.Context, id string) (dto.PartnerResponse, error)
 		Update(ctx context.Context, req dto.UpdatePartnerRequest) (dto.PartnerResponse, error)
@@ -23,13 +24,17 @@ type (
 
 	partnerService struct {
 		partnerRepo repository.IPartnerRepository
+		fileService IFileService
+		auditLog    IAuditLogService
 		jwt         jwt.IJWT
 	}
 )
 
-func NewPartnerService(partnerRepo repository.IPartnerRepository, jwt jwt.IJWT) *partnerService {
+func NewPartnerService(partnerRepo repository.IPartnerRepository, fileService IFileService, auditLog IAuditLogService, jwt jwt.IJWT) *partnerService {
 	return &partnerService{
 		partnerRepo: partnerRepo,
+		fileService: fileService,
+		auditLog:    auditLog,
 		jwt:         jwt,
 	}
 }
@@ -47,6 +52,10 @@ func (as *partnerService) Create(ctx context.Context, req dto.CreatePartnerReque
 	if req.Image == "" {
 		return dto.PartnerResponse{}, dto.ErrEmptyImage
 	}
+	image, err := as.fileService.ResolveImage(ctx, req.Image)
+	if err != nil {
+		return dto.PartnerResponse{}, err
+	}
 
 	// handle double data
 	_, found, _ := as.partnerRepo.GetByName(ctx, nil, req.Name)
@@ -58,7 +67,7 @@ func (as *partnerService) Create(ctx context.Context, req dto.CreatePartnerReque
 	partner := &entity.Partner{
 		ID:    partnerID,
 		Name:  req.Name,
-		Image: req.Image,
+		Image: image,
 	}
 
 	// create partner
@@ -66,15 +75,21 @@ func (as *partnerService) Create(ctx context.Context, req dto.CreatePartnerReque
 		return dto.PartnerResponse{}, dto.ErrCreatePartner
 	}
 
-	return dto.PartnerResponse{
-		ID:    partner.ID.String(),
-		Name:  partner.Name,
-		Image: partner.Image,
-	}, nil
+	res := dto.PartnerResponse{
+		ID:        partner.ID.String(),
+		Name:      partner.Name,
+		Image:     partner.Image,
+		CreatedBy: mapAuthor(partner.CreatedByID, partner.CreatedBy),
+		UpdatedBy: mapAuthor(partner.UpdatedByID, partner.UpdatedBy),
+	}
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_CREATE, constants.ENUM_AUDIT_ENTITY_PARTNER, partner.ID.String(), nil, res)
+
+	return res, nil
 }
 
-func (as *partnerService) GetAll(ctx context.Context) ([]dto.PartnerResponse, error) {
-	partners, err := as.partnerRepo.GetAll(ctx, nil)
+func (as *partnerService) GetAll(ctx context.Context, createdBy string) ([]dto.PartnerResponse, error) {
+	partners, err := as.partnerRepo.GetAll(ctx, nil, createdBy)
 	if err != nil {
 		return nil, dto.ErrGetAllPartnerNoPagination
 	}
@@ -82,9 +97,11 @@ func (as *partnerService) GetAll(ctx context.Context) ([]dto.PartnerResponse, er
 	var datas []dto.PartnerResponse
 	for _, partner := range partners {
 		data := dto.PartnerResponse{
-			ID:    partner.ID.String(),
-			Name:  partner.Name,
-			Image: partner.Image,
+			ID:        partner.ID.String(),
+			Name:      partner.Name,
+			Image:     partner.Image,
+			CreatedBy: mapAuthor(partner.CreatedByID, partner.CreatedBy),
+			UpdatedBy: mapAuthor(partner.UpdatedByID, partner.UpdatedBy),
 		}
 
 		datas = append(datas, data)
@@ -102,9 +119,11 @@ func (as *partnerService) GetAllWithPagination(ctx context.Context, req response
 	var datas []dto.PartnerResponse
 	for _, partner := range dataWithPaginate.Partners {
 		data := dto.PartnerResponse{
-			ID:    partner.ID.String(),
-			Name:  partner.Name,
-			Image: partner.Image,
+			ID:        partner.ID.String(),
+			Name:      partner.Name,
+			Image:     partner.Image,
+			CreatedBy: mapAuthor(partner.CreatedByID, partner.CreatedBy),
+			UpdatedBy: mapAuthor(partner.UpdatedByID, partner.UpdatedBy),
 		}
 
 		datas = append(datas, data)
@@ -128,9 +147,11 @@ func (as *partnerService) GetDetail(ctx context.Context, id string) (dto.Partner
 	}
 
 	res := dto.PartnerResponse{
-		ID:    partner.ID.String(),
-		Name:  partner.Name,
-		Image: partner.Image,
+		ID:        partner.ID.String(),
+		Name:      partner.Name,
+		Image:     partner.Image,
+		CreatedBy: mapAuthor(partner.CreatedByID, partner.CreatedBy),
+		UpdatedBy: mapAuthor(partner.UpdatedByID, partner.UpdatedBy),
 	}
 
 	return res, nil
@@ -146,6 +167,14 @@ func (as *partnerService) Update(ctx context.Context, req dto.UpdatePartnerReque
 		return dto.PartnerResponse{}, dto.ErrPartnerNotFound
 	}
 
+	before := dto.PartnerResponse{
+		ID:        partner.ID.String(),
+		Name:      partner.Name,
+		Image:     partner.Image,
+		CreatedBy: mapAuthor(partner.CreatedByID, partner.CreatedBy),
+		UpdatedBy: mapAuthor(partner.UpdatedByID, partner.UpdatedBy),
+	}
+
 	// handle name request
 	if req.Name != "" && req.Name != partner.Name {
 		if len(req.Name) < 3 {
@@ -155,9 +184,14 @@ func (as *partnerService) Update(ctx context.Context, req dto.UpdatePartnerReque
 		partner.Name = req.Name
 	}
 
-	// handle name request
+	// handle image request
 	if req.Image != "" && req.Image != partner.Image {
-		partner.Image = req.Image
+		image, err := as.fileService.ResolveImage(ctx, req.Image)
+		if err != nil {
+			return dto.PartnerResponse{}, err
+		}
+
+		partner.Image = image
 	}
 
 	err = as.partnerRepo.RunInTransaction(ctx, func(txRepo repository.IPartnerRepository) error {
@@ -172,11 +206,17 @@ func (as *partnerService) Update(ctx context.Context, req dto.UpdatePartnerReque
 		return dto.PartnerResponse{}, err
 	}
 
-	return dto.PartnerResponse{
-		ID:    partner.ID.String(),
-		Name:  partner.Name,
-		Image: partner.Image,
-	}, nil
+	res := dto.PartnerResponse{
+		ID:        partner.ID.String(),
+		Name:      partner.Name,
+		Image:     partner.Image,
+		CreatedBy: mapAuthor(partner.CreatedByID, partner.CreatedBy),
+		UpdatedBy: mapAuthor(partner.UpdatedByID, partner.UpdatedBy),
+	}
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_PARTNER, partner.ID.String(), before, res)
+
+	return res, nil
 }
 
 func (as *partnerService) Delete(ctx context.Context, id string) (dto.PartnerResponse, error) {
@@ -202,10 +242,14 @@ func (as *partnerService) Delete(ctx context.Context, id string) (dto.PartnerRes
 	}
 
 	res := dto.PartnerResponse{
-		ID:    deletedPartner.ID.String(),
-		Name:  deletedPartner.Name,
-		Image: deletedPartner.Image,
+		ID:        deletedPartner.ID.String(),
+		Name:      deletedPartner.Name,
+		Image:     deletedPartner.Image,
+		CreatedBy: mapAuthor(deletedPartner.CreatedByID, deletedPartner.CreatedBy),
+		UpdatedBy: mapAuthor(deletedPartner.UpdatedByID, deletedPartner.UpdatedBy),
 	}
 
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_DELETE, constants.ENUM_AUDIT_ENTITY_PARTNER, deletedPartner.ID.String(), res, nil)
+
 	return res, nil
 }
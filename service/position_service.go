@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/dto"
 	"github.com/Amierza/nawasena-backend/entity"
 	"github.com/Amierza/nawasena-backend/jwt"
@@ -14,7 +15,7 @@ import (
 type (
 	IPositionService interface {
 		Create(ctx context.Context, req dto.CreatePositionRequest) (dto.PositionResponse, error)
-		GetAll(ctx context.Context) ([]dto.PositionResponse, error)
+		GetAll(ctx context.Context, createdBy string) ([]dto.PositionResponse, error)
 		GetAllWithPagination(ctx context.Context, req response.PaginationRequest) (dto.PositionPaginationResponse, error)
 		GetDetail(ctx context.Context, id string) (dto.PositionResponse, error)
 		Update(ctx context.Context, req dto.UpdatePositionRequest) (dto.PositionResponse, error)
@@ -23,13 +24,15 @@ type (
 
 	positionService struct {
 		positionRepo repository.IPositionRepository
+		auditLog     IAuditLogService
 		jwt          jwt.IJWT
 	}
 )
 
-func NewPositionService(positionRepo repository.IPositionRepository, jwt jwt.IJWT) *positionService {
+func NewPositionService(positionRepo repository.IPositionRepository, auditLog IAuditLogService, jwt jwt.IJWT) *positionService {
 	return &positionService{
 		positionRepo: positionRepo,
+		auditLog:     auditLog,
 		jwt:          jwt,
 	}
 }
@@ -59,15 +62,21 @@ func (ps *positionService) Create(ctx context.Context, req dto.CreatePositionReq
 		return dto.PositionResponse{}, dto.ErrCreatePosition
 	}
 
-	return dto.PositionResponse{
-		ID:     position.ID.String(),
-		Name:   position.Name,
-		IsTech: position.IsTech,
-	}, nil
+	res := dto.PositionResponse{
+		ID:        position.ID.String(),
+		Name:      position.Name,
+		IsTech:    position.IsTech,
+		CreatedBy: mapAuthor(position.CreatedByID, position.CreatedBy),
+		UpdatedBy: mapAuthor(position.UpdatedByID, position.UpdatedBy),
+	}
+
+	ps.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_CREATE, constants.ENUM_AUDIT_ENTITY_POSITION, position.ID.String(), nil, res)
+
+	return res, nil
 }
 
-func (ps *positionService) GetAll(ctx context.Context) ([]dto.PositionResponse, error) {
-	positions, err := ps.positionRepo.GetAll(ctx, nil)
+func (ps *positionService) GetAll(ctx context.Context, createdBy string) ([]dto.PositionResponse, error) {
+	positions, err := ps.positionRepo.GetAll(ctx, nil, createdBy)
 	if err != nil {
 		return nil, dto.ErrGetAllPositionNoPagination
 	}
@@ -75,9 +84,11 @@ func (ps *positionService) GetAll(ctx context.Context) ([]dto.PositionResponse,
 	var datas []dto.PositionResponse
 	for _, position := range positions {
 		data := dto.PositionResponse{
-			ID:     position.ID.String(),
-			Name:   position.Name,
-			IsTech: position.IsTech,
+			ID:        position.ID.String(),
+			Name:      position.Name,
+			IsTech:    position.IsTech,
+			CreatedBy: mapAuthor(position.CreatedByID, position.CreatedBy),
+			UpdatedBy: mapAuthor(position.UpdatedByID, position.UpdatedBy),
 		}
 
 		datas = append(datas, data)
@@ -95,9 +106,11 @@ func (ps *positionService) GetAllWithPagination(ctx context.Context, req respons
 	var datas []dto.PositionResponse
 	for _, position := range dataWithPaginate.Positions {
 		data := dto.PositionResponse{
-			ID:     position.ID.String(),
-			Name:   position.Name,
-			IsTech: position.IsTech,
+			ID:        position.ID.String(),
+			Name:      position.Name,
+			IsTech:    position.IsTech,
+			CreatedBy: mapAuthor(position.CreatedByID, position.CreatedBy),
+			UpdatedBy: mapAuthor(position.UpdatedByID, position.UpdatedBy),
 		}
 
 		datas = append(datas, data)
@@ -134,6 +147,14 @@ func (ps *positionService) Update(ctx context.Context, req dto.UpdatePositionReq
 		return dto.PositionResponse{}, dto.ErrPositionNotFound
 	}
 
+	before := dto.PositionResponse{
+		ID:        position.ID.String(),
+		Name:      position.Name,
+		IsTech:    position.IsTech,
+		CreatedBy: mapAuthor(position.CreatedByID, position.CreatedBy),
+		UpdatedBy: mapAuthor(position.UpdatedByID, position.UpdatedBy),
+	}
+
 	// handle name request
 	if req.Name != "" && req.Name != position.Name {
 		if len(req.Name) < 3 {
@@ -157,11 +178,15 @@ func (ps *positionService) Update(ctx context.Context, req dto.UpdatePositionReq
 	}
 
 	res := dto.PositionResponse{
-		ID:     position.ID.String(),
-		Name:   position.Name,
-		IsTech: position.IsTech,
+		ID:        position.ID.String(),
+		Name:      position.Name,
+		IsTech:    position.IsTech,
+		CreatedBy: mapAuthor(position.CreatedByID, position.CreatedBy),
+		UpdatedBy: mapAuthor(position.UpdatedByID, position.UpdatedBy),
 	}
 
+	ps.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_POSITION, position.ID.String(), before, res)
+
 	return res, nil
 }
 
@@ -180,10 +205,14 @@ func (ps *positionService) Delete(ctx context.Context, id string) (dto.PositionR
 	}
 
 	res := dto.PositionResponse{
-		ID:     deletedPosition.ID.String(),
-		Name:   deletedPosition.Name,
-		IsTech: deletedPosition.IsTech,
+		ID:        deletedPosition.ID.String(),
+		Name:      deletedPosition.Name,
+		IsTech:    deletedPosition.IsTech,
+		CreatedBy: mapAuthor(deletedPosition.CreatedByID, deletedPosition.CreatedBy),
+		UpdatedBy: mapAuthor(deletedPosition.UpdatedByID, deletedPosition.UpdatedBy),
 	}
 
+	ps.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_DELETE, constants.ENUM_AUDIT_ENTITY_POSITION, deletedPosition.ID.String(), res, nil)
+
 	return res, nil
 }
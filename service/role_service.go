@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Amierza/nawasena-backend/constants"
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/entity"
+	"github.com/Amierza/nawasena-backend/repository"
+	"github.com/google/uuid"
+)
+
+type (
+	IRoleService interface {
+		Create(ctx context.Context, req dto.CreateRoleRequest) (dto.RoleResponse, error)
+		GetAll(ctx context.Context) ([]dto.RoleResponse, error)
+		GetDetail(ctx context.Context, id string) (dto.RoleResponse, error)
+		Update(ctx context.Context, req dto.UpdateRoleRequest) (dto.RoleResponse, error)
+		Delete(ctx context.Context, id string) (dto.RoleResponse, error)
+		GetAllPermissions(ctx context.Context) ([]dto.PermissionResponse, error)
+		AssignRole(ctx context.Context, req dto.AssignRoleRequest) error
+	}
+
+	roleService struct {
+		roleRepo repository.IRoleRepository
+		auditLog IAuditLogService
+	}
+)
+
+func NewRoleService(roleRepo repository.IRoleRepository, auditLog IAuditLogService) *roleService {
+	return &roleService{
+		roleRepo: roleRepo,
+		auditLog: auditLog,
+	}
+}
+
+func (rs *roleService) Create(ctx context.Context, req dto.CreateRoleRequest) (dto.RoleResponse, error) {
+	// handle name request
+	req.Name = strings.ToLower(strings.TrimSpace(req.Name))
+	if req.Name == "" {
+		return dto.RoleResponse{}, dto.ErrEmptyName
+	}
+	if len(req.Name) < 3 {
+		return dto.RoleResponse{}, dto.ErrNameTooShort
+	}
+	if len(req.Name) > 20 {
+		return dto.RoleResponse{}, dto.ErrRoleNameTooLong
+	}
+	_, found, err := rs.roleRepo.GetByName(ctx, nil, req.Name)
+	if err != nil {
+		return dto.RoleResponse{}, dto.ErrGetRoleByName
+	}
+	if found {
+		return dto.RoleResponse{}, dto.ErrRoleAlreadyExists
+	}
+
+	// handle permissions request
+	permissions, err := rs.resolvePermissions(ctx, req.Permissions)
+	if err != nil {
+		return dto.RoleResponse{}, err
+	}
+
+	role := &entity.AccessRole{
+		ID:          uuid.New(),
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: permissions,
+	}
+
+	if err := rs.roleRepo.Create(ctx, nil, role); err != nil {
+		return dto.RoleResponse{}, dto.ErrCreateRole
+	}
+
+	res := mapRoleToResponse(role)
+	rs.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_CREATE, constants.ENUM_AUDIT_ENTITY_ROLE, role.ID.String(), nil, res)
+
+	return res, nil
+}
+
+func (rs *roleService) GetAll(ctx context.Context) ([]dto.RoleResponse, error) {
+	roles, err := rs.roleRepo.GetAll(ctx, nil)
+	if err != nil {
+		return nil, dto.ErrGetAllRole
+	}
+
+	var datas []dto.RoleResponse
+	for _, role := range roles {
+		datas = append(datas, mapRoleToResponse(role))
+	}
+
+	return datas, nil
+}
+
+func (rs *roleService) GetDetail(ctx context.Context, id string) (dto.RoleResponse, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return dto.RoleResponse{}, dto.ErrParseUUID
+	}
+
+	role, found, err := rs.roleRepo.GetByID(ctx, nil, id)
+	if err != nil {
+		return dto.RoleResponse{}, dto.ErrGetRoleByID
+	}
+	if !found {
+		return dto.RoleResponse{}, dto.ErrRoleNotFound
+	}
+
+	return mapRoleToResponse(role), nil
+}
+
+func (rs *roleService) Update(ctx context.Context, req dto.UpdateRoleRequest) (dto.RoleResponse, error) {
+	if _, err := uuid.Parse(req.ID); err != nil {
+		return dto.RoleResponse{}, dto.ErrParseUUID
+	}
+
+	role, found, err := rs.roleRepo.GetByID(ctx, nil, req.ID)
+	if err != nil {
+		return dto.RoleResponse{}, dto.ErrGetRoleByID
+	}
+	if !found {
+		return dto.RoleResponse{}, dto.ErrRoleNotFound
+	}
+
+	// super admin selalu pegang semua permission, kalau bisa diubah admin bisa terkunci di luar
+	if role.Name == constants.ENUM_ROLE_SUPER_ADMIN {
+		return dto.RoleResponse{}, dto.ErrSystemRole
+	}
+
+	before := mapRoleToResponse(role)
+
+	// handle description request
+	if req.Description != "" {
+		role.Description = req.Description
+	}
+
+	// handle permissions request
+	var permissions []entity.Permission
+	if req.Permissions != nil {
+		permissions, err = rs.resolvePermissions(ctx, req.Permissions)
+		if err != nil {
+			return dto.RoleResponse{}, err
+		}
+	}
+
+	err = rs.roleRepo.RunInTransaction(ctx, func(txRepo repository.IRoleRepository) error {
+		if err := txRepo.Update(ctx, nil, role); err != nil {
+			return dto.ErrUpdateRole
+		}
+
+		if req.Permissions != nil {
+			if err := txRepo.ReplacePermissions(ctx, nil, role, permissions); err != nil {
+				return dto.ErrUpdateRole
+			}
+
+			role.Permissions = permissions
+		}
+
+		return nil
+	})
+	if err != nil {
+		return dto.RoleResponse{}, err
+	}
+
+	res := mapRoleToResponse(role)
+	rs.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_ROLE, role.ID.String(), before, res)
+
+	return res, nil
+}
+
+func (rs *roleService) Delete(ctx context.Context, id string) (dto.RoleResponse, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return dto.RoleResponse{}, dto.ErrParseUUID
+	}
+
+	role, found, err := rs.roleRepo.GetByID(ctx, nil, id)
+	if err != nil {
+		return dto.RoleResponse{}, dto.ErrGetRoleByID
+	}
+	if !found {
+		return dto.RoleResponse{}, dto.ErrRoleNotFound
+	}
+	if role.IsSystem {
+		return dto.RoleResponse{}, dto.ErrSystemRole
+	}
+
+	count, err := rs.roleRepo.CountAdminsByRoleName(ctx, nil, role.Name)
+	if err != nil {
+		return dto.RoleResponse{}, dto.ErrDeleteRole
+	}
+	if count > 0 {
+		return dto.RoleResponse{}, dto.ErrRoleInUse
+	}
+
+	err = rs.roleRepo.RunInTransaction(ctx, func(txRepo repository.IRoleRepository) error {
+		return txRepo.Delete(ctx, nil, role)
+	})
+	if err != nil {
+		return dto.RoleResponse{}, dto.ErrDeleteRole
+	}
+
+	res := mapRoleToResponse(role)
+	rs.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_DELETE, constants.ENUM_AUDIT_ENTITY_ROLE, role.ID.String(), res, nil)
+
+	return res, nil
+}
+
+func (rs *roleService) GetAllPermissions(ctx context.Context) ([]dto.PermissionResponse, error) {
+	permissions, err := rs.roleRepo.GetAllPermissions(ctx, nil)
+	if err != nil {
+		return nil, dto.ErrGetAllPermission
+	}
+
+	var datas []dto.PermissionResponse
+	for _, permission := range permissions {
+		datas = append(datas, dto.PermissionResponse{
+			ID:          permission.ID.String(),
+			Name:        permission.Name,
+			Description: permission.Description,
+		})
+	}
+
+	return datas, nil
+}
+
+func (rs *roleService) AssignRole(ctx context.Context, req dto.AssignRoleRequest) error {
+	adminID, err := uuid.Parse(req.AdminID)
+	if err != nil {
+		return dto.ErrParseUUID
+	}
+
+	// admin tidak boleh mengubah role dirinya sendiri
+	if req.AdminID == req.ActorID {
+		return dto.ErrChangeOwnRole
+	}
+
+	_, found, err := rs.roleRepo.GetByName(ctx, nil, req.Role)
+	if err != nil {
+		return dto.ErrGetRoleByName
+	}
+	if !found {
+		return dto.ErrRoleNotFound
+	}
+
+	err = rs.roleRepo.RunInTransaction(ctx, func(txRepo repository.IRoleRepository) error {
+		// super admin terakhir tidak boleh diturunkan, kalau tidak tidak ada lagi yang bisa mengatur role
+		if req.Role != constants.ENUM_ROLE_SUPER_ADMIN {
+			superAdmins, err := txRepo.LockAdminIDsByRoleName(ctx, nil, constants.ENUM_ROLE_SUPER_ADMIN)
+			if err != nil {
+				return dto.ErrAssignRole
+			}
+			if len(superAdmins) == 1 && superAdmins[0] == adminID.String() {
+				return dto.ErrDemoteLastSuperAdmin
+			}
+		}
+
+		updated, err := txRepo.UpdateAdminRole(ctx, nil, req.AdminID, req.Role)
+		if err != nil {
+			return dto.ErrAssignRole
+		}
+		if !updated {
+			return dto.ErrAdminNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	rs.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_ASSIGN_ROLE, constants.ENUM_AUDIT_ENTITY_ADMIN, req.AdminID, nil, map[string]string{"role": req.Role})
+
+	return nil
+}
+
+func (rs *roleService) resolvePermissions(ctx context.Context, names []string) ([]entity.Permission, error) {
+	unique := make(map[string]bool)
+	var cleaned []string
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || unique[name] {
+			continue
+		}
+
+		unique[name] = true
+		cleaned = append(cleaned, name)
+	}
+
+	permissions, err := rs.roleRepo.GetPermissionsByNames(ctx, nil, cleaned)
+	if err != nil {
+		return nil, dto.ErrGetAllPermission
+	}
+	if len(permissions) != len(cleaned) {
+		return nil, dto.ErrInvalidPermission
+	}
+
+	return permissions, nil
+}
+
+func mapRoleToResponse(role *entity.AccessRole) dto.RoleResponse {
+	permissions := []string{}
+	for _, permission := range role.Permissions {
+		permissions = append(permissions, permission.Name)
+	}
+
+	return dto.RoleResponse{
+		ID:          role.ID.String(),
+		Name:        role.Name,
+		Description: role.Description,
+		IsSystem:    role.IsSystem,
+		Permissions: permissions,
+	}
+}
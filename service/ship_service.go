@@ -2,7 +2,11 @@ package service
 
 import (
 	"context"
+	"log"
+	"slices"
+	"strings"
 
+	"github.com/Amierza/nawasena-backend/constants"
 	"github.com/Amierza/nawasena-backend/dto"
 	"github.com/Amierza/nawasena-backend/entity"
 	"github.com/Amierza/nawasena-backend/jwt"
@@ -14,23 +18,33 @@ import (
 type (
 	IShipService interface {
 		Create(ctx context.Context, req dto.CreateShipRequest) (dto.ShipResponse, error)
-		GetAll(ctx context.Context) ([]dto.ShipResponse, error)
+		GetAll(ctx context.Context, createdBy string) ([]dto.ShipResponse, error)
 		GetAllWithPagination(ctx context.Context, req response.PaginationRequest) (dto.ShipPaginationResponse, error)
 		GetDetail(ctx context.Context, id string) (dto.ShipResponse, error)
 		Update(ctx context.Context, req dto.UpdateShipRequest) (dto.ShipResponse, error)
 		Delete(ctx context.Context, id string) (dto.ShipResponse, error)
+		AddImage(ctx context.Context, req dto.AddImageRequest) (dto.ShipResponse, error)
+		UpdateImage(ctx context.Context, req dto.UpdateImageRequest) (dto.ShipResponse, error)
+		RemoveImage(ctx context.Context, id, imageID string) (dto.ShipResponse, error)
+		ReorderImages(ctx context.Context, req dto.ReorderImagesRequest) (dto.ShipResponse, error)
 	}
 
 	shipService struct {
-		shipRepo repository.IShipRepository
-		jwt      jwt.IJWT
+		shipRepo    repository.IShipRepository
+		fileService IFileService
+		slugService ISlugService
+		auditLog    IAuditLogService
+		jwt         jwt.IJWT
 	}
 )
 
-func NewShipService(shipRepo repository.IShipRepository, jwt jwt.IJWT) *shipService {
+func NewShipService(shipRepo repository.IShipRepository, fileService IFileService, slugService ISlugService, auditLog IAuditLogService, jwt jwt.IJWT) *shipService {
 	return &shipService{
-		shipRepo: shipRepo,
-		jwt:      jwt,
+		shipRepo:    shipRepo,
+		fileService: fileService,
+		slugService: slugService,
+		auditLog:    auditLog,
+		jwt:         jwt,
 	}
 }
 
@@ -57,46 +71,47 @@ func (as *shipService) Create(ctx context.Context, req dto.CreateShipRequest) (d
 		return dto.ShipResponse{}, dto.ErrShipAlreadyExists
 	}
 
+	slug, err := as.slugService.Generate(ctx, constants.ENUM_AUDIT_ENTITY_SHIP, req.Name, "")
+	if err != nil {
+		return dto.ShipResponse{}, err
+	}
+
 	shipID := uuid.New()
 	ship := &entity.Ship{
 		ID:          shipID,
 		Name:        req.Name,
+		Slug:        slug,
 		Description: req.Description,
 	}
 
 	// handle image url
-	var (
-		shipImages         []*entity.ShipImage
-		shipImageResponses []dto.ShipImageResponse
-	)
+	var shipImages []entity.ShipImage
 	if len(req.Images) == 0 {
 		return dto.ShipResponse{}, dto.ErrEmptyImages
 	}
-	for _, imgName := range req.Images {
-		imgID := uuid.New()
-		// handle entity
-		shipImages = append(shipImages, &entity.ShipImage{
-			ID:     imgID,
+	for _, value := range req.Images {
+		imgName, err := as.fileService.ResolveImage(ctx, value)
+		if err != nil {
+			return dto.ShipResponse{}, err
+		}
+
+		shipImages = append(shipImages, entity.ShipImage{
+			ID:     uuid.New(),
 			Name:   imgName,
 			ShipID: &shipID,
 		})
-
-		// handle response
-		shipImageResponses = append(shipImageResponses, dto.ShipImageResponse{
-			ID:   imgID.String(),
-			Name: imgName,
-		})
 	}
+	arrangeGallery(shipGalleries(shipImages))
 
-	err := as.shipRepo.RunInTransaction(ctx, func(txRepo repository.IShipRepository) error {
+	err = as.shipRepo.RunInTransaction(ctx, func(txRepo repository.IShipRepository) error {
 		// create ship
 		if err := txRepo.Create(ctx, nil, ship); err != nil {
 			return dto.ErrCreateShip
 		}
 
 		// Create new ship images
-		for _, img := range shipImages {
-			if err := txRepo.CreateImage(ctx, nil, img); err != nil {
+		for i := range shipImages {
+			if err := txRepo.CreateImage(ctx, nil, &shipImages[i]); err != nil {
 				return dto.ErrCreateShipImage
 			}
 		}
@@ -107,16 +122,25 @@ func (as *shipService) Create(ctx context.Context, req dto.CreateShipRequest) (d
 		return dto.ShipResponse{}, err
 	}
 
-	return dto.ShipResponse{
+	shipImageResponses, cover := as.mapShipImages(shipImages)
+	res := dto.ShipResponse{
 		ID:          ship.ID.String(),
 		Name:        ship.Name,
+		Slug:        ship.Slug,
 		Description: ship.Description,
 		Images:      shipImageResponses,
-	}, nil
+		Cover:       cover,
+		CreatedBy:   mapAuthor(ship.CreatedByID, ship.CreatedBy),
+		UpdatedBy:   mapAuthor(ship.UpdatedByID, ship.UpdatedBy),
+	}
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_CREATE, constants.ENUM_AUDIT_ENTITY_SHIP, ship.ID.String(), nil, res)
+
+	return res, nil
 }
 
-func (as *shipService) GetAll(ctx context.Context) ([]dto.ShipResponse, error) {
-	ships, err := as.shipRepo.GetAll(ctx, nil)
+func (as *shipService) GetAll(ctx context.Context, createdBy string) ([]dto.ShipResponse, error) {
+	ships, err := as.shipRepo.GetAll(ctx, nil, createdBy)
 	if err != nil {
 		return nil, dto.ErrGetAllShipNoPagination
 	}
@@ -126,15 +150,13 @@ func (as *shipService) GetAll(ctx context.Context) ([]dto.ShipResponse, error) {
 		data := dto.ShipResponse{
 			ID:          ship.ID.String(),
 			Name:        ship.Name,
+			Slug:        ship.Slug,
 			Description: ship.Description,
+			CreatedBy:   mapAuthor(ship.CreatedByID, ship.CreatedBy),
+			UpdatedBy:   mapAuthor(ship.UpdatedByID, ship.UpdatedBy),
 		}
 
-		for _, a := range ship.Images {
-			data.Images = append(data.Images, dto.ShipImageResponse{
-				ID:   a.ID.String(),
-				Name: a.Name,
-			})
-		}
+		data.Images, data.Cover = as.mapShipImages(ship.Images)
 
 		datas = append(datas, data)
 	}
@@ -153,15 +175,13 @@ func (as *shipService) GetAllWithPagination(ctx context.Context, req response.Pa
 		data := dto.ShipResponse{
 			ID:          ship.ID.String(),
 			Name:        ship.Name,
+			Slug:        ship.Slug,
 			Description: ship.Description,
+			CreatedBy:   mapAuthor(ship.CreatedByID, ship.CreatedBy),
+			UpdatedBy:   mapAuthor(ship.UpdatedByID, ship.UpdatedBy),
 		}
 
-		for _, a := range ship.Images {
-			data.Images = append(data.Images, dto.ShipImageResponse{
-				ID:   a.ID.String(),
-				Name: a.Name,
-			})
-		}
+		data.Images, data.Cover = as.mapShipImages(ship.Images)
 
 		datas = append(datas, data)
 	}
@@ -178,6 +198,14 @@ func (as *shipService) GetAllWithPagination(ctx context.Context, req response.Pa
 }
 
 func (as *shipService) GetDetail(ctx context.Context, id string) (dto.ShipResponse, error) {
+	id, err := as.slugService.Resolve(ctx, constants.ENUM_AUDIT_ENTITY_SHIP, id)
+	if err != nil {
+		return dto.ShipResponse{}, err
+	}
+	if id == "" {
+		return dto.ShipResponse{}, dto.ErrShipNotFound
+	}
+
 	ship, _, err := as.shipRepo.GetByID(ctx, nil, id)
 	if err != nil {
 		return dto.ShipResponse{}, dto.ErrShipNotFound
@@ -186,15 +214,13 @@ func (as *shipService) GetDetail(ctx context.Context, id string) (dto.ShipRespon
 	res := dto.ShipResponse{
 		ID:          ship.ID.String(),
 		Name:        ship.Name,
+		Slug:        ship.Slug,
 		Description: ship.Description,
+		CreatedBy:   mapAuthor(ship.CreatedByID, ship.CreatedBy),
+		UpdatedBy:   mapAuthor(ship.UpdatedByID, ship.UpdatedBy),
 	}
 
-	for _, a := range ship.Images {
-		res.Images = append(res.Images, dto.ShipImageResponse{
-			ID:   a.ID.String(),
-			Name: a.Name,
-		})
-	}
+	res.Images, res.Cover = as.mapShipImages(ship.Images)
 
 	return res, nil
 }
@@ -209,6 +235,17 @@ func (as *shipService) Update(ctx context.Context, req dto.UpdateShipRequest) (d
 		return dto.ShipResponse{}, dto.ErrShipNotFound
 	}
 
+	before := dto.ShipResponse{
+		ID:          ship.ID.String(),
+		Name:        ship.Name,
+		Slug:        ship.Slug,
+		Description: ship.Description,
+		CreatedBy:   mapAuthor(ship.CreatedByID, ship.CreatedBy),
+		UpdatedBy:   mapAuthor(ship.UpdatedByID, ship.UpdatedBy),
+	}
+
+	before.Images, before.Cover = as.mapShipImages(ship.Images)
+
 	// handle name request
 	if req.Name != "" && req.Name != ship.Name {
 		if len(req.Name) < 3 {
@@ -218,6 +255,15 @@ func (as *shipService) Update(ctx context.Context, req dto.UpdateShipRequest) (d
 		ship.Name = req.Name
 	}
 
+	// slug ikut judul, slug lama disimpan sebagai redirect setelah update berhasil
+	oldSlug := ship.Slug
+	if ship.Name != before.Name || ship.Slug == "" {
+		ship.Slug, err = as.slugService.Generate(ctx, constants.ENUM_AUDIT_ENTITY_SHIP, ship.Name, ship.ID.String())
+		if err != nil {
+			return dto.ShipResponse{}, err
+		}
+	}
+
 	// handle description request
 	if req.Description != "" && req.Description != ship.Description {
 		if len(req.Description) < 5 {
@@ -229,25 +275,39 @@ func (as *shipService) Update(ctx context.Context, req dto.UpdateShipRequest) (d
 
 	// handle image url
 	var (
-		shipImages         []*entity.ShipImage
-		shipImageResponses []dto.ShipImageResponse
+		shipImages    []entity.ShipImage
+		removedImages []entity.ShipImage
 	)
 	if len(req.Images) > 0 {
-		for _, imgName := range req.Images {
-			imgID := uuid.New()
-			// handle entity
-			shipImages = append(shipImages, &entity.ShipImage{
-				ID:     imgID,
-				Name:   imgName,
-				ShipID: &ship.ID,
-			})
+		var names, existing []string
+		for _, value := range req.Images {
+			imgName, err := as.fileService.ResolveImage(ctx, value)
+			if err != nil {
+				return dto.ShipResponse{}, err
+			}
+			names = append(names, imgName)
+		}
+		for _, img := range ship.Images {
+			existing = append(existing, img.Name)
+		}
+
+		reuse, removed := planGallerySync(existing, names)
+		for i, name := range names {
+			if reuse[i] >= 0 {
+				shipImages = append(shipImages, ship.Images[reuse[i]])
+				continue
+			}
 
-			// handle response
-			shipImageResponses = append(shipImageResponses, dto.ShipImageResponse{
-				ID:   imgID.String(),
-				Name: imgName,
+			shipImages = append(shipImages, entity.ShipImage{
+				ID:     uuid.New(),
+				Name:   name,
+				ShipID: &ship.ID,
 			})
 		}
+		for _, index := range removed {
+			removedImages = append(removedImages, ship.Images[index])
+		}
+		arrangeGallery(shipGalleries(shipImages))
 	}
 
 	err = as.shipRepo.RunInTransaction(ctx, func(txRepo repository.IShipRepository) error {
@@ -257,18 +317,15 @@ func (as *shipService) Update(ctx context.Context, req dto.UpdateShipRequest) (d
 		}
 
 		// handle new image
-		if len(req.Name) > 0 {
-			// Delete Existing Ship Image
-			// in db
-			if err := txRepo.DeleteImagesByID(ctx, nil, ship.ID.String()); err != nil {
-				return dto.ErrDeleteShipImageByShipID
+		if len(req.Images) > 0 {
+			for _, img := range removedImages {
+				if err := txRepo.DeleteImageByID(ctx, nil, ship.ID.String(), img.ID.String()); err != nil {
+					return dto.ErrDeleteImage
+				}
 			}
 
-			// Create new ship images
-			for _, img := range shipImages {
-				if err := txRepo.CreateImage(ctx, nil, img); err != nil {
-					return dto.ErrCreateShipImage
-				}
+			if err := as.saveShipImages(ctx, txRepo, shipImages); err != nil {
+				return err
 			}
 		}
 
@@ -278,12 +335,18 @@ func (as *shipService) Update(ctx context.Context, req dto.UpdateShipRequest) (d
 		return dto.ShipResponse{}, err
 	}
 
-	return dto.ShipResponse{
-		ID:          ship.ID.String(),
-		Name:        ship.Name,
-		Description: ship.Description,
-		Images:      shipImageResponses,
-	}, nil
+	if err := as.slugService.Rename(ctx, constants.ENUM_AUDIT_ENTITY_SHIP, ship.ID.String(), oldSlug, ship.Slug); err != nil {
+		log.Printf("slug redirect ship %s: %v", ship.ID, err)
+	}
+
+	if len(req.Images) > 0 {
+		ship.Images = shipImages
+	}
+	res := as.mapShipToResponse(ship)
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_SHIP, ship.ID.String(), before, res)
+
+	return res, nil
 }
 
 func (as *shipService) Delete(ctx context.Context, id string) (dto.ShipResponse, error) {
@@ -313,18 +376,278 @@ func (as *shipService) Delete(ctx context.Context, id string) (dto.ShipResponse,
 		return dto.ShipResponse{}, err
 	}
 
+	if err := as.slugService.DeleteRedirects(ctx, constants.ENUM_AUDIT_ENTITY_SHIP, id); err != nil {
+		log.Printf("slug redirect ship %s: %v", id, err)
+	}
+
 	res := dto.ShipResponse{
 		ID:          deletedShip.ID.String(),
 		Name:        deletedShip.Name,
+		Slug:        deletedShip.Slug,
 		Description: deletedShip.Description,
+		CreatedBy:   mapAuthor(deletedShip.CreatedByID, deletedShip.CreatedBy),
+		UpdatedBy:   mapAuthor(deletedShip.UpdatedByID, deletedShip.UpdatedBy),
 	}
 
-	for _, a := range deletedShip.Images {
-		res.Images = append(res.Images, dto.ShipImageResponse{
-			ID:   a.ID.String(),
-			Name: a.Name,
-		})
+	res.Images, res.Cover = as.mapShipImages(deletedShip.Images)
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_DELETE, constants.ENUM_AUDIT_ENTITY_SHIP, deletedShip.ID.String(), res, nil)
+
+	return res, nil
+}
+
+func (as *shipService) AddImage(ctx context.Context, req dto.AddImageRequest) (dto.ShipResponse, error) {
+	ship, found, err := as.shipRepo.GetByID(ctx, nil, req.ParentID)
+	if err != nil {
+		return dto.ShipResponse{}, dto.ErrGetShipByID
+	}
+	if !found {
+		return dto.ShipResponse{}, dto.ErrShipNotFound
+	}
+
+	before := as.mapShipToResponse(ship)
+
+	// handle image request
+	if strings.TrimSpace(req.Image) == "" {
+		return dto.ShipResponse{}, dto.ErrEmptyImage
+	}
+	imgName, err := as.fileService.ResolveImage(ctx, strings.TrimSpace(req.Image))
+	if err != nil {
+		return dto.ShipResponse{}, err
+	}
+
+	caption, alt, err := validateGalleryText(req.Caption, req.Alt)
+	if err != nil {
+		return dto.ShipResponse{}, err
+	}
+
+	index, err := galleryInsertIndex(req.Position, len(ship.Images))
+	if err != nil {
+		return dto.ShipResponse{}, err
+	}
+
+	ship.Images = slices.Insert(ship.Images, index, entity.ShipImage{
+		ID:      uuid.New(),
+		Name:    imgName,
+		ShipID:  &ship.ID,
+		Gallery: entity.Gallery{Caption: caption, Alt: alt},
+	})
+	galleries := shipGalleries(ship.Images)
+	if req.IsCover {
+		setGalleryCover(galleries, index)
+	}
+	arrangeGallery(galleries)
+
+	err = as.shipRepo.RunInTransaction(ctx, func(txRepo repository.IShipRepository) error {
+		return as.saveShipImages(ctx, txRepo, ship.Images)
+	})
+	if err != nil {
+		return dto.ShipResponse{}, err
+	}
+
+	res := as.mapShipToResponse(ship)
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_SHIP, ship.ID.String(), before, res)
+
+	return res, nil
+}
+
+func (as *shipService) UpdateImage(ctx context.Context, req dto.UpdateImageRequest) (dto.ShipResponse, error) {
+	ship, found, err := as.shipRepo.GetByID(ctx, nil, req.ParentID)
+	if err != nil {
+		return dto.ShipResponse{}, dto.ErrGetShipByID
+	}
+	if !found {
+		return dto.ShipResponse{}, dto.ErrShipNotFound
+	}
+
+	index := slices.IndexFunc(ship.Images, func(img entity.ShipImage) bool { return img.ID.String() == req.ImageID })
+	if index == -1 {
+		return dto.ShipResponse{}, dto.ErrImageNotFound
 	}
 
+	before := as.mapShipToResponse(ship)
+	image := &ship.Images[index]
+
+	// handle caption & alt request, string kosong berarti dihapus
+	caption, alt := image.Caption, image.Alt
+	if req.Caption != nil {
+		caption = *req.Caption
+	}
+	if req.Alt != nil {
+		alt = *req.Alt
+	}
+	image.Caption, image.Alt, err = validateGalleryText(caption, alt)
+	if err != nil {
+		return dto.ShipResponse{}, err
+	}
+
+	// handle cover request
+	galleries := shipGalleries(ship.Images)
+	if req.IsCover != nil {
+		if *req.IsCover {
+			setGalleryCover(galleries, index)
+		} else {
+			unsetGalleryCover(galleries, index)
+		}
+	}
+	arrangeGallery(galleries)
+
+	err = as.shipRepo.RunInTransaction(ctx, func(txRepo repository.IShipRepository) error {
+		return as.saveShipImages(ctx, txRepo, ship.Images)
+	})
+	if err != nil {
+		return dto.ShipResponse{}, err
+	}
+
+	res := as.mapShipToResponse(ship)
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_SHIP, ship.ID.String(), before, res)
+
 	return res, nil
 }
+
+func (as *shipService) RemoveImage(ctx context.Context, id, imageID string) (dto.ShipResponse, error) {
+	ship, found, err := as.shipRepo.GetByID(ctx, nil, id)
+	if err != nil {
+		return dto.ShipResponse{}, dto.ErrGetShipByID
+	}
+	if !found {
+		return dto.ShipResponse{}, dto.ErrShipNotFound
+	}
+
+	index := slices.IndexFunc(ship.Images, func(img entity.ShipImage) bool { return img.ID.String() == imageID })
+	if index == -1 {
+		return dto.ShipResponse{}, dto.ErrImageNotFound
+	}
+	if len(ship.Images) == 1 {
+		return dto.ShipResponse{}, dto.ErrLastImage
+	}
+
+	before := as.mapShipToResponse(ship)
+
+	ship.Images = slices.Delete(ship.Images, index, index+1)
+	arrangeGallery(shipGalleries(ship.Images))
+
+	err = as.shipRepo.RunInTransaction(ctx, func(txRepo repository.IShipRepository) error {
+		if err := txRepo.DeleteImageByID(ctx, nil, ship.ID.String(), imageID); err != nil {
+			return dto.ErrDeleteImage
+		}
+
+		return as.saveShipImages(ctx, txRepo, ship.Images)
+	})
+	if err != nil {
+		return dto.ShipResponse{}, err
+	}
+
+	res := as.mapShipToResponse(ship)
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_SHIP, ship.ID.String(), before, res)
+
+	return res, nil
+}
+
+func (as *shipService) ReorderImages(ctx context.Context, req dto.ReorderImagesRequest) (dto.ShipResponse, error) {
+	ship, found, err := as.shipRepo.GetByID(ctx, nil, req.ParentID)
+	if err != nil {
+		return dto.ShipResponse{}, dto.ErrGetShipByID
+	}
+	if !found {
+		return dto.ShipResponse{}, dto.ErrShipNotFound
+	}
+
+	var ids []uuid.UUID
+	for _, img := range ship.Images {
+		ids = append(ids, img.ID)
+	}
+	order, err := galleryOrder(ids, req.ImageIDs)
+	if err != nil {
+		return dto.ShipResponse{}, err
+	}
+
+	before := as.mapShipToResponse(ship)
+
+	var images []entity.ShipImage
+	for _, index := range order {
+		images = append(images, ship.Images[index])
+	}
+	ship.Images = images
+	arrangeGallery(shipGalleries(ship.Images))
+
+	err = as.shipRepo.RunInTransaction(ctx, func(txRepo repository.IShipRepository) error {
+		return as.saveShipImages(ctx, txRepo, ship.Images)
+	})
+	if err != nil {
+		return dto.ShipResponse{}, err
+	}
+
+	res := as.mapShipToResponse(ship)
+
+	as.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_UPDATE, constants.ENUM_AUDIT_ENTITY_SHIP, ship.ID.String(), before, res)
+
+	return res, nil
+}
+
+func (as *shipService) saveShipImages(ctx context.Context, txRepo repository.IShipRepository, images []entity.ShipImage) error {
+	for i := range images {
+		if images[i].CreatedAt.IsZero() {
+			if err := txRepo.CreateImage(ctx, nil, &images[i]); err != nil {
+				return dto.ErrCreateShipImage
+			}
+			continue
+		}
+
+		if err := txRepo.UpdateImage(ctx, nil, &images[i]); err != nil {
+			return dto.ErrUpdateImage
+		}
+	}
+
+	return nil
+}
+
+func (as *shipService) mapShipToResponse(ship *entity.Ship) dto.ShipResponse {
+	res := dto.ShipResponse{
+		ID:          ship.ID.String(),
+		Name:        ship.Name,
+		Slug:        ship.Slug,
+		Description: ship.Description,
+		CreatedBy:   mapAuthor(ship.CreatedByID, ship.CreatedBy),
+		UpdatedBy:   mapAuthor(ship.UpdatedByID, ship.UpdatedBy),
+	}
+	res.Images, res.Cover = as.mapShipImages(ship.Images)
+
+	return res
+}
+
+func (as *shipService) mapShipImages(images []entity.ShipImage) ([]dto.ShipImageResponse, *dto.ShipImageResponse) {
+	datas := []dto.ShipImageResponse{}
+	for _, img := range images {
+		datas = append(datas, dto.ShipImageResponse{
+			ID:       img.ID.String(),
+			Name:     img.Name,
+			Position: img.Position,
+			Caption:  img.Caption,
+			Alt:      img.Alt,
+			IsCover:  img.IsCover,
+			Sources:  as.fileService.ImageSources(img.Name),
+		})
+	}
+
+	cover := galleryCoverIndex(shipGalleries(images))
+	if cover == -1 {
+		return datas, nil
+	}
+	datas[cover].IsCover = true
+	res := datas[cover]
+
+	return datas, &res
+}
+
+func shipGalleries(images []entity.ShipImage) []*entity.Gallery {
+	galleries := make([]*entity.Gallery, len(images))
+	for i := range images {
+		galleries[i] = &images[i].Gallery
+	}
+
+	return galleries
+}
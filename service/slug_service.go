@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/Amierza/nawasena-backend/constants"
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/entity"
+	"github.com/Amierza/nawasena-backend/helper"
+	"github.com/Amierza/nawasena-backend/repository"
+	"github.com/google/uuid"
+)
+
+const maxSlugAttempts = 50
+
+// reservedSlugs bentrok dengan route statis (GET /news/featured, /news/tags, /news/admin), jadi selalu diberi nomor
+var reservedSlugs = []string{"featured", "tags", "admin"}
+
+type (
+	ISlugService interface {
+		Generate(ctx context.Context, entityType, name, excludeID string) (string, error)
+		Resolve(ctx context.Context, entityType, value string) (string, error)
+		Rename(ctx context.Context, entityType, id, oldSlug, newSlug string) error
+		DeleteRedirects(ctx context.Context, entityType, id string) error
+		BackfillMissing(ctx context.Context) (int, error)
+	}
+
+	slugService struct {
+		slugRepo repository.ISlugRepository
+	}
+)
+
+func NewSlugService(slugRepo repository.ISlugRepository) *slugService {
+	return &slugService{
+		slugRepo: slugRepo,
+	}
+}
+
+// Generate membuat slug unik dari judul. Kalau sudah dipakai ditambah nomor (kri-2024, kri-2024-2, ...).
+// excludeID diisi saat update supaya slug milik data itu sendiri tidak dianggap bentrok
+func (ss *slugService) Generate(ctx context.Context, entityType, name, excludeID string) (string, error) {
+	base := helper.Slugify(name)
+	if base == "" {
+		// judul tanpa huruf latin sama sekali
+		base = strings.ReplaceAll(entityType, "_", "-")
+	}
+
+	for i := 1; i <= maxSlugAttempts; i++ {
+		slug := base
+		if i > 1 {
+			slug = fmt.Sprintf("%s-%d", base, i)
+		}
+
+		if slices.Contains(reservedSlugs, slug) {
+			continue
+		}
+
+		taken, err := ss.slugRepo.IsSlugTaken(ctx, nil, entityType, slug, excludeID)
+		if err != nil {
+			return "", dto.ErrGenerateSlug
+		}
+		if !taken {
+			return slug, nil
+		}
+	}
+
+	return fmt.Sprintf("%s-%s", base, uuid.NewString()[:8]), nil
+}
+
+// Resolve menerima UUID atau slug dan mengembalikan id-nya. Slug lama menghasilkan dto.SlugMovedError
+// berisi slug terbaru, detail tidak dimuat supaya handler bisa langsung redirect
+func (ss *slugService) Resolve(ctx context.Context, entityType, value string) (string, error) {
+	if _, err := uuid.Parse(value); err == nil {
+		return value, nil
+	}
+
+	id, found, err := ss.slugRepo.GetIDBySlug(ctx, nil, entityType, value)
+	if err != nil {
+		return "", dto.ErrResolveSlug
+	}
+	if found {
+		return id, nil
+	}
+
+	redirect, found, err := ss.slugRepo.GetRedirect(ctx, nil, entityType, value)
+	if err != nil {
+		return "", dto.ErrResolveSlug
+	}
+	if !found {
+		return "", nil
+	}
+
+	slug, found, err := ss.slugRepo.GetSlugByID(ctx, nil, entityType, redirect.TargetID.String())
+	if err != nil {
+		return "", dto.ErrResolveSlug
+	}
+	if !found {
+		return "", nil
+	}
+
+	return "", dto.SlugMovedError{Slug: slug}
+}
+
+// Rename dipanggil setelah judul berubah: slug lama disimpan sebagai redirect, dan redirect yang
+// sama dengan slug baru dihapus (judul dikembalikan ke judul lama)
+func (ss *slugService) Rename(ctx context.Context, entityType, id, oldSlug, newSlug string) error {
+	if oldSlug == newSlug {
+		return nil
+	}
+
+	if err := ss.slugRepo.DeleteRedirect(ctx, nil, entityType, newSlug); err != nil {
+		return err
+	}
+	if oldSlug == "" {
+		return nil
+	}
+
+	targetID, err := uuid.Parse(id)
+	if err != nil {
+		return dto.ErrParseUUID
+	}
+
+	return ss.slugRepo.CreateRedirect(ctx, nil, &entity.SlugRedirect{
+		ID:         uuid.New(),
+		EntityType: entityType,
+		Slug:       oldSlug,
+		TargetID:   targetID,
+	})
+}
+
+func (ss *slugService) DeleteRedirects(ctx context.Context, entityType, id string) error {
+	return ss.slugRepo.DeleteRedirectsByTargetID(ctx, nil, entityType, id)
+}
+
+// BackfillMissing dipakai CLI --backfill-slugs untuk data yang dibuat sebelum ada slug
+func (ss *slugService) BackfillMissing(ctx context.Context) (int, error) {
+	entityTypes := []string{
+		constants.ENUM_AUDIT_ENTITY_NEWS,
+		constants.ENUM_AUDIT_ENTITY_ACHIEVEMENT,
+		constants.ENUM_AUDIT_ENTITY_SHIP,
+		constants.ENUM_AUDIT_ENTITY_COMPETITION,
+	}
+
+	generated := 0
+	for _, entityType := range entityTypes {
+		targets, err := ss.slugRepo.GetWithoutSlug(ctx, nil, entityType)
+		if err != nil {
+			return generated, err
+		}
+
+		for _, target := range targets {
+			slug, err := ss.Generate(ctx, entityType, target.Name, target.ID)
+			if err != nil {
+				return generated, err
+			}
+
+			if err := ss.slugRepo.UpdateSlug(ctx, nil, entityType, target.ID, slug); err != nil {
+				log.Printf("backfill slug %s %s: %v", entityType, target.ID, err)
+				continue
+			}
+			generated++
+		}
+	}
+
+	return generated, nil
+}
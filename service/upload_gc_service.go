@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"log"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Amierza/nawasena-backend/constants"
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/entity"
+	"github.com/Amierza/nawasena-backend/repository"
+	"github.com/Amierza/nawasena-backend/storage"
+)
+
+type (
+	IUploadGCService interface {
+		Collect(ctx context.Context, req dto.UploadGCRequest) (dto.UploadGCResponse, error)
+		GracePeriod() time.Duration
+		Start(ctx context.Context)
+	}
+
+	uploadGCService struct {
+		storage       storage.IStorage
+		fileService   IFileService
+		referenceRepo repository.IUploadReferenceRepository
+		mediaRepo     repository.IMediaRepository
+		auditLog      IAuditLogService
+		gracePeriod   time.Duration
+		interval      time.Duration
+	}
+)
+
+// NewUploadGCService membaca UPLOAD_GC_GRACE_HOURS (default 24 jam) dan UPLOAD_GC_INTERVAL_HOURS,
+// job berkala hanya jalan kalau interval diisi
+func NewUploadGCService(storage storage.IStorage, fileService IFileService, referenceRepo repository.IUploadReferenceRepository, mediaRepo repository.IMediaRepository, auditLog IAuditLogService) *uploadGCService {
+	interval, _ := strconv.Atoi(os.Getenv("UPLOAD_GC_INTERVAL_HOURS"))
+
+	return &uploadGCService{
+		storage:       storage,
+		fileService:   fileService,
+		referenceRepo: referenceRepo,
+		mediaRepo:     mediaRepo,
+		auditLog:      auditLog,
+		gracePeriod:   time.Duration(intEnv("UPLOAD_GC_GRACE_HOURS", constants.ENUM_UPLOAD_GC_GRACE_HOURS)) * time.Hour,
+		interval:      time.Duration(interval) * time.Hour,
+	}
+}
+
+// Collect menghapus file di storage yang tidak dipakai entity mana pun. File yang tercatat di tabel
+// media tetap disimpan sebagai library, kecuali pernah dipasang ke entity lalu dilepas / diganti.
+// File yang lebih baru dari grace period dilewati karena bisa jadi baru di-upload dan belum sempat dipasang
+func (gs *uploadGCService) Collect(ctx context.Context, req dto.UploadGCRequest) (dto.UploadGCResponse, error) {
+	res := dto.UploadGCResponse{
+		DryRun:  req.DryRun,
+		Orphans: []string{},
+	}
+
+	names, err := gs.referenceRepo.GetReferencedNames(ctx, nil)
+	if err != nil {
+		return res, err
+	}
+	referenced := referencedKeys(names)
+
+	now := time.Now()
+	cutoff := now.Add(-req.GracePeriod)
+
+	// media yang baru dipakai ulang lewat dedup ikut dianggap baru walau file di storage sudah lama
+	touched, err := gs.mediaRepo.GetNamesUpdatedSince(ctx, nil, cutoff)
+	if err != nil {
+		return res, err
+	}
+	recent := map[string]bool{}
+	for _, name := range touched {
+		recent[name] = true
+	}
+
+	library, err := gs.syncAttachment(ctx, referenced, now, req.DryRun)
+	if err != nil {
+		return res, err
+	}
+
+	objects, err := gs.storage.List(ctx, "")
+	if err != nil {
+		return res, err
+	}
+
+	originals := map[string]bool{}
+	for _, object := range objects {
+		if !gs.fileService.isDerivative(object.Key) {
+			originals[trimExt(object.Key)] = true
+		}
+	}
+
+	for _, object := range objects {
+		res.Scanned++
+
+		// turunan ikut file aslinya, hanya turunan yang aslinya sudah hilang yang dibersihkan di sini
+		derivative := gs.fileService.isDerivative(object.Key)
+		if derivative && originals[derivativeBase(object.Key)] {
+			continue
+		}
+		if !derivative && referenced[object.Key] {
+			res.Referenced++
+			continue
+		}
+
+		media, inLibrary := library[object.Key]
+		if !derivative && inLibrary && media.DetachedAt == nil {
+			res.Library++
+			continue
+		}
+
+		// backend yang tidak memberi waktu modifikasi dianggap baru, lebih aman tidak dihapus
+		fresh := object.LastModified.IsZero() || object.LastModified.After(cutoff) || recent[object.Key]
+		if inLibrary {
+			fresh = fresh || media.DetachedAt.After(cutoff)
+		}
+		if fresh {
+			res.Recent++
+			continue
+		}
+
+		res.Orphans = append(res.Orphans, object.Key)
+		if req.DryRun {
+			continue
+		}
+
+		entityID := object.Key
+		before := map[string]any{"name": object.Key, "size": object.Size}
+		if derivative {
+			if err := gs.storage.Delete(ctx, object.Key); err != nil {
+				log.Printf("gc uploads: failed delete %s: %v", object.Key, err)
+				continue
+			}
+		} else {
+			gs.fileService.Remove(ctx, object.Key, constants.ENUM_VISIBILITY_PUBLIC)
+			if inLibrary {
+				entityID = media.ID.String()
+				before["detached_at"] = media.DetachedAt
+				if err := gs.mediaRepo.DeleteByID(ctx, nil, entityID); err != nil {
+					log.Printf("gc uploads: failed delete media %s: %v", object.Key, err)
+				}
+			}
+		}
+
+		gs.auditLog.Record(ctx, constants.ENUM_AUDIT_ACTION_GC, constants.ENUM_AUDIT_ENTITY_MEDIA, entityID, before, nil)
+
+		res.Deleted++
+		res.FreedBytes += object.Size
+	}
+
+	return res, nil
+}
+
+// syncAttachment mencatat media yang sedang dipakai entity (attached) dan media yang dulu dipakai
+// tapi sudah dilepas (detached), hasilnya media per nama file. Dry run tidak menulis ke database
+func (gs *uploadGCService) syncAttachment(ctx context.Context, referenced map[string]bool, now time.Time, dryRun bool) (map[string]*entity.Media, error) {
+	medias, err := gs.mediaRepo.GetAllAttachment(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	library := map[string]*entity.Media{}
+	var attached, detached []string
+	for i := range medias {
+		media := &medias[i]
+		library[media.Name] = media
+
+		switch {
+		case referenced[media.Name]:
+			if media.AttachedAt == nil || media.DetachedAt != nil {
+				attached = append(attached, media.ID.String())
+			}
+		case media.AttachedAt != nil && media.DetachedAt == nil:
+			// grace period dihitung sejak media terlihat dilepas
+			media.DetachedAt = &now
+			detached = append(detached, media.ID.String())
+		}
+	}
+
+	if dryRun {
+		return library, nil
+	}
+	if err := gs.mediaRepo.MarkAttached(ctx, nil, attached); err != nil {
+		return nil, err
+	}
+	if err := gs.mediaRepo.MarkDetached(ctx, nil, detached); err != nil {
+		return nil, err
+	}
+
+	return library, nil
+}
+
+func (gs *uploadGCService) GracePeriod() time.Duration {
+	return gs.gracePeriod
+}
+
+// Start menjalankan GC berkala di background sampai ctx selesai
+func (gs *uploadGCService) Start(ctx context.Context) {
+	if gs.interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(gs.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				res, err := gs.Collect(ctx, dto.UploadGCRequest{GracePeriod: gs.gracePeriod})
+				if err != nil {
+					log.Printf("gc uploads: %v", err)
+					continue
+				}
+				log.Printf("gc uploads: scanned %d, deleted %d, freed %d bytes", res.Scanned, res.Deleted, res.FreedBytes)
+			}
+		}
+	}()
+}
+
+// referencedKeys menerima nama file, "/uploads/<key>" maupun url penuh. Setiap akhiran path
+// ikut dicatat supaya url publik s3 / supabase tetap cocok dengan key di storage
+func referencedKeys(names []string) map[string]bool {
+	keys := map[string]bool{}
+	for _, name := range names {
+		name, _, _ = strings.Cut(name, "?")
+		name, _, _ = strings.Cut(name, "#")
+
+		parts := strings.Split(strings.Trim(name, "/"), "/")
+		for i := range parts {
+			keys[strings.Join(parts[i:], "/")] = true
+		}
+	}
+
+	return keys
+}
+
+// derivativeBase mengembalikan key asli tanpa extension, mis. abc_thumbnail.webp → abc
+func derivativeBase(key string) string {
+	base := trimExt(key)
+	if i := strings.LastIndex(base, "_"); i >= 0 {
+		return base[:i]
+	}
+
+	return base
+}
+
+func trimExt(key string) string {
+	return strings.TrimSuffix(key, path.Ext(key))
+}
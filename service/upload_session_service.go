@@ -0,0 +1,384 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Amierza/nawasena-backend/constants"
+	"github.com/Amierza/nawasena-backend/dto"
+	"github.com/Amierza/nawasena-backend/entity"
+	"github.com/Amierza/nawasena-backend/repository"
+	"github.com/google/uuid"
+)
+
+type (
+	IUploadSessionService interface {
+		Create(ctx context.Context, req dto.CreateUploadSessionRequest) (dto.UploadSessionResponse, error)
+		GetDetail(ctx context.Context, id string) (dto.UploadSessionResponse, error)
+		Append(ctx context.Context, req dto.AppendUploadChunkRequest) (dto.UploadSessionResponse, error)
+		Complete(ctx context.Context, id string) (dto.UploadedFileResponse, error)
+		Abort(ctx context.Context, id string) error
+		ChunkSize() int64
+		CleanupExpired(ctx context.Context) (int, error)
+		Start(ctx context.Context)
+	}
+
+	uploadSessionService struct {
+		sessionRepo repository.IUploadSessionRepository
+		fileService IFileService
+		tempDir     string
+		chunkSize   int64
+		maxSize     int64
+		ttl         time.Duration
+		// satu chunk per session dalam satu waktu, file sementaranya ada di server ini
+		locks sync.Map
+	}
+)
+
+// NewUploadSessionService membaca UPLOAD_CHUNK_SIZE_MB, UPLOAD_MAX_RESUMABLE_MB, UPLOAD_SESSION_TTL_HOURS
+// dan UPLOAD_TMP_DIR. Chunk ditampung di disk server sampai upload selesai, baru dipindah ke storage
+func NewUploadSessionService(sessionRepo repository.IUploadSessionRepository, fileService IFileService) *uploadSessionService {
+	tempDir := os.Getenv("UPLOAD_TMP_DIR")
+	if tempDir == "" {
+		tempDir = filepath.Join(os.TempDir(), "nawasena-uploads")
+	}
+
+	return &uploadSessionService{
+		sessionRepo: sessionRepo,
+		fileService: fileService,
+		tempDir:     tempDir,
+		chunkSize:   int64(intEnv("UPLOAD_CHUNK_SIZE_MB", constants.ENUM_UPLOAD_CHUNK_SIZE_MB)) << 20,
+		maxSize:     int64(intEnv("UPLOAD_MAX_RESUMABLE_MB", constants.ENUM_UPLOAD_MAX_RESUMABLE_MB)) << 20,
+		ttl:         time.Duration(intEnv("UPLOAD_SESSION_TTL_HOURS", constants.ENUM_UPLOAD_SESSION_TTL_HOURS)) * time.Hour,
+	}
+}
+
+func (uss *uploadSessionService) Create(ctx context.Context, req dto.CreateUploadSessionRequest) (dto.UploadSessionResponse, error) {
+	// handle filename request
+	req.Filename = filepath.Base(strings.TrimSpace(req.Filename))
+	if req.Filename == "" || req.Filename == "." {
+		return dto.UploadSessionResponse{}, dto.ErrEmptyFilename
+	}
+	if _, ok := allowedTypes[strings.ToLower(filepath.Ext(req.Filename))]; !ok {
+		return dto.UploadSessionResponse{}, fmt.Errorf("%w, only %s allowed", dto.ErrUnsupportedFileType, allowedExtensions())
+	}
+
+	// handle size request
+	if req.Size <= 0 {
+		return dto.UploadSessionResponse{}, dto.ErrInvalidUploadSize
+	}
+	if req.Size > uss.maxSize {
+		return dto.UploadSessionResponse{}, fmt.Errorf("%w, max %d MB", dto.ErrUploadTooLarge, uss.maxSize>>20)
+	}
+
+	// handle checksum request, sha-256 seluruh file dalam hex
+	req.Checksum = strings.ToLower(strings.TrimSpace(req.Checksum))
+	if decoded, err := hex.DecodeString(req.Checksum); err != nil || len(decoded) != sha256.Size {
+		return dto.UploadSessionResponse{}, dto.ErrInvalidChecksum
+	}
+
+	// handle visibility request, storage private dicek sekarang supaya tidak gagal setelah semua chunk terkirim
+	if req.Visibility == "" {
+		req.Visibility = constants.ENUM_VISIBILITY_PUBLIC
+	}
+	if _, err := uss.fileService.storageFor(req.Visibility); err != nil {
+		return dto.UploadSessionResponse{}, err
+	}
+
+	if err := os.MkdirAll(uss.tempDir, os.ModePerm); err != nil {
+		return dto.UploadSessionResponse{}, dto.ErrCreateUploadSession
+	}
+
+	session := &entity.UploadSession{
+		ID:         uuid.New(),
+		Filename:   req.Filename,
+		Size:       req.Size,
+		Checksum:   req.Checksum,
+		Visibility: req.Visibility,
+		ExpiresAt:  time.Now().Add(uss.ttl),
+	}
+	if err := uss.sessionRepo.Create(ctx, nil, session); err != nil {
+		return dto.UploadSessionResponse{}, dto.ErrCreateUploadSession
+	}
+
+	return uss.mapSessionToResponse(session), nil
+}
+
+func (uss *uploadSessionService) GetDetail(ctx context.Context, id string) (dto.UploadSessionResponse, error) {
+	session, err := uss.getSession(ctx, id)
+	if err != nil {
+		return dto.UploadSessionResponse{}, err
+	}
+
+	return uss.mapSessionToResponse(session), nil
+}
+
+// Append menulis satu chunk di posisi Offset. Offset harus sama dengan progres di server,
+// client yang terputus cukup GET session lalu melanjutkan dari offset yang dikembalikan
+func (uss *uploadSessionService) Append(ctx context.Context, req dto.AppendUploadChunkRequest) (dto.UploadSessionResponse, error) {
+	unlock := uss.lock(req.ID)
+	defer unlock()
+
+	session, err := uss.getSession(ctx, req.ID)
+	if err != nil {
+		return dto.UploadSessionResponse{}, err
+	}
+
+	if req.Offset < 0 {
+		return dto.UploadSessionResponse{}, dto.ErrInvalidUploadOffset
+	}
+	if req.Offset != session.Offset {
+		return uss.mapSessionToResponse(session), dto.ErrUploadOffsetMismatch
+	}
+
+	// chunk dibaca penuh dulu supaya checksum bisa dicek sebelum menyentuh file
+	chunk, err := io.ReadAll(io.LimitReader(req.Body, uss.chunkSize+1))
+	if err != nil {
+		return dto.UploadSessionResponse{}, dto.ErrWriteUploadChunk
+	}
+	if int64(len(chunk)) > uss.chunkSize {
+		return dto.UploadSessionResponse{}, fmt.Errorf("%w, max %d MB", dto.ErrChunkTooLarge, uss.chunkSize>>20)
+	}
+	if session.Offset+int64(len(chunk)) > session.Size {
+		return dto.UploadSessionResponse{}, dto.ErrChunkExceedsUploadSize
+	}
+	if err := verifyChunkChecksum(req.Checksum, chunk); err != nil {
+		return dto.UploadSessionResponse{}, err
+	}
+
+	file, err := os.OpenFile(uss.partPath(session.ID.String()), os.O_WRONLY|os.O_CREATE, 0o600)
+	if err != nil {
+		return dto.UploadSessionResponse{}, dto.ErrWriteUploadChunk
+	}
+	defer file.Close()
+
+	// sisa tulisan chunk yang gagal sebelumnya dibuang dulu
+	if err := file.Truncate(session.Offset); err != nil {
+		return dto.UploadSessionResponse{}, dto.ErrWriteUploadChunk
+	}
+	if _, err := file.WriteAt(chunk, session.Offset); err != nil {
+		return dto.UploadSessionResponse{}, dto.ErrWriteUploadChunk
+	}
+
+	newOffset := session.Offset + int64(len(chunk))
+	expiresAt := time.Now().Add(uss.ttl)
+	updated, err := uss.sessionRepo.UpdateOffset(ctx, nil, session.ID.String(), session.Offset, newOffset, expiresAt)
+	if err != nil {
+		return dto.UploadSessionResponse{}, dto.ErrWriteUploadChunk
+	}
+	if !updated {
+		return dto.UploadSessionResponse{}, dto.ErrUploadOffsetMismatch
+	}
+
+	session.Offset = newOffset
+	session.ExpiresAt = expiresAt
+
+	return uss.mapSessionToResponse(session), nil
+}
+
+// Complete mencocokkan checksum seluruh file lalu menyerahkannya ke file service,
+// jadi validasi isi file, dedup dan media library sama dengan upload biasa
+func (uss *uploadSessionService) Complete(ctx context.Context, id string) (dto.UploadedFileResponse, error) {
+	unlock := uss.lock(id)
+	defer unlock()
+
+	session, err := uss.getSession(ctx, id)
+	if err != nil {
+		return dto.UploadedFileResponse{}, err
+	}
+	if session.Offset != session.Size {
+		return dto.UploadedFileResponse{}, fmt.Errorf("%w, %d of %d bytes received", dto.ErrUploadIncomplete, session.Offset, session.Size)
+	}
+
+	file, err := os.Open(uss.partPath(id))
+	if err != nil {
+		return dto.UploadedFileResponse{}, dto.ErrSaveFile
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return dto.UploadedFileResponse{}, dto.ErrSaveFile
+	}
+	if hex.EncodeToString(hasher.Sum(nil)) != session.Checksum {
+		// isi file sudah pasti rusak, session dibuang supaya client mulai ulang
+		uss.discard(ctx, id)
+		return dto.UploadedFileResponse{}, dto.ErrUploadChecksumMismatch
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return dto.UploadedFileResponse{}, dto.ErrSaveFile
+	}
+
+	res, err := uss.fileService.StoreFile(ctx, file, session.Size, session.Filename, session.Visibility)
+	if err != nil {
+		var fileErr dto.FileError
+		if errors.As(err, &fileErr) {
+			uss.discard(ctx, id)
+		}
+		return dto.UploadedFileResponse{}, err
+	}
+
+	uss.discard(ctx, id)
+
+	return res, nil
+}
+
+func (uss *uploadSessionService) Abort(ctx context.Context, id string) error {
+	unlock := uss.lock(id)
+	defer unlock()
+
+	if _, err := uss.getSession(ctx, id); err != nil && !errors.Is(err, dto.ErrUploadSessionExpired) {
+		return err
+	}
+
+	if err := uss.sessionRepo.DeleteByID(ctx, nil, id); err != nil {
+		return dto.ErrDeleteUploadSessionByID
+	}
+	uss.removePart(id)
+	uss.locks.Delete(id)
+
+	return nil
+}
+
+func (uss *uploadSessionService) ChunkSize() int64 {
+	return uss.chunkSize
+}
+
+// CleanupExpired menghapus session yang ditinggalkan beserta file sementaranya
+func (uss *uploadSessionService) CleanupExpired(ctx context.Context) (int, error) {
+	sessions, err := uss.sessionRepo.GetExpired(ctx, nil, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	for _, session := range sessions {
+		uss.discard(ctx, session.ID.String())
+	}
+
+	return len(sessions), nil
+}
+
+// Start membersihkan session kadaluarsa tiap jam di background sampai ctx selesai
+func (uss *uploadSessionService) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				removed, err := uss.CleanupExpired(ctx)
+				if err != nil {
+					log.Printf("cleanup upload sessions: %v", err)
+					continue
+				}
+				if removed > 0 {
+					log.Printf("cleanup upload sessions: removed %d expired session(s)", removed)
+				}
+			}
+		}
+	}()
+}
+
+// getSession hanya mengembalikan session milik admin yang sedang login
+func (uss *uploadSessionService) getSession(ctx context.Context, id string) (*entity.UploadSession, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, dto.ErrParseUUID
+	}
+
+	session, found, err := uss.sessionRepo.GetByID(ctx, nil, id)
+	if err != nil {
+		return nil, dto.ErrGetUploadSessionByID
+	}
+	if !found {
+		return nil, dto.ErrUploadSessionNotFound
+	}
+
+	adminID, _ := ctx.Value("admin_id").(string)
+	if session.CreatedByID == nil || session.CreatedByID.String() != adminID {
+		return nil, dto.ErrUploadSessionNotFound
+	}
+
+	if time.Now().After(session.ExpiresAt) {
+		return session, dto.ErrUploadSessionExpired
+	}
+
+	return session, nil
+}
+
+func (uss *uploadSessionService) discard(ctx context.Context, id string) {
+	if err := uss.sessionRepo.DeleteByID(ctx, nil, id); err != nil {
+		log.Printf("failed delete upload session %s: %v", id, err)
+	}
+	uss.removePart(id)
+	uss.locks.Delete(id)
+}
+
+func (uss *uploadSessionService) removePart(id string) {
+	if err := os.Remove(uss.partPath(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("failed delete upload part %s: %v", id, err)
+	}
+}
+
+func (uss *uploadSessionService) partPath(id string) string {
+	return filepath.Join(uss.tempDir, id+".part")
+}
+
+func (uss *uploadSessionService) lock(id string) func() {
+	value, _ := uss.locks.LoadOrStore(id, &sync.Mutex{})
+	mu := value.(*sync.Mutex)
+	mu.Lock()
+
+	return mu.Unlock
+}
+
+func (uss *uploadSessionService) mapSessionToResponse(session *entity.UploadSession) dto.UploadSessionResponse {
+	return dto.UploadSessionResponse{
+		ID:         session.ID.String(),
+		Filename:   session.Filename,
+		Size:       session.Size,
+		Offset:     session.Offset,
+		ChunkSize:  uss.chunkSize,
+		Checksum:   session.Checksum,
+		Visibility: session.Visibility,
+		ExpiresAt:  session.ExpiresAt,
+	}
+}
+
+// verifyChunkChecksum memakai format header tus "sha256 <base64>", header kosong berarti tidak dicek
+func verifyChunkChecksum(header string, chunk []byte) error {
+	if header == "" {
+		return nil
+	}
+
+	algorithm, value, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok || !strings.EqualFold(algorithm, "sha256") {
+		return dto.ErrInvalidChecksum
+	}
+
+	expected, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return dto.ErrInvalidChecksum
+	}
+
+	sum := sha256.Sum256(chunk)
+	if !bytes.Equal(sum[:], expected) {
+		return dto.ErrChunkChecksumMismatch
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+type LocalStorage struct {
+	root string
+}
+
+func NewLocalStorage(root string) (*LocalStorage, error) {
+	if err := os.MkdirAll(root, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed create storage folder %s: %w", root, err)
+	}
+
+	return &LocalStorage{root: root}, nil
+}
+
+func (ls *LocalStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	fullPath, err := ls.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fullPath), os.ModePerm); err != nil {
+		return err
+	}
+
+	// tulis ke file sementara dulu supaya file setengah jadi tidak pernah ikut dilayani
+	tmp, err := os.CreateTemp(filepath.Dir(fullPath), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), fullPath)
+}
+
+func (ls *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error) {
+	info, err := ls.Stat(ctx, key)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+
+	fullPath, _ := ls.path(key)
+	file, err := os.Open(fullPath)
+	if err != nil {
+		return nil, ObjectInfo{}, ls.mapError(err)
+	}
+
+	return file, info, nil
+}
+
+func (ls *LocalStorage) Delete(ctx context.Context, key string) error {
+	fullPath, err := ls.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(fullPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (ls *LocalStorage) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	fullPath, err := ls.path(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	stat, err := os.Stat(fullPath)
+	if err != nil {
+		return ObjectInfo{}, ls.mapError(err)
+	}
+	if stat.IsDir() {
+		return ObjectInfo{}, ErrNotFound
+	}
+
+	cleaned, _ := CleanKey(key)
+	return ObjectInfo{
+		Key:          cleaned,
+		Size:         stat.Size(),
+		ContentType:  mime.TypeByExtension(path.Ext(cleaned)),
+		LastModified: stat.ModTime(),
+	}, nil
+}
+
+func (ls *LocalStorage) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	err := filepath.WalkDir(ls.root, func(fullPath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(ls.root, fullPath)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		stat, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, ObjectInfo{
+			Key:          key,
+			Size:         stat.Size(),
+			ContentType:  mime.TypeByExtension(path.Ext(key)),
+			LastModified: stat.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+// URL kosong karena file lokal dilayani langsung oleh handler /uploads
+func (ls *LocalStorage) URL(key string) string {
+	return ""
+}
+
+func (ls *LocalStorage) path(key string) (string, error) {
+	cleaned, err := CleanKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(ls.root, filepath.FromSlash(cleaned)), nil
+}
+
+func (ls *LocalStorage) mapError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+
+	return err
+}
@@ -0,0 +1,384 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	S3Config struct {
+		// Endpoint mis. http://localhost:9000 untuk MinIO, kosong berarti AWS S3 sesuai region
+		Endpoint  string
+		Region    string
+		Bucket    string
+		AccessKey string
+		SecretKey string
+		// ForcePathStyle memakai endpoint/bucket/key, dibutuhkan MinIO dan kebanyakan S3-compatible
+		ForcePathStyle bool
+		// PublicURL kalau diisi, GET /uploads akan redirect ke PublicURL/key
+		PublicURL string
+	}
+
+	// S3Storage bicara langsung ke REST API S3 dengan Signature V4, jadi tidak butuh SDK
+	S3Storage struct {
+		config   S3Config
+		endpoint *url.URL
+		client   *http.Client
+		now      func() time.Time
+	}
+)
+
+const s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+
+func NewS3Storage(config S3Config) (*S3Storage, error) {
+	if config.Bucket == "" || config.AccessKey == "" || config.SecretKey == "" {
+		return nil, fmt.Errorf("S3_BUCKET, S3_ACCESS_KEY and S3_SECRET_KEY must be set")
+	}
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+	if config.Endpoint == "" {
+		config.Endpoint = "https://s3." + config.Region + ".amazonaws.com"
+	}
+
+	endpoint, err := url.Parse(strings.TrimRight(config.Endpoint, "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3_ENDPOINT %q", config.Endpoint)
+	}
+
+	return &S3Storage{
+		config:   config,
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 5 * time.Minute},
+		now:      time.Now,
+	}, nil
+}
+
+func (ss *S3Storage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	payloadHash := s3UnsignedPayload
+
+	// kalau body bisa di-seek (multipart file), hash payload ikut ditandatangani
+	if seeker, ok := body.(io.ReadSeeker); ok {
+		hash := sha256.New()
+		if _, err := io.Copy(hash, seeker); err != nil {
+			return err
+		}
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		payloadHash = hex.EncodeToString(hash.Sum(nil))
+	}
+
+	req, err := ss.newRequest(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	res, err := ss.do(req, payloadHash)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+
+	return nil
+}
+
+func (ss *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error) {
+	req, err := ss.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+
+	res, err := ss.do(req, emptyPayloadHash())
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+
+	return res.Body, ss.objectInfo(key, res), nil
+}
+
+func (ss *S3Storage) Delete(ctx context.Context, key string) error {
+	req, err := ss.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := ss.do(req, emptyPayloadHash())
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+
+	return nil
+}
+
+func (ss *S3Storage) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	req, err := ss.newRequest(ctx, http.MethodHead, key, nil)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	res, err := ss.do(req, emptyPayloadHash())
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	res.Body.Close()
+
+	return ss.objectInfo(key, res), nil
+}
+
+func (ss *S3Storage) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var (
+		objects           []ObjectInfo
+		continuationToken string
+	)
+
+	// ListObjectsV2 maksimal 1000 object per halaman
+	for {
+		query := url.Values{"list-type": {"2"}}
+		if prefix != "" {
+			query.Set("prefix", prefix)
+		}
+		if continuationToken != "" {
+			query.Set("continuation-token", continuationToken)
+		}
+
+		u := *ss.endpoint
+		if ss.config.ForcePathStyle {
+			u.Path = u.Path + "/" + ss.config.Bucket
+		} else {
+			u.Host = ss.config.Bucket + "." + u.Host
+			u.Path = u.Path + "/"
+		}
+		u.RawQuery = s3CanonicalQuery(query)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := ss.do(req, emptyPayloadHash())
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			IsTruncated           bool   `xml:"IsTruncated"`
+			NextContinuationToken string `xml:"NextContinuationToken"`
+			Contents              []struct {
+				Key          string    `xml:"Key"`
+				Size         int64     `xml:"Size"`
+				ETag         string    `xml:"ETag"`
+				LastModified time.Time `xml:"LastModified"`
+			} `xml:"Contents"`
+		}
+		err = xml.NewDecoder(res.Body).Decode(&result)
+		res.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed decode s3 list response: %w", err)
+		}
+
+		for _, content := range result.Contents {
+			objects = append(objects, ObjectInfo{
+				Key:          content.Key,
+				Size:         content.Size,
+				ContentType:  mime.TypeByExtension(path.Ext(content.Key)),
+				ETag:         strings.Trim(content.ETag, `"`),
+				LastModified: content.LastModified,
+			})
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		continuationToken = result.NextContinuationToken
+	}
+}
+
+func (ss *S3Storage) URL(key string) string {
+	cleaned, err := CleanKey(key)
+	if err != nil || ss.config.PublicURL == "" {
+		return ""
+	}
+
+	return strings.TrimRight(ss.config.PublicURL, "/") + "/" + s3EscapePath(cleaned)
+}
+
+func (ss *S3Storage) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	cleaned, err := CleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	u := *ss.endpoint
+	if ss.config.ForcePathStyle {
+		u.Path = u.Path + "/" + ss.config.Bucket + "/" + cleaned
+	} else {
+		u.Host = ss.config.Bucket + "." + u.Host
+		u.Path = u.Path + "/" + cleaned
+	}
+	u.RawPath = s3EscapePath(u.Path)
+
+	return http.NewRequestWithContext(ctx, method, u.String(), body)
+}
+
+func (ss *S3Storage) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	ss.sign(req, payloadHash, ss.now())
+
+	res, err := ss.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode == http.StatusNotFound {
+		res.Body.Close()
+		return nil, ErrNotFound
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		defer res.Body.Close()
+		message, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return nil, fmt.Errorf("s3 %s %s: %s %s", req.Method, req.URL.Path, res.Status, strings.TrimSpace(string(message)))
+	}
+
+	return res, nil
+}
+
+func (ss *S3Storage) objectInfo(key string, res *http.Response) ObjectInfo {
+	cleaned, _ := CleanKey(key)
+	info := ObjectInfo{
+		Key:         cleaned,
+		ContentType: res.Header.Get("Content-Type"),
+		ETag:        strings.Trim(res.Header.Get("ETag"), `"`),
+	}
+	if size, err := strconv.ParseInt(res.Header.Get("Content-Length"), 10, 64); err == nil {
+		info.Size = size
+	}
+	if modified, err := http.ParseTime(res.Header.Get("Last-Modified")); err == nil {
+		info.LastModified = modified
+	}
+
+	return info
+}
+
+// sign menambahkan header Authorization AWS Signature V4. Semua header yang sudah
+// ada di request ikut ditandatangani, jadi set header sebelum memanggil sign.
+func (ss *S3Storage) sign(req *http.Request, payloadHash string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3EscapePath(req.URL.Path),
+		s3CanonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + ss.config.Region + "/s3/aws4_request"
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalHash[:])
+
+	signingKey := hmacSHA256([]byte("AWS4"+ss.config.SecretKey), date)
+	signingKey = hmacSHA256(signingKey, ss.config.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+ss.config.AccessKey+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func emptyPayloadHash() string {
+	sum := sha256.Sum256(nil)
+	return hex.EncodeToString(sum[:])
+}
+
+func s3CanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			parts = append(parts, s3Escape(key, true)+"="+s3Escape(value, true))
+		}
+	}
+
+	return strings.Join(parts, "&")
+}
+
+func s3EscapePath(p string) string {
+	return s3Escape(p, false)
+}
+
+// s3Escape mengikuti aturan URI encode SigV4: hanya A-Z a-z 0-9 - _ . ~ yang tidak di-encode
+func s3Escape(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
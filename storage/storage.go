@@ -0,0 +1,176 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type (
+	IStorage interface {
+		Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+		Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error)
+		Delete(ctx context.Context, key string) error
+		Stat(ctx context.Context, key string) (ObjectInfo, error)
+		// List mengembalikan semua object yang key-nya diawali prefix, prefix kosong berarti semua
+		List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+		// URL mengembalikan url publik object, kosong kalau object harus dilayani lewat server
+		URL(key string) string
+	}
+
+	ObjectInfo struct {
+		Key          string
+		Size         int64
+		ContentType  string
+		ETag         string
+		LastModified time.Time
+	}
+)
+
+const (
+	DRIVER_LOCAL    = "local"
+	DRIVER_S3       = "s3"
+	DRIVER_SUPABASE = "supabase"
+)
+
+var (
+	ErrNotFound   = errors.New("storage object not found")
+	ErrInvalidKey = errors.New("invalid storage key")
+)
+
+// NewStorage memilih backend dari STORAGE_DRIVER: local (default), s3 atau supabase
+func NewStorage() IStorage {
+	var (
+		s   IStorage
+		err error
+	)
+
+	switch driver := strings.ToLower(envOrDefault("STORAGE_DRIVER", DRIVER_LOCAL)); driver {
+	case DRIVER_LOCAL:
+		s, err = NewLocalStorage(envOrDefault("STORAGE_LOCAL_PATH", "uploads"))
+	case DRIVER_S3:
+		s, err = NewS3Storage(S3Config{
+			Endpoint:       os.Getenv("S3_ENDPOINT"),
+			Region:         envOrDefault("S3_REGION", "us-east-1"),
+			Bucket:         os.Getenv("S3_BUCKET"),
+			AccessKey:      os.Getenv("S3_ACCESS_KEY"),
+			SecretKey:      os.Getenv("S3_SECRET_KEY"),
+			ForcePathStyle: envOrDefault("S3_FORCE_PATH_STYLE", "true") == "true",
+			PublicURL:      os.Getenv("S3_PUBLIC_URL"),
+		})
+	case DRIVER_SUPABASE:
+		s, err = NewSupabaseStorage(SupabaseConfig{
+			URL:          os.Getenv("SUPABASE_URL"),
+			Key:          os.Getenv("SUPABASE_KEY"),
+			Bucket:       os.Getenv("SUPABASE_BUCKET"),
+			PublicBucket: envOrDefault("SUPABASE_PUBLIC_BUCKET", "true") == "true",
+		})
+	default:
+		err = fmt.Errorf("unsupported STORAGE_DRIVER %q", driver)
+	}
+
+	if err != nil {
+		panic(fmt.Errorf("failed to set up storage: %v", err))
+	}
+
+	return s
+}
+
+// NewPrivateStorage menyiapkan backend untuk file private (kontrak, dokumen member) yang
+// hanya boleh diunduh lewat signed url. Lokasinya harus terpisah dari storage publik:
+// folder STORAGE_PRIVATE_PATH untuk local, S3_PRIVATE_BUCKET / SUPABASE_PRIVATE_BUCKET untuk
+// s3 dan supabase. Kalau bucket private belum diisi, nil dikembalikan dan upload private ditolak
+func NewPrivateStorage() IStorage {
+	var (
+		s   IStorage
+		err error
+	)
+
+	switch driver := strings.ToLower(envOrDefault("STORAGE_DRIVER", DRIVER_LOCAL)); driver {
+	case DRIVER_LOCAL:
+		root := envOrDefault("STORAGE_PRIVATE_PATH", "private")
+		if isSubPath(envOrDefault("STORAGE_LOCAL_PATH", "uploads"), root) {
+			err = errors.New("STORAGE_PRIVATE_PATH must be outside STORAGE_LOCAL_PATH")
+			break
+		}
+		s, err = NewLocalStorage(root)
+	case DRIVER_S3:
+		bucket := os.Getenv("S3_PRIVATE_BUCKET")
+		if bucket == "" {
+			return nil
+		}
+		if bucket == os.Getenv("S3_BUCKET") {
+			err = errors.New("S3_PRIVATE_BUCKET must differ from S3_BUCKET")
+			break
+		}
+		s, err = NewS3Storage(S3Config{
+			Endpoint:       os.Getenv("S3_ENDPOINT"),
+			Region:         envOrDefault("S3_REGION", "us-east-1"),
+			Bucket:         bucket,
+			AccessKey:      os.Getenv("S3_ACCESS_KEY"),
+			SecretKey:      os.Getenv("S3_SECRET_KEY"),
+			ForcePathStyle: envOrDefault("S3_FORCE_PATH_STYLE", "true") == "true",
+		})
+	case DRIVER_SUPABASE:
+		bucket := os.Getenv("SUPABASE_PRIVATE_BUCKET")
+		if bucket == "" {
+			return nil
+		}
+		if bucket == os.Getenv("SUPABASE_BUCKET") {
+			err = errors.New("SUPABASE_PRIVATE_BUCKET must differ from SUPABASE_BUCKET")
+			break
+		}
+		s, err = NewSupabaseStorage(SupabaseConfig{
+			URL:    os.Getenv("SUPABASE_URL"),
+			Key:    os.Getenv("SUPABASE_KEY"),
+			Bucket: bucket,
+		})
+	default:
+		err = fmt.Errorf("unsupported STORAGE_DRIVER %q", driver)
+	}
+
+	if err != nil {
+		panic(fmt.Errorf("failed to set up private storage: %v", err))
+	}
+
+	return s
+}
+
+// CleanKey menormalkan key jadi path relatif dengan separator "/" dan menolak key yang keluar dari root
+func CleanKey(key string) (string, error) {
+	key = strings.ReplaceAll(key, "\\", "/")
+	key = strings.TrimPrefix(path.Clean("/"+key), "/")
+	if key == "" || key == "." {
+		return "", ErrInvalidKey
+	}
+
+	return key, nil
+}
+
+func envOrDefault(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	return fallback
+}
+
+func isSubPath(parent, child string) bool {
+	parentAbs, err := filepath.Abs(parent)
+	if err != nil {
+		return false
+	}
+	childAbs, err := filepath.Abs(child)
+	if err != nil {
+		return false
+	}
+
+	rel, err := filepath.Rel(parentAbs, childAbs)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, "../")
+}
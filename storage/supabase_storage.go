@@ -0,0 +1,244 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	storage_go "github.com/supabase-community/storage-go"
+)
+
+type (
+	SupabaseConfig struct {
+		// URL project Supabase, mis. https://xyz.supabase.co
+		URL string
+		// Key service role key, jangan pakai anon key
+		Key    string
+		Bucket string
+		// PublicBucket kalau true, GET /uploads redirect ke url publik Supabase
+		PublicBucket bool
+	}
+
+	SupabaseStorage struct {
+		config  SupabaseConfig
+		baseURL string
+		client  *storage_go.Client
+		http    *http.Client
+	}
+)
+
+const supabaseListLimit = 100
+
+func NewSupabaseStorage(config SupabaseConfig) (*SupabaseStorage, error) {
+	if config.URL == "" || config.Key == "" || config.Bucket == "" {
+		return nil, fmt.Errorf("SUPABASE_URL, SUPABASE_KEY and SUPABASE_BUCKET must be set")
+	}
+
+	baseURL := strings.TrimRight(config.URL, "/") + "/storage/v1"
+	return &SupabaseStorage{
+		config:  config,
+		baseURL: baseURL,
+		client:  storage_go.NewClient(baseURL, config.Key, map[string]string{"apikey": config.Key}),
+		http:    &http.Client{Timeout: 5 * time.Minute},
+	}, nil
+}
+
+func (ss *SupabaseStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	cleaned, err := CleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	// UploadFile di storage-go menyimpan content type di header client yang dipakai bersama
+	// (ikut terkirim di request json berikutnya), jadi upload dikirim langsung
+	req, err := ss.newRequest(ctx, http.MethodPost, objectPath(ss.config.Bucket, cleaned), body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Cache-Control", "max-age=3600")
+	req.Header.Set("X-Upsert", "true")
+
+	res, err := ss.do(req)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+
+	return nil
+}
+
+func (ss *SupabaseStorage) Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error) {
+	res, err := ss.object(ctx, http.MethodGet, key)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+
+	return res.Body, ss.objectInfo(key, res), nil
+}
+
+func (ss *SupabaseStorage) Delete(ctx context.Context, key string) error {
+	cleaned, err := CleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	_, err = ss.client.RemoveFile(ss.config.Bucket, []string{cleaned})
+	return err
+}
+
+func (ss *SupabaseStorage) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	res, err := ss.object(ctx, http.MethodHead, key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	res.Body.Close()
+
+	return ss.objectInfo(key, res), nil
+}
+
+func (ss *SupabaseStorage) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	return ss.listFolder("", prefix)
+}
+
+func (ss *SupabaseStorage) URL(key string) string {
+	cleaned, err := CleanKey(key)
+	if err != nil || !ss.config.PublicBucket {
+		return ""
+	}
+
+	return ss.client.GetPublicUrl(ss.config.Bucket, cleaned).SignedURL
+}
+
+// object dipakai untuk GET / HEAD karena DownloadFile di storage-go tidak mengembalikan
+// header (content type, ukuran) dan selalu membaca seluruh isi file ke memori
+func (ss *SupabaseStorage) object(ctx context.Context, method, key string) (*http.Response, error) {
+	cleaned, err := CleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := ss.newRequest(ctx, method, "authenticated/"+objectPath(ss.config.Bucket, cleaned), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return ss.do(req)
+}
+
+func (ss *SupabaseStorage) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, ss.baseURL+"/object/"+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+ss.config.Key)
+	req.Header.Set("apikey", ss.config.Key)
+
+	return req, nil
+}
+
+func (ss *SupabaseStorage) do(req *http.Request) (*http.Response, error) {
+	res, err := ss.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// storage api Supabase membalas 400 "Object not found" untuk object yang tidak ada
+	if res.StatusCode == http.StatusNotFound || (res.StatusCode == http.StatusBadRequest && req.Method != http.MethodPost) {
+		res.Body.Close()
+		return nil, ErrNotFound
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		defer res.Body.Close()
+		message, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return nil, fmt.Errorf("supabase storage %s %s: %s %s", req.Method, req.URL.Path, res.Status, strings.TrimSpace(string(message)))
+	}
+
+	return res, nil
+}
+
+func (ss *SupabaseStorage) objectInfo(key string, res *http.Response) ObjectInfo {
+	cleaned, _ := CleanKey(key)
+	info := ObjectInfo{
+		Key:         cleaned,
+		ContentType: res.Header.Get("Content-Type"),
+		ETag:        strings.Trim(res.Header.Get("ETag"), `"`),
+	}
+	if size, err := strconv.ParseInt(res.Header.Get("Content-Length"), 10, 64); err == nil {
+		info.Size = size
+	}
+	if modified, err := http.ParseTime(res.Header.Get("Last-Modified")); err == nil {
+		info.LastModified = modified
+	}
+
+	return info
+}
+
+// listFolder menelusuri folder secara rekursif karena list api Supabase hanya satu level
+func (ss *SupabaseStorage) listFolder(folder, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+
+	for offset := 0; ; offset += supabaseListLimit {
+		files, err := ss.client.ListFiles(ss.config.Bucket, folder, storage_go.FileSearchOptions{
+			Limit:  supabaseListLimit,
+			Offset: offset,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			key := strings.TrimPrefix(folder+"/"+file.Name, "/")
+
+			// folder tidak punya id
+			if file.Id == "" {
+				if !strings.HasPrefix(key+"/", prefix) && !strings.HasPrefix(prefix, key+"/") {
+					continue
+				}
+
+				children, err := ss.listFolder(key, prefix)
+				if err != nil {
+					return nil, err
+				}
+				objects = append(objects, children...)
+				continue
+			}
+
+			if !strings.HasPrefix(key, prefix) {
+				continue
+			}
+
+			info := ObjectInfo{Key: key, ContentType: mime.TypeByExtension(path.Ext(key))}
+			if metadata, ok := file.Metadata.(map[string]interface{}); ok {
+				if size, ok := metadata["size"].(float64); ok {
+					info.Size = int64(size)
+				}
+				if contentType, ok := metadata["mimetype"].(string); ok && contentType != "" {
+					info.ContentType = contentType
+				}
+				if etag, ok := metadata["eTag"].(string); ok {
+					info.ETag = strings.Trim(etag, `"`)
+				}
+			}
+			if modified, err := time.Parse(time.RFC3339, file.UpdatedAt); err == nil {
+				info.LastModified = modified
+			}
+			objects = append(objects, info)
+		}
+
+		if len(files) < supabaseListLimit {
+			return objects, nil
+		}
+	}
+}
+
+func objectPath(bucket, key string) string {
+	return s3EscapePath(bucket + "/" + key)
+}